@@ -81,3 +81,60 @@ func (h *AddressHandler) GetAddressTxs(
 		},
 	}, nil
 }
+
+func (h *AddressHandler) GetAddressTokens(
+	ctx context.Context,
+	input *humatypes.AddressTokensGetInput,
+) (*humatypes.AddressTokensGetOutput, error) {
+	tokens, err := h.db.GetAddressTokens(ctx, input.Address, h.chainName)
+	if err != nil {
+		return nil, huma.Error404NotFound("Address not found", err)
+	}
+	return &humatypes.AddressTokensGetOutput{
+		Body: humatypes.AddressTokensBody{Tokens: tokens},
+	}, nil
+}
+
+func (h *AddressHandler) GetAddressNfts(
+	ctx context.Context,
+	input *humatypes.AddressNftsGetInput,
+) (*humatypes.AddressNftsGetOutput, error) {
+	nfts, err := h.db.GetAddressNfts(ctx, input.Address, h.chainName)
+	if err != nil {
+		return nil, huma.Error404NotFound("Address not found", err)
+	}
+	return &humatypes.AddressNftsGetOutput{
+		Body: humatypes.AddressNftsBody{Nfts: nfts},
+	}, nil
+}
+
+// GetAddress retrieves an address with its public key
+func (h *AddressHandler) GetAddress(
+	ctx context.Context,
+	input *humatypes.AddressInfoGetInput,
+) (*humatypes.AddressInfoGetOutput, error) {
+	addressInfo, err := h.db.GetAddress(ctx, input.Address, h.chainName)
+	if err != nil {
+		return nil, huma.Error404NotFound("Address not found", err)
+	}
+	return &humatypes.AddressInfoGetOutput{
+		Body: addressInfo,
+	}, nil
+}
+
+func (h *AddressHandler) GetAddressBalances(
+	ctx context.Context,
+	input *humatypes.AddressBalancesGetInput,
+) (*humatypes.AddressBalancesGetOutput, error) {
+	var atHeight *uint64
+	if input.AtHeight > 0 {
+		atHeight = &input.AtHeight
+	}
+	balances, err := h.db.GetAddressBalances(ctx, input.Address, h.chainName, atHeight)
+	if err != nil {
+		return nil, huma.Error404NotFound("Address not found", err)
+	}
+	return &humatypes.AddressBalancesGetOutput{
+		Body: humatypes.AddressBalancesBody{Balances: balances},
+	}, nil
+}
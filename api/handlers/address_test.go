@@ -41,6 +41,30 @@ func TestAddressHandler_GetAddressTxs_Success(t *testing.T) {
 	assert.Equal(t, "", response.Body.NextCursor)
 }
 
+func TestAddressHandler_GetAddress(t *testing.T) {
+	db := MockDatabase{
+		addresses: map[string]*database.AddressInfo{
+			"gno_address_1": {Address: "gno_address_1", PubKeyType: "/tm.PubKeySecp256k1", PubKey: "pub_key"},
+		},
+	}
+	handler := handlers.NewAddressHandler(&db, "gnoland")
+
+	response, err := handler.GetAddress(context.Background(), &humatypes.AddressInfoGetInput{
+		Address: "gno_address_1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/tm.PubKeySecp256k1", response.Body.PubKeyType)
+	assert.Equal(t, "pub_key", response.Body.PubKey)
+
+	// an address that was never indexed is not found
+	response, err = handler.GetAddress(context.Background(), &humatypes.AddressInfoGetInput{
+		Address: "gno_address_2",
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "Address not found")
+}
+
 func TestAddressHandler_GetAddressTxs_Fail(t *testing.T) {
 	db := MockDatabase{
 		shouldError: true,
@@ -57,3 +81,63 @@ func TestAddressHandler_GetAddressTxs_Fail(t *testing.T) {
 	assert.Nil(t, response)
 	assert.Contains(t, err.Error(), "Address not found")
 }
+
+func TestAddressHandler_GetAddressTokens(t *testing.T) {
+	db := MockDatabase{
+		tokenBalances: []*database.TokenBalance{
+			{TokenPath: "gno.land/r/demo/foo20.FOO", Address: "gno_address_1", Balance: "750"},
+			{TokenPath: "gno.land/r/demo/foo20.FOO", Address: "gno_address_2", Balance: "250"},
+		},
+	}
+	handler := handlers.NewAddressHandler(&db, "gnoland")
+	response, err := handler.GetAddressTokens(context.Background(), &humatypes.AddressTokensGetInput{
+		Address: "gno_address_1",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, response.Body.Tokens, 1)
+	assert.Equal(t, "750", response.Body.Tokens[0].Balance)
+}
+
+func TestAddressHandler_GetAddressNfts(t *testing.T) {
+	db := MockDatabase{
+		nfts: []*database.Nft{
+			{CollectionPath: "gno.land/r/demo/nft", TokenId: "1", Owner: "gno_address_1"},
+			{CollectionPath: "gno.land/r/demo/nft", TokenId: "2", Owner: "gno_address_2"},
+		},
+	}
+	handler := handlers.NewAddressHandler(&db, "gnoland")
+	response, err := handler.GetAddressNfts(context.Background(), &humatypes.AddressNftsGetInput{
+		Address: "gno_address_1",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, response.Body.Nfts, 1)
+	assert.Equal(t, "1", response.Body.Nfts[0].TokenId)
+}
+
+func TestAddressHandler_GetAddressBalances(t *testing.T) {
+	db := MockDatabase{
+		balances: map[string][]*database.NativeBalance{
+			"gno_address_1": {{Denom: "ugnot", Balance: "1000", LastHeight: 12}},
+		},
+	}
+	handler := handlers.NewAddressHandler(&db, "gnoland")
+
+	response, err := handler.GetAddressBalances(context.Background(), &humatypes.AddressBalancesGetInput{
+		Address: "gno_address_1",
+	})
+	require.NoError(t, err)
+	require.Len(t, response.Body.Balances, 1)
+	assert.Equal(t, "1000", response.Body.Balances[0].Balance)
+	assert.Nil(t, db.atHeight)
+
+	// the height is only passed to the database when it is set
+	_, err = handler.GetAddressBalances(context.Background(), &humatypes.AddressBalancesGetInput{
+		Address:  "gno_address_1",
+		AtHeight: 10,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, db.atHeight)
+	assert.Equal(t, uint64(10), *db.atHeight)
+}
@@ -73,6 +73,18 @@ func (h *BlocksHandler) GetAllBlockSigners(
 	return response, nil
 }
 
+// GetBlockEvents retrieves the BeginBlock and EndBlock events and the validator updates of a block
+func (h *BlocksHandler) GetBlockEvents(
+	ctx context.Context,
+	input *humatypes.BlockEventsGetInput,
+) (*humatypes.BlockEventsGetOutput, error) {
+	blockEvents, err := h.db.GetBlockEvents(ctx, input.BlockHeight, h.chainName)
+	if err != nil {
+		return nil, huma.Error404NotFound(fmt.Sprintf("Block events at height %d not found", input.BlockHeight), err)
+	}
+	return &humatypes.BlockEventsGetOutput{Body: blockEvents}, nil
+}
+
 // Get latest block height
 func (h *BlocksHandler) GetLatestBlock(ctx context.Context, _ *humatypes.LatestBlockHeightGetInput) (*humatypes.LatestBlockHeightGetOutput, error) {
 	block, err := h.db.GetLatestBlock(ctx, h.chainName)
@@ -93,6 +93,35 @@ func TestBlocksHandler_GetAllBlockSigners_Fail(t *testing.T) {
 	assert.Contains(t, err.Error(), "Block signers not found")
 }
 
+func TestBlocksHandler_GetBlockEvents_Success(t *testing.T) {
+	blockEvents := &database.BlockEvents{
+		Height:     42,
+		BeginBlock: []database.Event{},
+		EndBlock:   []database.Event{{AtType: "/tm.gnoEvent", Type: "ValidatorAdded"}},
+		ValidatorUpdates: []*database.Validator{
+			{Address: "val1", VotingPower: 10, PubKeyType: "/tm.PubKeyEd25519", PubKey: "cHVia2V5"},
+		},
+	}
+	db := MockDatabase{
+		blockEvents: map[uint64]*database.BlockEvents{42: blockEvents},
+	}
+	handler := handlers.NewBlocksHandler(&db, "gnoland")
+	response, err := handler.GetBlockEvents(context.Background(), &humatypes.BlockEventsGetInput{BlockHeight: 42})
+	assert.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, blockEvents, response.Body)
+}
+
+func TestBlocksHandler_GetBlockEvents_Fail(t *testing.T) {
+	db := MockDatabase{}
+	handler := handlers.NewBlocksHandler(&db, "gnoland")
+	response, err := handler.GetBlockEvents(context.Background(), &humatypes.BlockEventsGetInput{BlockHeight: 42})
+
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "Block events at height 42 not found")
+}
+
 func TestBlocksHandler_GetLatestBlockHeight_Success(t *testing.T) {
 	db := MockDatabase{
 		latestBlock: &database.BlockData{Height: 42, Hash: "abc123"},
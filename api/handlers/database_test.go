@@ -12,15 +12,37 @@ type MockDatabase struct {
 	blocks       map[uint64]*database.BlockData
 	transactions map[string]*database.Transaction
 	addressTxs   map[string]*[]database.AddressTx
+	addresses    map[string]*database.AddressInfo
 	blockSigners map[uint64]*database.BlockSigners
+	blockEvents  map[uint64]*database.BlockEvents
 	latestBlock  *database.BlockData
 
 	bankSend      map[string]*database.BankSend
 	msgCall       map[string]*database.MsgCall
 	msgAddPackage map[string]*database.MsgAddPackage
 	msgRun        map[string]*database.MsgRun
+	msgGeneric    map[string]*database.MsgGeneric
 	msgTypes      map[string][]string
 
+	packages       map[string]*database.PackageDetails
+	packageCalls   map[string][]*database.MsgCall
+	packageSources map[string]*database.PackageSource
+	packageFiles   map[string]*database.PackageFileContent
+
+	events []*database.TxEvent
+
+	tokens        []*database.Token
+	tokenBalances []*database.TokenBalance
+
+	nfts         []*database.Nft
+	balances     map[string][]*database.NativeBalance
+	atHeight     *uint64
+	nftTransfers []*database.NftTransfer
+
+	validatorSets []*database.ValidatorSetData
+	validators    map[string]*database.ValidatorDetails
+	uptime        *database.ValidatorsUptime
+
 	shouldError bool
 	errorMsg    string
 }
@@ -51,6 +73,17 @@ func (m *MockDatabase) GetFromToBlocks(ctx context.Context, fromHeight uint64, t
 	return result, nil
 }
 
+func (m *MockDatabase) GetBlockEvents(ctx context.Context, height uint64, chainName string) (*database.BlockEvents, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	blockEvents, ok := m.blockEvents[height]
+	if !ok {
+		return nil, fmt.Errorf("block not found")
+	}
+	return blockEvents, nil
+}
+
 func (m *MockDatabase) GetAllBlockSigners(ctx context.Context, chainName string, blockHeight uint64) (*database.BlockSigners, error) {
 	if m.shouldError {
 		return nil, fmt.Errorf("%s", m.errorMsg)
@@ -115,12 +148,15 @@ func (m *MockDatabase) GetLastXBlocks(ctx context.Context, chainName string, x u
 	return blocks, nil
 }
 
-func (m *MockDatabase) GetLastXTransactions(ctx context.Context, chainName string, x uint64) ([]*database.Transaction, error) {
+func (m *MockDatabase) GetLastXTransactions(ctx context.Context, chainName string, x uint64, failedOnly bool) ([]*database.Transaction, error) {
 	if m.shouldError {
 		return nil, fmt.Errorf("%s", m.errorMsg)
 	}
 	transactions := make([]*database.Transaction, 0, len(m.transactions))
 	for _, transaction := range m.transactions {
+		if failedOnly && transaction.Success {
+			continue
+		}
 		transactions = append(transactions, transaction)
 	}
 	return transactions, nil
@@ -181,12 +217,26 @@ func (m *MockDatabase) GetMsgRun(ctx context.Context, txHash string, chainName s
 	return []*database.MsgRun{msgRun}, nil
 }
 
-func (m *MockDatabase) GetTransactionsByCursor(ctx context.Context, chainName string, cursor string, limit uint64) ([]*database.Transaction, error) {
+func (m *MockDatabase) GetMsgGeneric(ctx context.Context, txHash string, chainName string) ([]*database.MsgGeneric, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	msgGeneric, ok := m.msgGeneric[txHash]
+	if !ok {
+		return nil, fmt.Errorf("generic message not found")
+	}
+	return []*database.MsgGeneric{msgGeneric}, nil
+}
+
+func (m *MockDatabase) GetTransactionsByCursor(ctx context.Context, chainName string, cursor string, limit uint64, failedOnly bool) ([]*database.Transaction, error) {
 	if m.shouldError {
 		return nil, fmt.Errorf("%s", m.errorMsg)
 	}
 	transactions := make([]*database.Transaction, 0, len(m.transactions))
 	for _, transaction := range m.transactions {
+		if failedOnly && transaction.Success {
+			continue
+		}
 		transactions = append(transactions, transaction)
 	}
 	return transactions, nil
@@ -248,3 +298,289 @@ func (m *MockDatabase) GetDailyActiveAccount(ctx context.Context, chainName stri
 	return []*database.DailyActiveAccount{}, nil
 }
 
+func (m *MockDatabase) GetPackageSource(ctx context.Context, pkgPath string, chainName string, txHash *string) (*database.PackageSource, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	source, ok := m.packageSources[pkgPath]
+	if !ok {
+		return nil, fmt.Errorf("package not found")
+	}
+	if txHash != nil && *txHash != source.TxHash {
+		return nil, fmt.Errorf("deployment not found")
+	}
+	return source, nil
+}
+
+func (m *MockDatabase) GetPackageFile(
+	ctx context.Context,
+	pkgPath string,
+	fileName string,
+	chainName string,
+	txHash *string,
+) (*database.PackageFileContent, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	file, ok := m.packageFiles[pkgPath+"/"+fileName]
+	if !ok {
+		return nil, fmt.Errorf("file not found")
+	}
+	return file, nil
+}
+
+func (m *MockDatabase) GetPackages(ctx context.Context, chainName string, limit uint64, page uint64) ([]*database.Package, uint64, error) {
+	if m.shouldError {
+		return nil, 0, fmt.Errorf("%s", m.errorMsg)
+	}
+	packages := make([]*database.Package, 0, len(m.packages))
+	for _, pkg := range m.packages {
+		packages = append(packages, &pkg.Package)
+	}
+	return packages, uint64(len(packages)), nil
+}
+
+func (m *MockDatabase) GetPackage(ctx context.Context, pkgPath string, chainName string) (*database.PackageDetails, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	pkg, ok := m.packages[pkgPath]
+	if !ok {
+		return nil, fmt.Errorf("package not found")
+	}
+	return pkg, nil
+}
+
+func (m *MockDatabase) GetPackageCalls(
+	ctx context.Context,
+	pkgPath string,
+	chainName string,
+	cursor *string,
+	limit uint64,
+) ([]*database.MsgCall, string, error) {
+	if m.shouldError {
+		return nil, "", fmt.Errorf("%s", m.errorMsg)
+	}
+	calls := m.packageCalls[pkgPath]
+	if uint64(len(calls)) > limit {
+		return calls[:limit], "next", nil
+	}
+	return calls, "", nil
+}
+
+func (m *MockDatabase) GetEvents(
+	ctx context.Context,
+	chainName string,
+	filter database.EventFilter,
+	cursor *string,
+	limit uint64,
+) ([]*database.TxEvent, string, error) {
+	if m.shouldError {
+		return nil, "", fmt.Errorf("%s", m.errorMsg)
+	}
+	events := make([]*database.TxEvent, 0)
+	for _, event := range m.events {
+		if filter.EventType != nil && event.Type != *filter.EventType {
+			continue
+		}
+		if filter.PkgPath != nil && event.PkgPath != *filter.PkgPath {
+			continue
+		}
+		events = append(events, event)
+	}
+	if uint64(len(events)) > limit {
+		return events[:limit], "next", nil
+	}
+	return events, "", nil
+}
+
+func (m *MockDatabase) GetTokens(ctx context.Context, chainName string, limit uint64, page uint64) ([]*database.Token, uint64, error) {
+	if m.shouldError {
+		return nil, 0, fmt.Errorf("%s", m.errorMsg)
+	}
+	return m.tokens, uint64(len(m.tokens)), nil
+}
+
+func (m *MockDatabase) GetTokenHolders(
+	ctx context.Context,
+	tokenPath string,
+	chainName string,
+	limit uint64,
+	page uint64,
+) ([]*database.TokenBalance, uint64, error) {
+	if m.shouldError {
+		return nil, 0, fmt.Errorf("%s", m.errorMsg)
+	}
+	holders := make([]*database.TokenBalance, 0)
+	for _, balance := range m.tokenBalances {
+		if balance.TokenPath == tokenPath {
+			holders = append(holders, balance)
+		}
+	}
+	return holders, uint64(len(holders)), nil
+}
+
+func (m *MockDatabase) GetAddressTokens(ctx context.Context, address string, chainName string) ([]*database.TokenBalance, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	tokens := make([]*database.TokenBalance, 0)
+	for _, balance := range m.tokenBalances {
+		if balance.Address == address {
+			tokens = append(tokens, balance)
+		}
+	}
+	return tokens, nil
+}
+
+func (m *MockDatabase) GetCollectionTokens(
+	ctx context.Context,
+	collectionPath string,
+	chainName string,
+	limit uint64,
+	page uint64,
+) ([]*database.Nft, uint64, error) {
+	if m.shouldError {
+		return nil, 0, fmt.Errorf("%s", m.errorMsg)
+	}
+	tokens := make([]*database.Nft, 0)
+	for _, nft := range m.nfts {
+		if nft.CollectionPath == collectionPath {
+			tokens = append(tokens, nft)
+		}
+	}
+	return tokens, uint64(len(tokens)), nil
+}
+
+func (m *MockDatabase) GetNftHistory(
+	ctx context.Context,
+	collectionPath string,
+	tokenId string,
+	chainName string,
+) ([]*database.NftTransfer, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	transfers := make([]*database.NftTransfer, 0)
+	for _, transfer := range m.nftTransfers {
+		if transfer.CollectionPath == collectionPath && transfer.TokenId == tokenId {
+			transfers = append(transfers, transfer)
+		}
+	}
+	return transfers, nil
+}
+
+func (m *MockDatabase) GetAddressNfts(ctx context.Context, address string, chainName string) ([]*database.Nft, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	nfts := make([]*database.Nft, 0)
+	for _, nft := range m.nfts {
+		if nft.Owner == address {
+			nfts = append(nfts, nft)
+		}
+	}
+	return nfts, nil
+}
+
+func (m *MockDatabase) GetAddress(ctx context.Context, address string, chainName string) (*database.AddressInfo, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	addressInfo, ok := m.addresses[address]
+	if !ok {
+		return nil, fmt.Errorf("address not found")
+	}
+	return addressInfo, nil
+}
+
+func (m *MockDatabase) GetAddressBalances(
+	ctx context.Context,
+	address string,
+	chainName string,
+	atHeight *uint64,
+) ([]*database.NativeBalance, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	m.atHeight = atHeight
+	if balances, ok := m.balances[address]; ok {
+		return balances, nil
+	}
+	return []*database.NativeBalance{}, nil
+}
+
+func (m *MockDatabase) GetValidatorSigning24h(
+	ctx context.Context,
+	validatorAddress string,
+	chainName string,
+) (*database.ValidatorSigning, error) {
+	return nil, fmt.Errorf("signing data not found")
+}
+
+func (m *MockDatabase) GetValidatorSigningByHour(
+	ctx context.Context,
+	validatorAddress string,
+	chainName string,
+	date1 time.Time,
+	date2 time.Time,
+) ([]*database.ValidatorSigning, error) {
+	return nil, fmt.Errorf("signing data not found")
+}
+
+// GetValidatorSet returns the latest of the validator sets stored at or below the height
+func (m *MockDatabase) GetValidatorSet(
+	ctx context.Context,
+	chainName string,
+	atHeight *uint64,
+) (*database.ValidatorSetData, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	var result *database.ValidatorSetData
+	for _, validatorSet := range m.validatorSets {
+		if atHeight != nil && validatorSet.Height > *atHeight {
+			continue
+		}
+		if result == nil || validatorSet.Height > result.Height {
+			result = validatorSet
+		}
+	}
+	if result == nil {
+		return nil, fmt.Errorf("no validator set stored")
+	}
+	return result, nil
+}
+
+func (m *MockDatabase) GetValidator(
+	ctx context.Context,
+	validatorAddress string,
+	chainName string,
+) (*database.ValidatorDetails, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	validator, ok := m.validators[validatorAddress]
+	if !ok {
+		return nil, fmt.Errorf("validator seems to not exist")
+	}
+	return validator, nil
+}
+
+func (m *MockDatabase) GetValidatorsUptime(
+	ctx context.Context,
+	chainName string,
+	blocks uint64,
+) (*database.ValidatorsUptime, error) {
+	if m.shouldError {
+		return nil, fmt.Errorf("%s", m.errorMsg)
+	}
+	if m.uptime == nil {
+		return nil, fmt.Errorf("no validator block signings stored")
+	}
+	uptime := *m.uptime
+	if uptime.ToHeight > blocks {
+		uptime.FromHeight = uptime.ToHeight - blocks + 1
+	}
+	return &uptime, nil
+}
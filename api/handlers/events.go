@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+
+	humatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/huma-types"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// EventsHandler handles the requests for the events emitted by the transactions
+type EventsHandler struct {
+	db        EventDbHandler
+	chainName string
+}
+
+// NewEventsHandler creates a new events handler
+func NewEventsHandler(db EventDbHandler, chainName string) *EventsHandler {
+	return &EventsHandler{db: db, chainName: chainName}
+}
+
+// GetEvents pages through the events that match the filters, newest first
+func (h *EventsHandler) GetEvents(
+	ctx context.Context,
+	input *humatypes.EventsGetInput,
+) (*humatypes.EventsGetOutput, error) {
+	if input.AttrValue != "" && input.AttrKey == "" {
+		return nil, huma.Error400BadRequest("attr_value needs the attr_key", nil)
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = 10
+	}
+	var cursor *string
+	if input.Cursor != "" {
+		cursor = &input.Cursor
+	}
+	filter := database.EventFilter{
+		EventType: optionalString(input.Type),
+		PkgPath:   optionalString(input.PkgPath),
+		AttrKey:   optionalString(input.AttrKey),
+		AttrValue: optionalString(input.AttrValue),
+	}
+	events, nextCursor, err := h.db.GetEvents(ctx, h.chainName, filter, cursor, limit)
+	if err != nil {
+		return nil, huma.Error404NotFound("Events not found", err)
+	}
+	return &humatypes.EventsGetOutput{
+		Body: humatypes.EventsBody{Events: events, NextCursor: nextCursor},
+	}, nil
+}
+
+// optionalString returns nil for an empty query parameter
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,49 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/handlers"
+	humatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/huma-types"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsHandler_GetEvents(t *testing.T) {
+	db := MockDatabase{
+		events: []*database.TxEvent{
+			{EventIndex: 0, Event: database.Event{Type: "Transfer", PkgPath: "gno.land/r/demo/foo20"}},
+			{EventIndex: 1, Event: database.Event{Type: "Approval", PkgPath: "gno.land/r/demo/foo20"}},
+			{EventIndex: 0, Event: database.Event{Type: "Transfer", PkgPath: "gno.land/r/demo/bar20"}},
+		},
+	}
+	handler := handlers.NewEventsHandler(&db, "gnoland")
+
+	response, err := handler.GetEvents(context.Background(), &humatypes.EventsGetInput{Type: "Transfer"})
+	require.NoError(t, err)
+	assert.Len(t, response.Body.Events, 2)
+	assert.Empty(t, response.Body.NextCursor)
+
+	response, err = handler.GetEvents(context.Background(), &humatypes.EventsGetInput{
+		PkgPath: "gno.land/r/demo/foo20",
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	assert.Len(t, response.Body.Events, 1)
+	assert.Equal(t, "next", response.Body.NextCursor)
+}
+
+func TestEventsHandler_GetEvents_Fail(t *testing.T) {
+	db := MockDatabase{shouldError: true, errorMsg: "error getting events"}
+	handler := handlers.NewEventsHandler(&db, "gnoland")
+
+	_, err := handler.GetEvents(context.Background(), &humatypes.EventsGetInput{AttrValue: "g1from"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "attr_key")
+
+	_, err = handler.GetEvents(context.Background(), &humatypes.EventsGetInput{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
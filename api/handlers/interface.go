@@ -8,6 +8,7 @@ import (
 )
 
 type AddressDbHandler interface {
+	GetAddress(ctx context.Context, address string, chainName string) (*database.AddressInfo, error)
 	GetAddressTxs(
 		ctx context.Context,
 		address string,
@@ -24,6 +25,14 @@ type AddressDbHandler interface {
 		date1 time.Time,
 		date2 time.Time,
 	) ([]*database.DailyActiveAccount, error)
+	GetAddressTokens(ctx context.Context, address string, chainName string) ([]*database.TokenBalance, error)
+	GetAddressNfts(ctx context.Context, address string, chainName string) ([]*database.Nft, error)
+	GetAddressBalances(
+		ctx context.Context,
+		address string,
+		chainName string,
+		atHeight *uint64,
+	) ([]*database.NativeBalance, error)
 }
 
 type ValidatorDbHandler interface {
@@ -39,12 +48,16 @@ type ValidatorDbHandler interface {
 		date1 time.Time,
 		date2 time.Time,
 	) ([]*database.ValidatorSigning, error)
+	GetValidatorSet(ctx context.Context, chainName string, atHeight *uint64) (*database.ValidatorSetData, error)
+	GetValidator(ctx context.Context, validatorAddress string, chainName string) (*database.ValidatorDetails, error)
+	GetValidatorsUptime(ctx context.Context, chainName string, blocks uint64) (*database.ValidatorsUptime, error)
 }
 
 type BlockDbHandler interface {
 	GetBlock(ctx context.Context, height uint64, chainName string) (*database.BlockData, error)
 	GetFromToBlocks(ctx context.Context, fromHeight uint64, toHeight uint64, chainName string) ([]*database.BlockData, error)
 	GetAllBlockSigners(ctx context.Context, chainName string, blockHeight uint64) (*database.BlockSigners, error)
+	GetBlockEvents(ctx context.Context, height uint64, chainName string) (*database.BlockEvents, error)
 	GetLatestBlock(ctx context.Context, chainName string) (*database.BlockData, error)
 	GetLastXBlocks(ctx context.Context, chainName string, x uint64) ([]*database.BlockData, error)
 	GetBlockCount24h(ctx context.Context, chainName string) (int64, error)
@@ -53,16 +66,69 @@ type BlockDbHandler interface {
 
 type TransactionDbHandler interface {
 	GetTransaction(ctx context.Context, txHash string, chainName string) (*database.Transaction, error)
-	GetLastXTransactions(ctx context.Context, chainName string, x uint64) ([]*database.Transaction, error)
+	GetLastXTransactions(ctx context.Context, chainName string, x uint64, failedOnly bool) ([]*database.Transaction, error)
 	GetMsgTypes(ctx context.Context, txHash string, chainName string) ([]string, error)
 	GetBankSend(ctx context.Context, txHash string, chainName string) ([]*database.BankSend, error)
 	GetMsgCall(ctx context.Context, txHash string, chainName string) ([]*database.MsgCall, error)
 	GetMsgAddPackage(ctx context.Context, txHash string, chainName string) ([]*database.MsgAddPackage, error)
 	GetMsgRun(ctx context.Context, txHash string, chainName string) ([]*database.MsgRun, error)
-	GetTransactionsByCursor(ctx context.Context, chainName string, cursor string, limit uint64) ([]*database.Transaction, error)
+	GetMsgGeneric(ctx context.Context, txHash string, chainName string) ([]*database.MsgGeneric, error)
+	GetTransactionsByCursor(ctx context.Context, chainName string, cursor string, limit uint64, failedOnly bool) ([]*database.Transaction, error)
 	GetTotalTxCount24h(ctx context.Context, chainName string) (int64, error)
 	GetTotalTxCountByDate(ctx context.Context, chainName string, date1 time.Time, date2 time.Time) ([]*database.TxCountTimeRange, error)
 	GetTotalTxCountByHour(ctx context.Context, chainName string, date1 time.Time, date2 time.Time) ([]*database.TxCountTimeRange, error)
 	GetVolumeByDate(ctx context.Context, chainName string, date1 time.Time, date2 time.Time) (database.VolumeByDenom, error)
 	GetVolumeByHour(ctx context.Context, chainName string, date1 time.Time, date2 time.Time) (database.VolumeByDenom, error)
 }
+
+type PackageDbHandler interface {
+	GetPackages(ctx context.Context, chainName string, limit uint64, page uint64) ([]*database.Package, uint64, error)
+	GetPackage(ctx context.Context, pkgPath string, chainName string) (*database.PackageDetails, error)
+	GetPackageCalls(
+		ctx context.Context,
+		pkgPath string,
+		chainName string,
+		cursor *string,
+		limit uint64,
+	) ([]*database.MsgCall, string, error)
+	GetPackageSource(ctx context.Context, pkgPath string, chainName string, txHash *string) (*database.PackageSource, error)
+	GetPackageFile(
+		ctx context.Context,
+		pkgPath string,
+		fileName string,
+		chainName string,
+		txHash *string,
+	) (*database.PackageFileContent, error)
+}
+
+type EventDbHandler interface {
+	GetEvents(
+		ctx context.Context,
+		chainName string,
+		filter database.EventFilter,
+		cursor *string,
+		limit uint64,
+	) ([]*database.TxEvent, string, error)
+}
+
+type TokenDbHandler interface {
+	GetTokens(ctx context.Context, chainName string, limit uint64, page uint64) ([]*database.Token, uint64, error)
+	GetTokenHolders(
+		ctx context.Context,
+		tokenPath string,
+		chainName string,
+		limit uint64,
+		page uint64,
+	) ([]*database.TokenBalance, uint64, error)
+}
+
+type NftDbHandler interface {
+	GetCollectionTokens(
+		ctx context.Context,
+		collectionPath string,
+		chainName string,
+		limit uint64,
+		page uint64,
+	) ([]*database.Nft, uint64, error)
+	GetNftHistory(ctx context.Context, collectionPath string, tokenId string, chainName string) ([]*database.NftTransfer, error)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	humatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/huma-types"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// NftsHandler handles the requests for the GRC721 collections
+type NftsHandler struct {
+	db        NftDbHandler
+	chainName string
+}
+
+// NewNftsHandler creates a new NFTs handler
+func NewNftsHandler(db NftDbHandler, chainName string) *NftsHandler {
+	return &NftsHandler{db: db, chainName: chainName}
+}
+
+// GetCollectionTokens lists the tokens of a collection with their current owner
+func (h *NftsHandler) GetCollectionTokens(
+	ctx context.Context,
+	input *humatypes.CollectionTokensGetInput,
+) (*humatypes.CollectionTokensGetOutput, error) {
+	collectionPath, err := url.PathUnescape(input.PkgPath)
+	if err != nil || collectionPath == "" {
+		return nil, huma.Error400BadRequest("pkg_path is not a valid url encoded collection path", err)
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = 10
+	}
+	tokens, count, err := h.db.GetCollectionTokens(ctx, collectionPath, h.chainName, limit, input.Page)
+	if err != nil {
+		return nil, huma.Error404NotFound(fmt.Sprintf("Tokens of collection %s not found", collectionPath), err)
+	}
+	return &humatypes.CollectionTokensGetOutput{
+		Body: humatypes.CollectionTokensBody{Tokens: tokens, TokenCount: count},
+	}, nil
+}
+
+// GetNftHistory returns every transfer of a token, the latest first
+func (h *NftsHandler) GetNftHistory(
+	ctx context.Context,
+	input *humatypes.NftHistoryGetInput,
+) (*humatypes.NftHistoryGetOutput, error) {
+	collectionPath, err := url.PathUnescape(input.PkgPath)
+	if err != nil || collectionPath == "" {
+		return nil, huma.Error400BadRequest("pkg_path is not a valid url encoded collection path", err)
+	}
+	tokenId, err := url.PathUnescape(input.TokenId)
+	if err != nil || tokenId == "" {
+		return nil, huma.Error400BadRequest("token_id is not a valid url encoded token id", err)
+	}
+	transfers, err := h.db.GetNftHistory(ctx, collectionPath, tokenId, h.chainName)
+	if err != nil {
+		return nil, huma.Error404NotFound(fmt.Sprintf("Token %s of collection %s not found", tokenId, collectionPath), err)
+	}
+	if len(transfers) == 0 {
+		return nil, huma.Error404NotFound(fmt.Sprintf("Token %s of collection %s not found", tokenId, collectionPath))
+	}
+	return &humatypes.NftHistoryGetOutput{
+		Body: humatypes.NftHistoryBody{Transfers: transfers},
+	}, nil
+}
@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/handlers"
+	humatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/huma-types"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNftsHandler_GetCollectionTokens(t *testing.T) {
+	db := MockDatabase{
+		nfts: []*database.Nft{
+			{CollectionPath: "gno.land/r/demo/nft", TokenId: "1", Owner: "gno_address_1"},
+			{CollectionPath: "gno.land/r/demo/nft", TokenId: "2", Burned: true},
+			{CollectionPath: "gno.land/r/demo/other", TokenId: "1", Owner: "gno_address_1"},
+		},
+	}
+	handler := handlers.NewNftsHandler(&db, "gnoland")
+
+	response, err := handler.GetCollectionTokens(context.Background(), &humatypes.CollectionTokensGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fnft",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), response.Body.TokenCount)
+	assert.True(t, response.Body.Tokens[1].Burned)
+
+	db.shouldError = true
+	db.errorMsg = "error getting tokens"
+	_, err = handler.GetCollectionTokens(context.Background(), &humatypes.CollectionTokensGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fnft",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestNftsHandler_GetNftHistory(t *testing.T) {
+	db := MockDatabase{
+		nftTransfers: []*database.NftTransfer{
+			{CollectionPath: "gno.land/r/demo/nft", TokenId: "1", EventType: "Transfer", From: "gno_address_1", To: "gno_address_2"},
+			{CollectionPath: "gno.land/r/demo/nft", TokenId: "1", EventType: "Mint", To: "gno_address_1"},
+		},
+	}
+	handler := handlers.NewNftsHandler(&db, "gnoland")
+
+	response, err := handler.GetNftHistory(context.Background(), &humatypes.NftHistoryGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fnft",
+		TokenId: "1",
+	})
+	require.NoError(t, err)
+	require.Len(t, response.Body.Transfers, 2)
+	assert.Equal(t, "Mint", response.Body.Transfers[1].EventType)
+
+	// a token without any transfer is not known
+	_, err = handler.GetNftHistory(context.Background(), &humatypes.NftHistoryGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fnft",
+		TokenId: "2",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
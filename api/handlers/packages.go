@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	humatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/huma-types"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// PackagesHandler handles the requests for the deployed packages
+type PackagesHandler struct {
+	db        PackageDbHandler
+	chainName string
+}
+
+// NewPackagesHandler creates a new packages handler
+func NewPackagesHandler(db PackageDbHandler, chainName string) *PackagesHandler {
+	return &PackagesHandler{db: db, chainName: chainName}
+}
+
+// GetPackages lists the packages, the latest deployed packages come first
+func (h *PackagesHandler) GetPackages(
+	ctx context.Context,
+	input *humatypes.PackagesGetInput,
+) (*humatypes.PackagesGetOutput, error) {
+	limit := input.Limit
+	if limit == 0 {
+		limit = 10
+	}
+	packages, count, err := h.db.GetPackages(ctx, h.chainName, limit, input.Page)
+	if err != nil {
+		return nil, huma.Error404NotFound("Packages not found", err)
+	}
+	return &humatypes.PackagesGetOutput{
+		Body: humatypes.PackagesBody{Packages: packages, PackageCount: count},
+	}, nil
+}
+
+// GetPackage returns a package with its deployment history
+func (h *PackagesHandler) GetPackage(
+	ctx context.Context,
+	input *humatypes.PackageGetInput,
+) (*humatypes.PackageGetOutput, error) {
+	pkgPath, _, err := parsePackageInput(input.PkgPath, "")
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := h.db.GetPackage(ctx, pkgPath, h.chainName)
+	if err != nil {
+		return nil, huma.Error404NotFound(fmt.Sprintf("Package %s not found", pkgPath), err)
+	}
+	return &humatypes.PackageGetOutput{Body: pkg}, nil
+}
+
+// GetPackageCalls pages through the MsgCall messages that call the package
+func (h *PackagesHandler) GetPackageCalls(
+	ctx context.Context,
+	input *humatypes.PackageCallsGetInput,
+) (*humatypes.PackageCallsGetOutput, error) {
+	pkgPath, _, err := parsePackageInput(input.PkgPath, "")
+	if err != nil {
+		return nil, err
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = 10
+	}
+	var cursor *string
+	if input.Cursor != "" {
+		cursor = &input.Cursor
+	}
+	calls, nextCursor, err := h.db.GetPackageCalls(ctx, pkgPath, h.chainName, cursor, limit)
+	if err != nil {
+		return nil, huma.Error404NotFound(fmt.Sprintf("Calls of package %s not found", pkgPath), err)
+	}
+	return &humatypes.PackageCallsGetOutput{
+		Body: humatypes.PackageCallsBody{Calls: calls, NextCursor: nextCursor},
+	}, nil
+}
+
+// GetPackageSource lists the source files of a package as of the latest or the given deployment
+func (h *PackagesHandler) GetPackageSource(
+	ctx context.Context,
+	input *humatypes.PackageSourceGetInput,
+) (*humatypes.PackageSourceGetOutput, error) {
+	pkgPath, txHash, err := parsePackageInput(input.PkgPath, input.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	source, err := h.db.GetPackageSource(ctx, pkgPath, h.chainName, txHash)
+	if err != nil {
+		return nil, huma.Error404NotFound(fmt.Sprintf("Package %s not found", pkgPath), err)
+	}
+	return &humatypes.PackageSourceGetOutput{Body: source}, nil
+}
+
+// GetPackageFile downloads a single source file of a package as of the latest or the given deployment
+func (h *PackagesHandler) GetPackageFile(
+	ctx context.Context,
+	input *humatypes.PackageFileGetInput,
+) (*humatypes.PackageFileGetOutput, error) {
+	pkgPath, txHash, err := parsePackageInput(input.PkgPath, input.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	if input.FileName == "" {
+		return nil, huma.Error400BadRequest("file_name is required", nil)
+	}
+	file, err := h.db.GetPackageFile(ctx, pkgPath, input.FileName, h.chainName, txHash)
+	if err != nil {
+		return nil, huma.Error404NotFound(
+			fmt.Sprintf("File %s of package %s not found", input.FileName, pkgPath), err)
+	}
+	return &humatypes.PackageFileGetOutput{
+		ContentType: "text/plain; charset=utf-8",
+		FileHash:    file.Hash,
+		Body:        file.Content,
+	}, nil
+}
+
+// parsePackageInput unescapes the package path and converts the optional tx hash to base64
+func parsePackageInput(rawPkgPath string, rawTxHash string) (string, *string, error) {
+	pkgPath, err := url.PathUnescape(rawPkgPath)
+	if err != nil || pkgPath == "" {
+		return "", nil, huma.Error400BadRequest("pkg_path is not a valid url encoded package path", err)
+	}
+
+	rawTxHash = strings.Trim(rawTxHash, " ")
+	if rawTxHash == "" {
+		return pkgPath, nil, nil
+	}
+	decoded, err := base64.URLEncoding.DecodeString(rawTxHash)
+	if err != nil {
+		return "", nil, huma.Error400BadRequest("Transaction hash is not valid base64url encoded", err)
+	}
+	txHash := base64.StdEncoding.EncodeToString(decoded)
+	return pkgPath, &txHash, nil
+}
@@ -0,0 +1,151 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/handlers"
+	humatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/huma-types"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackagesHandler_GetPackages(t *testing.T) {
+	db := MockDatabase{
+		packages: map[string]*database.PackageDetails{
+			"gno.land/r/demo/boards": {Package: database.Package{PkgPath: "gno.land/r/demo/boards", PkgName: "boards"}},
+		},
+	}
+	handler := handlers.NewPackagesHandler(&db, "gnoland")
+
+	response, err := handler.GetPackages(context.Background(), &humatypes.PackagesGetInput{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), response.Body.PackageCount)
+	assert.Equal(t, "boards", response.Body.Packages[0].PkgName)
+}
+
+func TestPackagesHandler_GetPackage(t *testing.T) {
+	db := MockDatabase{
+		packages: map[string]*database.PackageDetails{
+			"gno.land/r/demo/boards": {
+				Package:     database.Package{PkgPath: "gno.land/r/demo/boards", FirstHeight: 10},
+				Deployments: []database.PackageDeployment{{BlockHeight: 10, Success: true}},
+			},
+		},
+	}
+	handler := handlers.NewPackagesHandler(&db, "gnoland")
+
+	response, err := handler.GetPackage(context.Background(), &humatypes.PackageGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fboards",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), response.Body.FirstHeight)
+	assert.Len(t, response.Body.Deployments, 1)
+
+	_, err = handler.GetPackage(context.Background(), &humatypes.PackageGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fmissing",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestPackagesHandler_GetPackageCalls(t *testing.T) {
+	db := MockDatabase{
+		packageCalls: map[string][]*database.MsgCall{
+			"gno.land/r/demo/boards": {
+				{FuncName: "CreateBoard"},
+				{FuncName: "CreateThread"},
+			},
+		},
+	}
+	handler := handlers.NewPackagesHandler(&db, "gnoland")
+
+	response, err := handler.GetPackageCalls(context.Background(), &humatypes.PackageCallsGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fboards",
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	assert.Len(t, response.Body.Calls, 1)
+	assert.Equal(t, "next", response.Body.NextCursor)
+
+	db.shouldError = true
+	db.errorMsg = "error getting calls"
+	_, err = handler.GetPackageCalls(context.Background(), &humatypes.PackageCallsGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fboards",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestPackagesHandler_GetPackageSource_Success(t *testing.T) {
+	txHash := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	db := MockDatabase{
+		packageSources: map[string]*database.PackageSource{
+			"gno.land/r/demo/boards": {
+				PkgPath: "gno.land/r/demo/boards",
+				TxHash:  txHash,
+				MsgType: "vm_msg_add_package",
+				Files:   []database.PackageSourceFile{{Name: "boards.gno", Size: 10, Archived: true}},
+			},
+		},
+	}
+	handler := handlers.NewPackagesHandler(&db, "gnoland")
+
+	// the package path comes url encoded
+	response, err := handler.GetPackageSource(context.Background(), &humatypes.PackageSourceGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fboards",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "boards.gno", response.Body.Files[0].Name)
+
+	// the tx hash is base64url encoded and converted to base64
+	response, err = handler.GetPackageSource(context.Background(), &humatypes.PackageSourceGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fboards",
+		TxHash:  base64.URLEncoding.EncodeToString(make([]byte, 32)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, txHash, response.Body.TxHash)
+}
+
+func TestPackagesHandler_GetPackageSource_Fail(t *testing.T) {
+	handler := handlers.NewPackagesHandler(&MockDatabase{}, "gnoland")
+
+	_, err := handler.GetPackageSource(context.Background(), &humatypes.PackageSourceGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fmissing",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	_, err = handler.GetPackageSource(context.Background(), &humatypes.PackageSourceGetInput{
+		PkgPath: "gno.land%2Fr%2Fdemo%2Fboards",
+		TxHash:  "not base64!",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "base64url")
+}
+
+func TestPackagesHandler_GetPackageFile(t *testing.T) {
+	db := MockDatabase{
+		packageFiles: map[string]*database.PackageFileContent{
+			"gno.land/r/demo/boards/boards.gno": {Name: "boards.gno", Hash: "hash", Content: []byte("package boards")},
+		},
+	}
+	handler := handlers.NewPackagesHandler(&db, "gnoland")
+
+	response, err := handler.GetPackageFile(context.Background(), &humatypes.PackageFileGetInput{
+		PkgPath:  "gno.land%2Fr%2Fdemo%2Fboards",
+		FileName: "boards.gno",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "package boards", string(response.Body))
+	assert.Contains(t, response.ContentType, "text/plain")
+
+	_, err = handler.GetPackageFile(context.Background(), &humatypes.PackageFileGetInput{
+		PkgPath:  "gno.land%2Fr%2Fdemo%2Fboards",
+		FileName: "missing.gno",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
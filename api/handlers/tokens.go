@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	humatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/huma-types"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// TokensHandler handles the requests for the GRC20 tokens
+type TokensHandler struct {
+	db        TokenDbHandler
+	chainName string
+}
+
+// NewTokensHandler creates a new tokens handler
+func NewTokensHandler(db TokenDbHandler, chainName string) *TokensHandler {
+	return &TokensHandler{db: db, chainName: chainName}
+}
+
+// GetTokens lists the GRC20 tokens, the tokens with the most holders come first
+func (h *TokensHandler) GetTokens(
+	ctx context.Context,
+	input *humatypes.TokensGetInput,
+) (*humatypes.TokensGetOutput, error) {
+	limit := input.Limit
+	if limit == 0 {
+		limit = 10
+	}
+	tokens, count, err := h.db.GetTokens(ctx, h.chainName, limit, input.Page)
+	if err != nil {
+		return nil, huma.Error404NotFound("Tokens not found", err)
+	}
+	return &humatypes.TokensGetOutput{
+		Body: humatypes.TokensBody{Tokens: tokens, TokenCount: count},
+	}, nil
+}
+
+// GetTokenHolders lists the holders of a GRC20 token, the largest balances come first
+func (h *TokensHandler) GetTokenHolders(
+	ctx context.Context,
+	input *humatypes.TokenHoldersGetInput,
+) (*humatypes.TokenHoldersGetOutput, error) {
+	tokenPath, err := url.PathUnescape(input.TokenPath)
+	if err != nil || tokenPath == "" {
+		return nil, huma.Error400BadRequest("pkg_path is not a valid url encoded token path", err)
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = 10
+	}
+	holders, count, err := h.db.GetTokenHolders(ctx, tokenPath, h.chainName, limit, input.Page)
+	if err != nil {
+		return nil, huma.Error404NotFound(fmt.Sprintf("Holders of token %s not found", tokenPath), err)
+	}
+	return &humatypes.TokenHoldersGetOutput{
+		Body: humatypes.TokenHoldersBody{Holders: holders, HolderCount: count},
+	}, nil
+}
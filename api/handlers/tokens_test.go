@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/handlers"
+	humatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/huma-types"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokensHandler_GetTokens(t *testing.T) {
+	db := MockDatabase{
+		tokens: []*database.Token{{TokenPath: "gno.land/r/demo/foo20.FOO", HolderCount: 2, Supply: "1000"}},
+	}
+	handler := handlers.NewTokensHandler(&db, "gnoland")
+
+	response, err := handler.GetTokens(context.Background(), &humatypes.TokensGetInput{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), response.Body.TokenCount)
+	assert.Equal(t, "1000", response.Body.Tokens[0].Supply)
+
+	db.shouldError = true
+	db.errorMsg = "error getting tokens"
+	_, err = handler.GetTokens(context.Background(), &humatypes.TokensGetInput{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestTokensHandler_GetTokenHolders(t *testing.T) {
+	db := MockDatabase{
+		tokenBalances: []*database.TokenBalance{
+			{TokenPath: "gno.land/r/demo/foo20.FOO", Address: "gno_address_1", Balance: "750"},
+			{TokenPath: "gno.land/r/demo/foo20.FOO", Address: "gno_address_2", Balance: "250"},
+			{TokenPath: "gno.land/r/demo/bar20.BAR", Address: "gno_address_1", Balance: "1"},
+		},
+	}
+	handler := handlers.NewTokensHandler(&db, "gnoland")
+
+	response, err := handler.GetTokenHolders(context.Background(), &humatypes.TokenHoldersGetInput{
+		TokenPath: "gno.land%2Fr%2Fdemo%2Ffoo20.FOO",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), response.Body.HolderCount)
+	assert.Equal(t, "gno_address_1", response.Body.Holders[0].Address)
+
+	_, err = handler.GetTokenHolders(context.Background(), &humatypes.TokenHoldersGetInput{TokenPath: "%zz"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not a valid url encoded")
+}
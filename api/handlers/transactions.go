@@ -79,6 +79,11 @@ func (h *TransactionsHandler) GetTransactionMessage(
 			if err != nil {
 				return nil, err
 			}
+		case "msg_generic":
+			err := h.getMsgGenericResponse(ctx, msgType, txHashBase64, h.chainName, &response)
+			if err != nil {
+				return nil, err
+			}
 		default:
 			return nil, huma.Error400BadRequest("Transaction message type not found", nil)
 		}
@@ -93,7 +98,7 @@ func (h *TransactionsHandler) GetTransactionsByCursor(
 	ctx context.Context,
 	input *humatypes.TransactionGeneralListByCursorGetInput,
 ) (*humatypes.TransactionGeneralListByCursorGetOutput, error) {
-	transactions, err := h.db.GetTransactionsByCursor(ctx, h.chainName, input.Cursor, input.Limit)
+	transactions, err := h.db.GetTransactionsByCursor(ctx, h.chainName, input.Cursor, input.Limit, input.FailedOnly)
 	if err != nil {
 		return nil, huma.Error404NotFound("Transactions by cursor not found", err)
 	}
@@ -107,7 +112,7 @@ func (h *TransactionsHandler) GetLastXTransactions(
 	ctx context.Context,
 	input *humatypes.LastXTransactionsGetInput,
 ) (*humatypes.LastXTransactionsGetOutput, error) {
-	transactions, err := h.db.GetLastXTransactions(ctx, h.chainName, input.Amount)
+	transactions, err := h.db.GetLastXTransactions(ctx, h.chainName, input.Amount, input.FailedOnly)
 	if err != nil {
 		return nil, huma.Error404NotFound("Last transactions not found", err)
 	}
@@ -297,6 +302,34 @@ func (h *TransactionsHandler) getMsgRunResponse(
 	return nil
 }
 
+// Helper method that collects generic message data from the database and adds it to the response
+func (h *TransactionsHandler) getMsgGenericResponse(
+	ctx context.Context,
+	msgType string,
+	txHash string,
+	chainName string,
+	response *map[int16]humatypes.TransactionMessage,
+) error {
+	data, err := h.db.GetMsgGeneric(ctx, txHash, chainName)
+	if err != nil {
+		return huma.Error400BadRequest(
+			fmt.Sprintf("Failed to fetch %s data for transaction %s", "msg_generic", txHash), err)
+	}
+	for _, d := range data {
+		index := d.MessageCounter
+		(*response)[index] = humatypes.TransactionMessage{
+			MessageType: msgType,
+			TxHash:      d.TxHash,
+			Timestamp:   d.Timestamp,
+			Signers:     d.Signers,
+			TypeUrl:     d.TypeUrl,
+			Payload:     d.Payload,
+			Addresses:   d.Addresses,
+		}
+	}
+	return nil
+}
+
 // Helper method that collects bank send data from the database and adds it to the response
 func (h *TransactionsHandler) getBankSendResponse(
 	ctx context.Context,
@@ -91,3 +91,106 @@ func TestTransactionsHandler_GetTransactionBasic_Fail(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
+
+func TestTransactionsHandler_GetTransactionsByCursor_FailedOnly(t *testing.T) {
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	db := MockDatabase{
+		transactions: map[string]*database.Transaction{
+			"tx_hash_1": {
+				TxHash:      "tx_hash_1",
+				Timestamp:   fixedTime,
+				BlockHeight: 42,
+				Success:     true,
+			},
+			"tx_hash_2": {
+				TxHash:      "tx_hash_2",
+				Timestamp:   fixedTime,
+				BlockHeight: 43,
+				Success:     false,
+				TxError:     "/std.OutOfGasError",
+				TxLog:       "out of gas",
+			},
+		},
+	}
+
+	handler := handlers.NewTransactionsHandler(&db, "gnoland")
+	response, err := handler.GetTransactionsByCursor(
+		context.Background(),
+		&humatypes.TransactionGeneralListByCursorGetInput{Cursor: "", Limit: 10, FailedOnly: true},
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, 1, len(response.Body))
+	assert.Equal(t, "tx_hash_2", response.Body[0].TxHash)
+	assert.False(t, response.Body[0].Success)
+	assert.Equal(t, "/std.OutOfGasError", response.Body[0].TxError)
+}
+
+func TestTransactionsHandler_GetTransactionMessage_Generic(t *testing.T) {
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	hash := []byte("0123456789abcdef0123456789abcdef")
+	txHash := base64.StdEncoding.EncodeToString(hash)
+
+	db := MockDatabase{
+		msgTypes: map[string][]string{txHash: {"msg_generic"}},
+		msgGeneric: map[string]*database.MsgGeneric{
+			txHash: {
+				MessageCounter: 0,
+				TxHash:         txHash,
+				Timestamp:      fixedTime,
+				TypeUrl:        "/bank.MsgMultiSend",
+				Payload:        `{"@type":"/bank.MsgMultiSend"}`,
+				Addresses:      []string{"g1address"},
+				Signers:        []string{"g1signer"},
+			},
+		},
+	}
+
+	handler := handlers.NewTransactionsHandler(&db, "gnoland")
+	response, err := handler.GetTransactionMessage(
+		context.Background(),
+		&humatypes.TransactionGetInput{TxHash: base64.URLEncoding.EncodeToString(hash)},
+	)
+
+	require.NoError(t, err)
+	require.Len(t, response.Body, 1)
+	assert.Equal(t, "msg_generic", response.Body[0].MessageType)
+	assert.Equal(t, "/bank.MsgMultiSend", response.Body[0].TypeUrl)
+	assert.Equal(t, []string{"g1address"}, response.Body[0].Addresses)
+}
+
+func TestTransactionsHandler_GetTransactionMessage_MsgCallArgs(t *testing.T) {
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	hash := []byte("0123456789abcdef0123456789abcdef")
+	txHash := base64.StdEncoding.EncodeToString(hash)
+	args := []string{"g1recipient", "1,000", ""}
+
+	db := MockDatabase{
+		msgTypes: map[string][]string{txHash: {"vm_msg_call"}},
+		msgCall: map[string]*database.MsgCall{
+			txHash: {
+				MessageCounter: 0,
+				TxHash:         txHash,
+				Timestamp:      fixedTime,
+				Caller:         "g1caller",
+				PkgPath:        "gno.land/r/demo/foo",
+				FuncName:       "Transfer",
+				Args:           args,
+				Signers:        []string{"g1caller"},
+			},
+		},
+	}
+
+	handler := handlers.NewTransactionsHandler(&db, "gnoland")
+	response, err := handler.GetTransactionMessage(
+		context.Background(),
+		&humatypes.TransactionGetInput{TxHash: base64.URLEncoding.EncodeToString(hash)},
+	)
+
+	require.NoError(t, err)
+	require.Len(t, response.Body, 1)
+	assert.Equal(t, "vm_msg_call", response.Body[0].MessageType)
+	assert.Equal(t, args, response.Body[0].Args)
+}
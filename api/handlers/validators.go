@@ -18,6 +18,46 @@ func NewValidatorsHandler(db ValidatorDbHandler, chainName string) *ValidatorsHa
 	return &ValidatorsHandler{db: db, chainName: chainName}
 }
 
+// GetValidators returns the validator set, the latest one or the one that signed the given height
+func (h *ValidatorsHandler) GetValidators(
+	ctx context.Context,
+	input *humatypes.ValidatorsGetInput,
+) (*humatypes.ValidatorsGetOutput, error) {
+	var atHeight *uint64
+	if input.AtHeight > 0 {
+		atHeight = &input.AtHeight
+	}
+	validatorSet, err := h.db.GetValidatorSet(ctx, h.chainName, atHeight)
+	if err != nil {
+		return nil, huma.Error404NotFound("Validator set not found", err)
+	}
+	return &humatypes.ValidatorsGetOutput{Body: validatorSet}, nil
+}
+
+// GetValidator returns a validator with its current and historical voting power
+func (h *ValidatorsHandler) GetValidator(
+	ctx context.Context,
+	input *humatypes.ValidatorGetInput,
+) (*humatypes.ValidatorGetOutput, error) {
+	validator, err := h.db.GetValidator(ctx, input.ValidatorAddress, h.chainName)
+	if err != nil {
+		return nil, huma.Error404NotFound(fmt.Sprintf("Validator %s not found", input.ValidatorAddress), err)
+	}
+	return &humatypes.ValidatorGetOutput{Body: validator}, nil
+}
+
+// GetValidatorsUptime returns the uptime leaderboard of the validators over the latest indexed blocks
+func (h *ValidatorsHandler) GetValidatorsUptime(
+	ctx context.Context,
+	input *humatypes.ValidatorsUptimeGetInput,
+) (*humatypes.ValidatorsUptimeGetOutput, error) {
+	uptime, err := h.db.GetValidatorsUptime(ctx, h.chainName, input.Blocks)
+	if err != nil {
+		return nil, huma.Error404NotFound("Validator uptime not found", err)
+	}
+	return &humatypes.ValidatorsUptimeGetOutput{Body: uptime}, nil
+}
+
 // GetValidatorSigning24h returns the signing performance of a validator over the last 24 hours
 func (h *ValidatorsHandler) GetValidatorSigning24h(
 	ctx context.Context,
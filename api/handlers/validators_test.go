@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/handlers"
+	humatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/huma-types"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorsHandler_GetValidators(t *testing.T) {
+	db := MockDatabase{
+		validatorSets: []*database.ValidatorSetData{
+			{Height: 1, TotalPower: 10, Validators: []*database.Validator{{Address: "gno_validator_1", VotingPower: 10}}},
+			{Height: 50, TotalPower: 15, Validators: []*database.Validator{
+				{Address: "gno_validator_1", VotingPower: 10},
+				{Address: "gno_validator_2", VotingPower: 5},
+			}},
+		},
+	}
+	handler := handlers.NewValidatorsHandler(&db, "gnoland")
+
+	// the latest set
+	response, err := handler.GetValidators(context.Background(), &humatypes.ValidatorsGetInput{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), response.Body.Height)
+	assert.Len(t, response.Body.Validators, 2)
+
+	// the set that signed a past height
+	response, err = handler.GetValidators(context.Background(), &humatypes.ValidatorsGetInput{AtHeight: 49})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), response.Body.Height)
+	assert.Equal(t, int64(10), response.Body.TotalPower)
+
+	// no set stored yet
+	db.validatorSets = nil
+	_, err = handler.GetValidators(context.Background(), &humatypes.ValidatorsGetInput{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestValidatorsHandler_GetValidator(t *testing.T) {
+	db := MockDatabase{
+		validators: map[string]*database.ValidatorDetails{
+			"gno_validator_1": {
+				Address:     "gno_validator_1",
+				InSet:       true,
+				VotingPower: 10,
+				History: []*database.ValidatorPower{
+					{Height: 50, VotingPower: 10},
+					{Height: 1, VotingPower: 5},
+				},
+			},
+		},
+	}
+	handler := handlers.NewValidatorsHandler(&db, "gnoland")
+
+	response, err := handler.GetValidator(context.Background(), &humatypes.ValidatorGetInput{
+		ValidatorAddress: "gno_validator_1",
+	})
+	require.NoError(t, err)
+	assert.True(t, response.Body.InSet)
+	assert.Len(t, response.Body.History, 2)
+
+	_, err = handler.GetValidator(context.Background(), &humatypes.ValidatorGetInput{
+		ValidatorAddress: "gno_validator_2",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestValidatorsHandler_GetValidatorsUptime(t *testing.T) {
+	db := MockDatabase{
+		uptime: &database.ValidatorsUptime{
+			FromHeight: 1,
+			ToHeight:   500,
+			Validators: []*database.ValidatorUptime{
+				{Address: "gno_validator_1", ActiveBlocks: 500, Uptime: 100},
+				{Address: "gno_validator_2", ActiveBlocks: 500, MissedBlocks: 50, Uptime: 90,
+					LongestMissStreak: 30, CurrentMissStreak: 5},
+			},
+		},
+	}
+	handler := handlers.NewValidatorsHandler(&db, "gnoland")
+
+	response, err := handler.GetValidatorsUptime(context.Background(), &humatypes.ValidatorsUptimeGetInput{Blocks: 100})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(401), response.Body.FromHeight)
+	assert.Equal(t, uint64(500), response.Body.ToHeight)
+	require.Len(t, response.Body.Validators, 2)
+	assert.Equal(t, "gno_validator_1", response.Body.Validators[0].Address)
+	assert.Equal(t, int64(30), response.Body.Validators[1].LongestMissStreak)
+
+	// no signings stored yet
+	db.uptime = nil
+	_, err = handler.GetValidatorsUptime(context.Background(), &humatypes.ValidatorsUptimeGetInput{Blocks: 100})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
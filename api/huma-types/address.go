@@ -51,3 +51,24 @@ type DailyActiveAccountGetInput struct {
 type DailyActiveAccountGetOutput struct {
 	Body []*database.DailyActiveAccount
 }
+
+type AddressInfoGetInput struct {
+	Address string `path:"address" doc:"Gno address you want to query" required:"true" minLength:"40" maxLength:"40"`
+}
+
+type AddressInfoGetOutput struct {
+	Body *database.AddressInfo
+}
+
+type AddressBalancesGetInput struct {
+	Address  string `path:"address" doc:"Gno address you want to query" required:"true" minLength:"40" maxLength:"40"`
+	AtHeight uint64 `query:"at_height" doc:"Block height of the balances, the latest if not set"`
+}
+
+type AddressBalancesGetOutput struct {
+	Body AddressBalancesBody
+}
+
+type AddressBalancesBody struct {
+	Balances []*database.NativeBalance `json:"balances" doc:"Native coin balances of the address"`
+}
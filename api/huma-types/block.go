@@ -31,6 +31,14 @@ type AllBlockSignersGetOutput struct {
 	Body *database.BlockSigners
 }
 
+type BlockEventsGetInput struct {
+	BlockHeight uint64 `path:"block_height" minimum:"1" example:"12345" doc:"Block height" required:"true"`
+}
+
+type BlockEventsGetOutput struct {
+	Body *database.BlockEvents
+}
+
 // LatestBlockHeightGetInput represents the empty input for getting the latest block height
 type LatestBlockHeightGetInput struct{}
 
@@ -0,0 +1,23 @@
+package humatypes
+
+import (
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+)
+
+type EventsGetInput struct {
+	Type      string `query:"type" example:"Transfer" doc:"Event type"`
+	PkgPath   string `query:"pkg_path" example:"gno.land/r/demo/foo20" doc:"Package path of the realm that emitted the event"`
+	AttrKey   string `query:"attr_key" example:"from" doc:"Key of an event attribute"`
+	AttrValue string `query:"attr_value" doc:"Value of the attribute, needs the attr_key"`
+	Limit     uint64 `query:"limit" doc:"Limit of events to return" minimum:"1" maximum:"100" default:"10"`
+	Cursor    string `query:"cursor" doc:"Cursor to continue from"`
+}
+
+type EventsGetOutput struct {
+	Body EventsBody
+}
+
+type EventsBody struct {
+	Events     []*database.TxEvent `json:"events" doc:"Events emitted by the transactions, newest first"`
+	NextCursor string              `json:"next_cursor" doc:"Next cursor that can be used in the query"`
+}
@@ -0,0 +1,46 @@
+package humatypes
+
+import (
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+)
+
+type CollectionTokensGetInput struct {
+	// the slashes of the path need to be escaped as %2F
+	PkgPath string `path:"pkg_path" example:"gno.land%2Fr%2Fdemo%2Fnft" doc:"Collection realm path (url encoded)" required:"true"`
+	Limit   uint64 `query:"limit" doc:"Limit of tokens to return" minimum:"1" maximum:"100" default:"10"`
+	Page    uint64 `query:"page" doc:"Page of tokens to return, starts from 0"`
+}
+
+type CollectionTokensGetOutput struct {
+	Body CollectionTokensBody
+}
+
+type CollectionTokensBody struct {
+	Tokens     []*database.Nft `json:"tokens" doc:"Tokens of the collection with their owner, the latest transferred come first"`
+	TokenCount uint64          `json:"token_count" doc:"Total number of tokens, the burned tokens included"`
+}
+
+type NftHistoryGetInput struct {
+	PkgPath string `path:"pkg_path" example:"gno.land%2Fr%2Fdemo%2Fnft" doc:"Collection realm path (url encoded)" required:"true"`
+	TokenId string `path:"token_id" example:"1" doc:"Token id (url encoded)" required:"true"`
+}
+
+type NftHistoryGetOutput struct {
+	Body NftHistoryBody
+}
+
+type NftHistoryBody struct {
+	Transfers []*database.NftTransfer `json:"transfers" doc:"Transfers, mints and burns of the token, the latest come first"`
+}
+
+type AddressNftsGetInput struct {
+	Address string `path:"address" doc:"Gno address you want to query" required:"true" minLength:"40" maxLength:"40"`
+}
+
+type AddressNftsGetOutput struct {
+	Body AddressNftsBody
+}
+
+type AddressNftsBody struct {
+	Nfts []*database.Nft `json:"nfts" doc:"GRC721 tokens owned by the address"`
+}
@@ -0,0 +1,65 @@
+package humatypes
+
+import (
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+)
+
+type PackagesGetInput struct {
+	Limit uint64 `query:"limit" doc:"Limit of packages to return" minimum:"1" maximum:"100" default:"10"`
+	Page  uint64 `query:"page" doc:"Page of packages to return, starts from 0"`
+}
+
+type PackagesGetOutput struct {
+	Body PackagesBody
+}
+
+type PackagesBody struct {
+	Packages     []*database.Package `json:"packages" doc:"Packages, the latest deployed come first"`
+	PackageCount uint64              `json:"package_count" doc:"Total number of packages"`
+}
+
+type PackageGetInput struct {
+	PkgPath string `path:"pkg_path" example:"gno.land%2Fr%2Fdemo%2Fboards" doc:"Package path (url encoded)" required:"true"`
+}
+
+type PackageGetOutput struct {
+	Body *database.PackageDetails
+}
+
+type PackageCallsGetInput struct {
+	PkgPath string `path:"pkg_path" example:"gno.land%2Fr%2Fdemo%2Fboards" doc:"Package path (url encoded)" required:"true"`
+	Limit   uint64 `query:"limit" doc:"Limit of calls to return" minimum:"1" maximum:"100" default:"10"`
+	Cursor  string `query:"cursor" doc:"Cursor to continue from"`
+}
+
+type PackageCallsGetOutput struct {
+	Body PackageCallsBody
+}
+
+type PackageCallsBody struct {
+	Calls      []*database.MsgCall `json:"calls" doc:"MsgCall messages that call the package, newest first"`
+	NextCursor string              `json:"next_cursor" doc:"Next cursor that can be used in the query"`
+}
+
+type PackageSourceGetInput struct {
+	// the slashes of the path need to be escaped as %2F
+	PkgPath string `path:"pkg_path" example:"gno.land%2Fr%2Fdemo%2Fboards" doc:"Package path (url encoded)" required:"true"`
+	TxHash  string `query:"tx_hash" doc:"Hash of the deployment transaction (base64url encoded), the latest deployment if empty"`
+}
+
+type PackageSourceGetOutput struct {
+	Body *database.PackageSource
+}
+
+type PackageFileGetInput struct {
+	PkgPath  string `path:"pkg_path" example:"gno.land%2Fr%2Fdemo%2Fboards" doc:"Package path (url encoded)" required:"true"`
+	FileName string `path:"file_name" example:"boards.gno" doc:"File name" required:"true"`
+	TxHash   string `query:"tx_hash" doc:"Hash of the deployment transaction (base64url encoded), the latest deployment if empty"`
+}
+
+// PackageFileGetOutput is the raw file body
+type PackageFileGetOutput struct {
+	ContentType string `header:"Content-Type"`
+	FileHash    string `header:"X-File-Hash" doc:"sha256 hash of the file body (base64 encoded)"`
+	Body        []byte
+}
@@ -0,0 +1,47 @@
+package humatypes
+
+import (
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+)
+
+type TokensGetInput struct {
+	Limit uint64 `query:"limit" doc:"Limit of tokens to return" minimum:"1" maximum:"100" default:"10"`
+	Page  uint64 `query:"page" doc:"Page of tokens to return, starts from 0"`
+}
+
+type TokensGetOutput struct {
+	Body TokensBody
+}
+
+type TokensBody struct {
+	Tokens     []*database.Token `json:"tokens" doc:"GRC20 tokens, the tokens with the most holders come first"`
+	TokenCount uint64            `json:"token_count" doc:"Total number of tokens"`
+}
+
+type TokenHoldersGetInput struct {
+	// the slashes of the path need to be escaped as %2F
+	TokenPath string `path:"pkg_path" example:"gno.land%2Fr%2Fdemo%2Ffoo20.FOO" doc:"Token path (url encoded)" required:"true"`
+	Limit     uint64 `query:"limit" doc:"Limit of holders to return" minimum:"1" maximum:"100" default:"10"`
+	Page      uint64 `query:"page" doc:"Page of holders to return, starts from 0"`
+}
+
+type TokenHoldersGetOutput struct {
+	Body TokenHoldersBody
+}
+
+type TokenHoldersBody struct {
+	Holders     []*database.TokenBalance `json:"holders" doc:"Holders of the token, the largest balances come first"`
+	HolderCount uint64                   `json:"holder_count" doc:"Total number of holders"`
+}
+
+type AddressTokensGetInput struct {
+	Address string `path:"address" doc:"Gno address you want to query" required:"true" minLength:"40" maxLength:"40"`
+}
+
+type AddressTokensGetOutput struct {
+	Body AddressTokensBody
+}
+
+type AddressTokensBody struct {
+	Tokens []*database.TokenBalance `json:"tokens" doc:"GRC20 token balances of the address"`
+}
@@ -17,13 +17,13 @@ type TransactionBasicGetOutput struct {
 }
 
 // TransactionMessage represents a unified transaction message type that can be one of:
-// bank_msg_send, vm_msg_call, vm_msg_add_package, or vm_msg_run
+// bank_msg_send, vm_msg_call, vm_msg_add_package, vm_msg_run or msg_generic
 // not maybe the best implementation, but this one works for now
 // to future me, if you figure out a better way to do this, please do so
 // for now this is good enough
 type TransactionMessage struct {
 	// Common fields (always present)
-	MessageType string    `json:"message_type" doc:"Type of message: bank_msg_send, vm_msg_call, vm_msg_add_package, or vm_msg_run" enum:"bank_msg_send,vm_msg_call,vm_msg_add_package,vm_msg_run"`
+	MessageType string    `json:"message_type" doc:"Type of message: bank_msg_send, vm_msg_call, vm_msg_add_package, vm_msg_run, or msg_generic" enum:"bank_msg_send,vm_msg_call,vm_msg_add_package,vm_msg_run,msg_generic"`
 	TxHash      string    `json:"tx_hash" doc:"Transaction hash (base64 encoded)"`
 	Timestamp   time.Time `json:"timestamp" doc:"Transaction timestamp"`
 	Signers     []string  `json:"signers" doc:"Signers (addresses)"`
@@ -34,9 +34,9 @@ type TransactionMessage struct {
 	Amount      []database.Amount `json:"amount,omitempty" doc:"Amount (only for bank_msg_send)"`
 
 	// MsgCall specific fields
-	Caller   string `json:"caller,omitempty" doc:"Caller address (for vm_msg_call and vm_msg_run)"`
-	FuncName string `json:"func_name,omitempty" doc:"Function name (only for vm_msg_call)"`
-	Args     string `json:"args,omitempty" doc:"Arguments (only for vm_msg_call)"`
+	Caller   string   `json:"caller,omitempty" doc:"Caller address (for vm_msg_call and vm_msg_run)"`
+	FuncName string   `json:"func_name,omitempty" doc:"Function name (only for vm_msg_call)"`
+	Args     []string `json:"args,omitempty" doc:"Arguments in the order they were passed to the function (only for vm_msg_call)"`
 
 	// MsgAddPackage and MsgRun specific fields
 	Creator      string   `json:"creator,omitempty" doc:"Creator address (only for vm_msg_add_package)"`
@@ -47,6 +47,11 @@ type TransactionMessage struct {
 	PkgPath    string            `json:"pkg_path,omitempty" doc:"Package path (for vm_msg_call, vm_msg_add_package, and vm_msg_run)"`
 	Send       []database.Amount `json:"send,omitempty" doc:"Send amount (for vm_msg_call, vm_msg_add_package, and vm_msg_run)"`
 	MaxDeposit []database.Amount `json:"max_deposit,omitempty" doc:"Max deposit (for vm_msg_call, vm_msg_add_package, and vm_msg_run)"`
+
+	// MsgGeneric specific fields
+	TypeUrl   string   `json:"type_url,omitempty" doc:"Amino type url of the message (only for msg_generic)"`
+	Payload   string   `json:"payload,omitempty" doc:"Message encoded as json (only for msg_generic)"`
+	Addresses []string `json:"addresses,omitempty" doc:"Addresses involved in the message (only for msg_generic)"`
 }
 
 // TransactionMessageGetOutput represents the response containing all messages within a transaction
@@ -55,8 +60,9 @@ type TransactionMessageGetOutput struct {
 }
 
 type TransactionGeneralListByCursorGetInput struct {
-	Cursor string `query:"cursor" doc:"Cursor to get the next set of transactions in form of timestamp|tx_hash(base64url encoded)" required:"false"`
-	Limit  uint64 `query:"limit" doc:"Limit of transactions to get" required:"true" min:"1" max:"100" default:"10"`
+	Cursor     string `query:"cursor" doc:"Cursor to get the next set of transactions in form of block_height|tx_index|tx_hash(base64url encoded)" required:"false"`
+	Limit      uint64 `query:"limit" doc:"Limit of transactions to get" required:"true" min:"1" max:"100" default:"10"`
+	FailedOnly bool   `query:"failed_only" doc:"Return only the transactions that failed to execute" required:"false" default:"false"`
 }
 
 type TransactionGeneralListByCursorGetOutput struct {
@@ -64,7 +70,8 @@ type TransactionGeneralListByCursorGetOutput struct {
 }
 
 type LastXTransactionsGetInput struct {
-	Amount uint64 `query:"amount" doc:"Amount of transactions to get" required:"true" min:"1" max:"100" default:"10"`
+	Amount     uint64 `query:"amount" doc:"Amount of transactions to get" required:"true" min:"1" max:"100" default:"10"`
+	FailedOnly bool   `query:"failed_only" doc:"Return only the transactions that failed to execute" required:"false" default:"false"`
 }
 
 type LastXTransactionsGetOutput struct {
@@ -23,3 +23,27 @@ type ValidatorSigningByHourGetInput struct {
 type ValidatorSigningByHourGetOutput struct {
 	Body []*database.ValidatorSigning
 }
+
+type ValidatorsGetInput struct {
+	AtHeight uint64 `query:"at_height" doc:"Block height of the validator set, the latest if not set"`
+}
+
+type ValidatorsGetOutput struct {
+	Body *database.ValidatorSetData
+}
+
+type ValidatorGetInput struct {
+	ValidatorAddress string `path:"validator_address" doc:"Validator address" required:"true" example:"g16jqn9e738pwenxpseasr49sj3axcyd37262wal" minLength:"40" maxLength:"40"`
+}
+
+type ValidatorGetOutput struct {
+	Body *database.ValidatorDetails
+}
+
+type ValidatorsUptimeGetInput struct {
+	Blocks uint64 `query:"blocks" doc:"Amount of the latest indexed blocks in the window" minimum:"1" maximum:"100000" default:"10000"`
+}
+
+type ValidatorsUptimeGetOutput struct {
+	Body *database.ValidatorsUptime
+}
@@ -6,6 +6,12 @@ import (
 )
 
 func RegisterAddressesRoutes(api huma.API, h *handlers.AddressHandler) {
+	huma.Get(api, "/addresses/{address}", h.GetAddress,
+		func(op *huma.Operation) {
+			op.Summary = "Get Address"
+			op.Description = "Retrieve a given address with its public key. " +
+				"The public key is known once a signature of the address included it."
+		})
 	huma.Get(api, "/addresses/{address}/transactions", h.GetAddressTxs,
 		func(op *huma.Operation) {
 			op.Summary = "Get Address Transactions"
@@ -21,4 +27,20 @@ func RegisterAddressesRoutes(api huma.API, h *handlers.AddressHandler) {
 			op.Summary = "Get Daily Active Addresses"
 			op.Description = "Retrieve the number of daily active addresses within the given date range."
 		})
+	huma.Get(api, "/addresses/{address}/tokens", h.GetAddressTokens,
+		func(op *huma.Operation) {
+			op.Summary = "Get Address Tokens"
+			op.Description = "Retrieve the GRC20 token balances of a given address."
+		})
+	huma.Get(api, "/addresses/{address}/nfts", h.GetAddressNfts,
+		func(op *huma.Operation) {
+			op.Summary = "Get Address NFTs"
+			op.Description = "Retrieve the GRC721 tokens owned by a given address."
+		})
+	huma.Get(api, "/addresses/{address}/balances", h.GetAddressBalances,
+		func(op *huma.Operation) {
+			op.Summary = "Get Address Balances"
+			op.Description = "Retrieve the native coin balances of a given address, " +
+				"at_height returns the balances as they were after the given block."
+		})
 }
@@ -32,6 +32,12 @@ func RegisterBlocksRoutes(api huma.API, h *handlers.BlocksHandler) {
 			op.Summary = "Get Block Signers"
 			op.Description = "Retrieve all validators that signed a block by its height."
 		})
+	huma.Get(api, "/blocks/{block_height}/events", h.GetBlockEvents,
+		func(op *huma.Operation) {
+			op.Summary = "Get Block Events"
+			op.Description = `Retrieve the events of a block that are not tied to a transaction, emitted in the
+			BeginBlock and the EndBlock, and the validator set changes returned by the EndBlock.`
+		})
 	huma.Get(api, "/blocks/stats/count/recent", h.GetBlockCount24h,
 		func(op *huma.Operation) {
 			op.Summary = "Get Block Count (Last 24h)"
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/handlers"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func RegisterEventsRoutes(api huma.API, h *handlers.EventsHandler) {
+	huma.Get(api, "/events", h.GetEvents,
+		func(op *huma.Operation) {
+			op.Summary = "Get Events"
+			op.Description = "Page through the events emitted by the transactions, newest first. " +
+				"The events can be filtered by the type, the package path and an attribute key or key and value."
+		})
+}
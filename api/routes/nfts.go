@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/handlers"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func RegisterNftsRoutes(api huma.API, h *handlers.NftsHandler) {
+	huma.Get(api, "/nfts/{pkg_path}/tokens", h.GetCollectionTokens,
+		func(op *huma.Operation) {
+			op.Summary = "Get Collection Tokens"
+			op.Description = "List the tokens of a GRC721 collection with their current owner, the latest transferred come first. " +
+				"The collection path needs to be url encoded (gno.land%2Fr%2Fdemo%2Fnft)."
+		})
+	huma.Get(api, "/nfts/{pkg_path}/tokens/{token_id}/history", h.GetNftHistory,
+		func(op *huma.Operation) {
+			op.Summary = "Get NFT History"
+			op.Description = "Retrieve the ownership history of a GRC721 token, every transfer, mint and burn with the latest first. " +
+				"The collection path needs to be url encoded (gno.land%2Fr%2Fdemo%2Fnft)."
+		})
+}
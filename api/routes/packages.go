@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/handlers"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func RegisterPackagesRoutes(api huma.API, h *handlers.PackagesHandler) {
+	huma.Get(api, "/packages", h.GetPackages,
+		func(op *huma.Operation) {
+			op.Summary = "Get Packages"
+			op.Description = "List the packages added to the chain with their first deployment, the latest deployed come first."
+		})
+	huma.Get(api, "/packages/{pkg_path}", h.GetPackage,
+		func(op *huma.Operation) {
+			op.Summary = "Get Package"
+			op.Description = "Retrieve a package with its deployment history. " +
+				"The package path needs to be url encoded (gno.land%2Fr%2Fdemo%2Fboards)."
+		})
+	huma.Get(api, "/packages/{pkg_path}/calls", h.GetPackageCalls,
+		func(op *huma.Operation) {
+			op.Summary = "Get Package Calls"
+			op.Description = "Page through the MsgCall messages that call the realm, newest first. " +
+				"The package path needs to be url encoded (gno.land%2Fr%2Fdemo%2Fboards)."
+		})
+	huma.Get(api, "/packages/{pkg_path}/source", h.GetPackageSource,
+		func(op *huma.Operation) {
+			op.Summary = "Get Package Source"
+			op.Description = "List the source files of a package as of the latest deployment or the deployment " +
+				"from the given transaction. The package path needs to be url encoded (gno.land%2Fr%2Fdemo%2Fboards)."
+		})
+	huma.Get(api, "/packages/{pkg_path}/source/{file_name}", h.GetPackageFile,
+		func(op *huma.Operation) {
+			op.Summary = "Get Package File"
+			op.Description = "Download a source file of a package as of the latest deployment or the deployment " +
+				"from the given transaction. The package path needs to be url encoded (gno.land%2Fr%2Fdemo%2Fboards)."
+		})
+}
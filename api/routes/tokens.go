@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/api/handlers"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func RegisterTokensRoutes(api huma.API, h *handlers.TokensHandler) {
+	huma.Get(api, "/tokens", h.GetTokens,
+		func(op *huma.Operation) {
+			op.Summary = "Get Tokens"
+			op.Description = "List the GRC20 tokens with their holder count and supply, the tokens with the most holders come first."
+		})
+	huma.Get(api, "/tokens/{pkg_path}/holders", h.GetTokenHolders,
+		func(op *huma.Operation) {
+			op.Summary = "Get Token Holders"
+			op.Description = "List the holders of a GRC20 token, the largest balances come first. " +
+				"The token path needs to be url encoded (gno.land%2Fr%2Fdemo%2Ffoo20.FOO)."
+		})
+}
@@ -6,6 +6,21 @@ import (
 )
 
 func RegisterValidatorsRoutes(api huma.API, h *handlers.ValidatorsHandler) {
+	huma.Get(api, "/validators", h.GetValidators,
+		func(op *huma.Operation) {
+			op.Summary = "Get Validator Set"
+			op.Description = "Retrieve the validator set with the voting power and the pub keys. With at_height the set that signed the given height is returned."
+		})
+	huma.Get(api, "/validators/uptime", h.GetValidatorsUptime,
+		func(op *huma.Operation) {
+			op.Summary = "Get Validators Uptime"
+			op.Description = "Retrieve the uptime leaderboard of the validators over the latest indexed blocks, with the missed blocks and the longest and current miss streaks."
+		})
+	huma.Get(api, "/validators/{validator_address}", h.GetValidator,
+		func(op *huma.Operation) {
+			op.Summary = "Get Validator"
+			op.Description = "Retrieve a validator with its current voting power and the history of its voting power."
+		})
 	huma.Get(api, "/validators/{validator_address}/signing/recent", h.GetValidatorSigning24h,
 		func(op *huma.Operation) {
 			op.Summary = "Get Validator Signing (Last 24h)"
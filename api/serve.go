@@ -173,6 +173,10 @@ func runServe(cmd *cobra.Command, args []string) {
 	transactionsHandler := handlers.NewTransactionsHandler(db, conf.ChainName)
 	addressHandler := handlers.NewAddressHandler(db, conf.ChainName)
 	validatorsHandler := handlers.NewValidatorsHandler(db, conf.ChainName)
+	packagesHandler := handlers.NewPackagesHandler(db, conf.ChainName)
+	eventsHandler := handlers.NewEventsHandler(db, conf.ChainName)
+	tokensHandler := handlers.NewTokensHandler(db, conf.ChainName)
+	nftsHandler := handlers.NewNftsHandler(db, conf.ChainName)
 
 	router.Get("/favicon.ico", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "image/x-icon")
@@ -188,6 +192,10 @@ func runServe(cmd *cobra.Command, args []string) {
 	routes.RegisterTransactionsRoutes(api, transactionsHandler)
 	routes.RegisterAddressesRoutes(api, addressHandler)
 	routes.RegisterValidatorsRoutes(api, validatorsHandler)
+	routes.RegisterPackagesRoutes(api, packagesHandler)
+	routes.RegisterEventsRoutes(api, eventsHandler)
+	routes.RegisterTokensRoutes(api, tokensHandler)
+	routes.RegisterNftsRoutes(api, nftsHandler)
 	routes.RegisterUtilsRoutes(api)
 
 	addr := fmt.Sprintf("%s:%d", conf.Host, conf.Port)
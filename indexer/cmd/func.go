@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/config"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/query"
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
 	"github.com/spf13/cobra"
 	"go.yaml.in/yaml/v4"
@@ -138,6 +139,12 @@ func createConfig(overwrite bool, fileName string) error {
 		Pause:                     &[]int{3}[0],
 		PauseTime:                 &[]time.Duration{15 * time.Second}[0],
 		ExponentialBackoff:        &[]time.Duration{2 * time.Second}[0],
+		RpcSelection:              string(query.RoundRobin),
+		RpcHealthCheckInterval:    30 * time.Second,
+		RpcMaxLag:                 10,
+		RpcMaxFailures:            3,
+		MaxRewindDepth:            100,
+		QuerySignerAccounts:       false,
 	}
 
 	yamlFile, err := yaml.Marshal(cfg)
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	mainOperator "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/main_operator"
+	mainTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/main_types"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/logger"
+	"github.com/spf13/cobra"
+)
+
+var gapsCmd = &cobra.Command{
+	Use:   "gaps",
+	Short: "Find and backfill the missing heights",
+	Long: `Runs the spectra indexer in gaps mode. It checks the blocks and the validator block signings
+	for the missing heights, reports them and indexes the missing ranges again.
+
+	If the to height is not set the last height stored in the database is used. With the dry run flag
+	the gaps are only reported.
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+		l.Info().Msg("running in gaps mode")
+
+		configPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get config path")
+			return err
+		}
+
+		maxRequestsPerWindow, err := cmd.Flags().GetInt("max-req-per-window")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get max requests per window")
+			return err
+		}
+		rateLimitWindow, err := cmd.Flags().GetDuration("rate-limit-window")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get rate limit window")
+			return err
+		}
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get timeout")
+			return err
+		}
+		compressEvents, err := cmd.Flags().GetBool("compress-events")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get compress events")
+			return err
+		}
+		insertMode, err := cmd.Flags().GetString("insert-mode")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get insert mode")
+			return err
+		}
+
+		fromHeight, err := cmd.Flags().GetUint64("from-height")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get from height")
+			return err
+		}
+		toHeight, err := cmd.Flags().GetUint64("to-height")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get to height")
+			return err
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get dry run")
+			return err
+		}
+
+		rateLimitFlags := mainTypes.RpcFlags{
+			RequestsPerWindow: maxRequestsPerWindow,
+			TimeWindow:        rateLimitWindow,
+			Timeout:           timeout,
+		}
+
+		runningFlags := mainTypes.RunningFlags{
+			RunningMode:        "gaps",
+			SkipInitialDbCheck: false,
+			CompressEvents:     compressEvents,
+			FromHeight:         fromHeight,
+			ToHeight:           toHeight,
+			DryRun:             dryRun,
+			InsertMode:         insertMode,
+		}
+
+		l.Info().Msg("indexer started")
+		mainOperator.InitMainOperator(configPath, ".", rateLimitFlags, runningFlags)
+		return nil
+	},
+}
+
+func init() {
+	gapsCmd.Flags().Uint64P("from-height", "f", 1, "starting block height")
+	gapsCmd.Flags().Uint64P("to-height", "o", 0, "ending block height (default is the last stored height)")
+	gapsCmd.Flags().BoolP("dry-run", "d", false, "only report the gaps without indexing them")
+}
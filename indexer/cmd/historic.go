@@ -18,6 +18,9 @@ var historicCmd = &cobra.Command{
 	
 	It can also be useful if you want to index blockchain partially and work with data for any kind of testing
 	or partial scan of the chain where you want to index from a certain height to a certain height.
+
+	Every processed chunk is recorded in the database. If the historic run was stopped you can run it
+	again with the resume flag and only the ranges that were never completed will be processed.
 	`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		l := logger.Get()
@@ -49,6 +52,11 @@ var historicCmd = &cobra.Command{
 			l.Error().Err(err).Msg("failed to get compress events")
 			return err
 		}
+		insertMode, err := cmd.Flags().GetString("insert-mode")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get insert mode")
+			return err
+		}
 
 		fromHeight, err := cmd.Flags().GetUint64("from-height")
 		if err != nil {
@@ -61,6 +69,12 @@ var historicCmd = &cobra.Command{
 			return err
 		}
 
+		resume, err := cmd.Flags().GetBool("resume")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get resume")
+			return err
+		}
+
 		rateLimitFlags := mainTypes.RpcFlags{
 			RequestsPerWindow: maxRequestsPerWindow,
 			TimeWindow:        rateLimitWindow,
@@ -73,6 +87,8 @@ var historicCmd = &cobra.Command{
 			CompressEvents:     compressEvents,
 			FromHeight:         fromHeight,
 			ToHeight:           toHeight,
+			Resume:             resume,
+			InsertMode:         insertMode,
 		}
 
 		l.Info().Msg("indexer started")
@@ -87,6 +103,7 @@ var historicCmd = &cobra.Command{
 func init() {
 	historicCmd.Flags().Uint64P("from-height", "f", 1, "starting block height")
 	historicCmd.Flags().Uint64P("to-height", "o", 1000, "ending block height")
+	historicCmd.Flags().BoolP("resume", "u", false, "skip the ranges already processed by a previous historic run")
 
 	if err := historicCmd.MarkFlagRequired("from-height"); err != nil {
 		panic(fmt.Sprintf("failed to mark from-height as required: %v", err))
@@ -18,6 +18,17 @@ var liveCmd = &cobra.Command{
 
 	However if you do not need previous data, you can run the live mode with the skip-db-check flag set to true.
 	Afterwards you can run live mode normal without the skip-db-check flag.
+
+	With the websocket flag the indexer subscribes to the new block events on the RPC websocket
+	and processes the blocks as soon as they are produced. If the websocket is not available or
+	the connection drops it will fall back to polling the RPC.
+
+	With the gap check interval set the indexer periodically checks the recently indexed heights
+	for gaps and indexes the missing heights again.
+
+	With the balance reconcile interval set the indexer periodically compares the native balances
+	of the recently changed addresses with the bank/balances query of the node and stores the
+	differences as corrections.
 	`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		l := logger.Get()
@@ -49,6 +60,11 @@ var liveCmd = &cobra.Command{
 			l.Error().Err(err).Msg("failed to get compress events")
 			return err
 		}
+		insertMode, err := cmd.Flags().GetString("insert-mode")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get insert mode")
+			return err
+		}
 
 		skipDbCheck, err := cmd.Flags().GetBool("skip-db-check")
 		if err != nil {
@@ -56,6 +72,24 @@ var liveCmd = &cobra.Command{
 			return err
 		}
 
+		useWebsocket, err := cmd.Flags().GetBool("websocket")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get websocket")
+			return err
+		}
+
+		gapCheckInterval, err := cmd.Flags().GetDuration("gap-check-interval")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get gap check interval")
+			return err
+		}
+
+		balanceReconcileInterval, err := cmd.Flags().GetDuration("balance-reconcile-interval")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get balance reconcile interval")
+			return err
+		}
+
 		rateLimitFlags := mainTypes.RpcFlags{
 			RequestsPerWindow: maxRequestsPerWindow,
 			TimeWindow:        rateLimitWindow,
@@ -63,11 +97,15 @@ var liveCmd = &cobra.Command{
 		}
 
 		runningFlags := mainTypes.RunningFlags{
-			RunningMode:        "live",
-			SkipInitialDbCheck: skipDbCheck,
-			CompressEvents:     compressEvents,
-			FromHeight:         0,
-			ToHeight:           0,
+			RunningMode:              "live",
+			SkipInitialDbCheck:       skipDbCheck,
+			UseWebsocket:             useWebsocket,
+			GapCheckInterval:         gapCheckInterval,
+			BalanceReconcileInterval: balanceReconcileInterval,
+			InsertMode:               insertMode,
+			CompressEvents:           compressEvents,
+			FromHeight:               0,
+			ToHeight:                 0,
 		}
 
 		l.Info().Msg("indexer started")
@@ -81,4 +119,9 @@ var liveCmd = &cobra.Command{
 
 func init() {
 	liveCmd.Flags().BoolP("skip-db-check", "s", false, "skip initial database check")
+	liveCmd.Flags().BoolP("websocket", "w", false, "subscribe to new blocks over websocket instead of polling")
+	liveCmd.Flags().DurationP("gap-check-interval", "g", 0, "how often to check for gaps and backfill them (0 disables it)")
+	liveCmd.Flags().Duration(
+		"balance-reconcile-interval", 0, "how often to compare the native balances with the node (0 disables it)",
+	)
 }
@@ -9,18 +9,22 @@ import (
 var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Run the indexer",
-	Long:  `Run the indexer in either live or historic mode.`,
+	Long:  `Run the indexer in live or historic mode, or backfill the missing heights with the gaps mode.`,
 }
 
 func init() {
 	// Add subcommands
 	runCmd.AddCommand(liveCmd)
 	runCmd.AddCommand(historicCmd)
+	runCmd.AddCommand(gapsCmd)
 
-	// Persistent flags that apply to all run subcommands (live and historic)
+	// Persistent flags that apply to all run subcommands (live, historic and gaps)
 	runCmd.PersistentFlags().StringP("config", "c", "config.yml", "config file path")
 	runCmd.PersistentFlags().IntP("max-req-per-window", "m", 10000000, "max requests per window")
 	runCmd.PersistentFlags().DurationP("rate-limit-window", "r", 1*time.Minute, "rate limit window")
 	runCmd.PersistentFlags().DurationP("timeout", "t", 20*time.Second, "timeout")
 	runCmd.PersistentFlags().BoolP("compress-events", "e", false, "compress events")
+	runCmd.PersistentFlags().StringP(
+		"insert-mode", "i", "copy", "how to write the rows that already exist: copy (fail), skip or update",
+	)
 }
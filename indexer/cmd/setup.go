@@ -37,6 +37,7 @@ func init() {
 	setupCmd.AddCommand(createUserCmd)
 	setupCmd.AddCommand(createConfigCmd)
 	setupCmd.AddCommand(refreshAggregatesCmd)
+	setupCmd.AddCommand(migrateCmd)
 
 	// Common flags for both database setup commands
 	for _, cmd := range []*cobra.Command{createDbCmd, createUserCmd} {
@@ -54,6 +55,13 @@ func init() {
 	refreshAggregatesCmd.Flags().StringP("db-name", "d", "", "The database name to refresh, default is gnoland")
 	refreshAggregatesCmd.Flags().StringP("ssl-mode", "s", "", "The SSL mode for the database connection, default is disable")
 
+	// migrate flags (same connection flags as create-db)
+	migrateCmd.Flags().StringP("db-host", "b", "", "The database host, default is localhost")
+	migrateCmd.Flags().IntP("db-port", "p", 0, "The database port, default is 5432")
+	migrateCmd.Flags().StringP("db-user", "u", "", "The database user, default is postgres")
+	migrateCmd.Flags().StringP("db-name", "d", "", "The database name to migrate, default is gnoland")
+	migrateCmd.Flags().StringP("ssl-mode", "s", "", "The SSL mode for the database connection, default is disable")
+
 	// create-user specific flags
 	createUserCmd.Flags().StringP("privilege", "r", "", "The privilege level for the user (reader or writer)")
 	createUserCmd.Flags().String("user", "", "The user name for the user to create")
@@ -156,6 +164,10 @@ func initializeNewDatabase(db *database.TimescaleDb, dbConfig database.DatabaseP
 		return err
 	}
 
+	if err := createIndexes(dbInit, chainName); err != nil {
+		return err
+	}
+
 	if err := createContinuousAggregates(dbInit, chainName); err != nil {
 		return err
 	}
@@ -198,6 +210,12 @@ func createRegularTables(dbInit *dbinit.DBInitializer, chainName string) error {
 		sql_data_types.GnoAddress{},
 		sql_data_types.GnoValidatorAddress{},
 		sql_data_types.ApiKey{},
+		sql_data_types.IndexerProgress{},
+		sql_data_types.PackageFile{},
+		sql_data_types.Package{},
+		sql_data_types.Grc20Balance{},
+		sql_data_types.NftOwner{},
+		sql_data_types.NativeBalance{},
 	}
 
 	l.Info().Str("chain", chainName).Msg("inserting regular tables")
@@ -221,12 +239,22 @@ func createHypertables(dbInit *dbinit.DBInitializer, chainName string) error {
 	}{
 		{sql_data_types.Blocks{}, "timestamp", "1 week"},
 		{sql_data_types.ValidatorBlockSigning{}, "timestamp", "1 week"},
+		{sql_data_types.ValidatorSet{}, "timestamp", "1 week"},
+		{sql_data_types.ValidatorMissedBlock{}, "timestamp", "1 week"},
+		{sql_data_types.ValidatorUpdate{}, "timestamp", "1 week"},
+		{sql_data_types.BlockEvent{}, "timestamp", "1 week"},
 		{sql_data_types.AddressTx{}, "timestamp", "1 week"},
 		{sql_data_types.TransactionGeneral{}, "timestamp", "1 week"},
 		{sql_data_types.MsgSend{}, "timestamp", "1 week"},
 		{sql_data_types.MsgCall{}, "timestamp", "1 week"},
 		{sql_data_types.MsgAddPackage{}, "timestamp", "1 week"},
 		{sql_data_types.MsgRun{}, "timestamp", "1 week"},
+		{sql_data_types.MsgGeneric{}, "timestamp", "1 week"},
+		{sql_data_types.TxEvent{}, "timestamp", "1 week"},
+		{sql_data_types.TxSignature{}, "timestamp", "1 week"},
+		{sql_data_types.Grc20Transfer{}, "timestamp", "1 week"},
+		{sql_data_types.NftTransfer{}, "timestamp", "1 week"},
+		{sql_data_types.NativeBalanceChange{}, "timestamp", "1 week"},
 	}
 
 	l.Info().Str("chain", chainName).Msg("inserting hypertables")
@@ -240,6 +268,49 @@ func createHypertables(dbInit *dbinit.DBInitializer, chainName string) error {
 	return nil
 }
 
+// createIndexes creates the secondary indexes used by the API filters and the balance updates
+func createIndexes(dbInit *dbinit.DBInitializer, chainName string) error {
+	l := logger.Get()
+
+	indexes := []struct {
+		name    string
+		table   string
+		columns []string
+	}{
+		// the transaction list is ordered by the block height and the position within the block
+		{"transaction_general_height_idx", "transaction_general", []string{"chain_name", "block_height DESC"}},
+		{"tx_events_type_idx", "tx_events", []string{"chain_name", "event_type", "timestamp DESC"}},
+		{"tx_events_pkg_path_idx", "tx_events", []string{"chain_name", "pkg_path", "timestamp DESC"}},
+		{"tx_events_attr_idx", "tx_events", []string{"chain_name", "attr_key", "attr_value", "timestamp DESC"}},
+		// the balances are summed from the transfers of a token and an address
+		{"grc20_transfers_from_idx", "grc20_transfers", []string{"chain_name", "token_path", "from_address"}},
+		{"grc20_transfers_to_idx", "grc20_transfers", []string{"chain_name", "token_path", "to_address"}},
+		{"grc20_balances_address_idx", "grc20_balances", []string{"chain_name", "address"}},
+		{"grc20_balances_holders_idx", "grc20_balances", []string{"chain_name", "token_path", "balance DESC"}},
+		// the owner of a token is taken from its latest transfer, the history reads the same rows
+		{"nft_transfers_token_idx", "nft_transfers", []string{"chain_name", "collection_path", "token_id", "block_height DESC"}},
+		{"nft_owners_owner_idx", "nft_owners", []string{"chain_name", "owner"}},
+		// the balance of an address at a height is summed from its changes up to the height
+		{"native_balance_changes_address_idx", "native_balance_changes", []string{"chain_name", "address", "denom", "block_height"}},
+		{"native_balance_changes_height_idx", "native_balance_changes", []string{"chain_name", "block_height"}},
+		{"native_balances_last_height_idx", "native_balances", []string{"chain_name", "last_height"}},
+		// the power history of a validator reads its rows of every stored set
+		{"validator_set_validator_idx", "validator_set", []string{"chain_name", "validator", "block_height DESC"}},
+		// the uptime reads the missed blocks of a validator in a range of heights
+		{"validator_missed_blocks_validator_idx", "validator_missed_blocks", []string{"chain_name", "validator", "block_height"}},
+	}
+
+	l.Info().Str("chain", chainName).Msg("creating indexes")
+	for _, idx := range indexes {
+		if err := dbInit.CreateIndex(idx.name, idx.table, idx.columns); err != nil {
+			l.Error().Err(err).Str("index", idx.name).Msg("failed to create index")
+			return err
+		}
+	}
+
+	return nil
+}
+
 func createContinuousAggregates(dbInit *dbinit.DBInitializer, chainName string) error {
 	l := logger.Get()
 
@@ -363,6 +434,63 @@ user does not have the required privileges.`,
 	},
 }
 
+// migrateCmd applies the schema changes to a database created by an older version of the indexer.
+//
+// IMPORTANT: This command must be run with a database account that owns the tables
+// (e.g. the postgres account used during "setup create-db"). Stop the indexer before
+// running it, the tables are locked while they are migrated.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate the database schema to the current version",
+	Long: `Apply the schema changes to a database created by an older version of the indexer.
+
+The tables added by the newer versions are created first. Every migration checks
+if it is already applied, so the command is safe to run more than once. The
+existing rows are converted to the new schema.
+
+IMPORTANT: You must connect with an account that owns the tables (e.g. the
+postgres account) and the indexer should be stopped while the migration runs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		params, err := parseCommonFlags(cmd, "gnoland")
+		if err != nil {
+			l.Error().Err(err).Msg("failed to parse flags")
+			return err
+		}
+
+		params.password, err = promptPassword()
+		if err != nil {
+			l.Error().Err(err).Msg("failed to read password")
+			return err
+		}
+
+		dbConfig := params.createDatabaseConfig()
+		db := database.NewTimescaleDbSetup(dbConfig)
+		dbInit := dbinit.NewDBInitializer(db.GetPool())
+
+		// the tables added by the newer versions are created first, the existing ones are left as they are
+		if err := createRegularTables(dbInit, params.name); err != nil {
+			return err
+		}
+		if err := createHypertables(dbInit, params.name); err != nil {
+			return err
+		}
+		if err := createIndexes(dbInit, params.name); err != nil {
+			return err
+		}
+
+		applied, err := dbInit.RunMigrations()
+		if err != nil {
+			l.Error().Err(err).Msg("failed to migrate the database")
+			return err
+		}
+
+		l.Info().Int("applied", applied).Msg("database migrated successfully")
+		return nil
+	},
+}
+
 var createUserCmd = &cobra.Command{
 	Use:   "create-user",
 	Short: "Create a new user for the database",
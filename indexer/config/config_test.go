@@ -39,3 +39,31 @@ func TestErrorLoadConfig(t *testing.T) {
 		t.Skipf("failed to load config: %v", err)
 	}
 }
+
+func TestMultipleRpcLoadConfig(t *testing.T) {
+	conf, err := config.LoadConfig("testdata/test4.yml")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	// the duplicate url with the trailing slash is removed
+	endpoints := conf.Endpoints()
+	if len(endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got %v", endpoints)
+	}
+	if endpoints[0] != "https://gnoland-testnet-rpc.cogwheel.zone" || endpoints[1] != "http://localhost:26657" {
+		t.Fatalf("unexpected endpoints %v", endpoints)
+	}
+	if conf.RpcSelection != "latency" {
+		t.Fatalf("expected latency selection, got %s", conf.RpcSelection)
+	}
+	if conf.MaxRewindDepth != 20 {
+		t.Fatalf("expected max rewind depth 20, got %d", conf.MaxRewindDepth)
+	}
+	if !conf.QuerySignerAccounts {
+		t.Fatal("expected the signer accounts to be queried")
+	}
+	t.Log(conf.RpcHealthCheckInterval)
+	t.Log(conf.RpcMaxLag)
+	t.Log(conf.RpcMaxFailures)
+}
@@ -20,11 +20,15 @@ func LoadConfig(path string) (*Config, error) {
 	var config Config
 	err = yaml.Unmarshal(yamlFile, &config)
 	// all fields except rpc and chain name will throw errors if they are not set
-	// so we need a checker for the rpc url
-	if config.RpcUrl == "" {
+	// so we need a checker for the rpc urls, at least one of rpc or rpc_urls is required
+	endpoints := config.Endpoints()
+	if len(endpoints) == 0 {
 		return nil, errors.New("rpc url is required")
-	} else if !strings.HasPrefix(config.RpcUrl, "http://") && !strings.HasPrefix(config.RpcUrl, "https://") {
-		return nil, errors.New("rpc url must start with http:// or https://")
+	}
+	for _, url := range endpoints {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return nil, fmt.Errorf("rpc url %s must start with http:// or https://", url)
+		}
 	}
 	if config.ChainName == "" {
 		return nil, errors.New("chain name is required")
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"time"
 )
 
@@ -31,4 +32,39 @@ type Config struct {
 	Pause              *int           `yaml:"pause"`
 	PauseTime          *time.Duration `yaml:"pause_time"`
 	ExponentialBackoff *time.Duration `yaml:"exponential_backoff"`
+	// additional rpc endpoints are optional, the requests are balanced between rpc and rpc_urls
+	// the zero values of the pool options fall back to the defaults
+	RpcUrls                []string      `yaml:"rpc_urls,omitempty"`
+	RpcSelection           string        `yaml:"rpc_selection"`
+	RpcHealthCheckInterval time.Duration `yaml:"rpc_health_check_interval"`
+	RpcMaxLag              uint64        `yaml:"rpc_max_lag"`
+	RpcMaxFailures         int           `yaml:"rpc_max_failures"`
+	// the max amount of blocks looked back for the last common block after a fork is optional
+	// the zero value falls back to the default
+	MaxRewindDepth uint64 `yaml:"max_rewind_depth"`
+	// the account number and the sequence of the signatures are read from the node with one
+	// auth/accounts query per signer and block, it needs an archive node so it is off by default
+	QuerySignerAccounts bool `yaml:"query_signer_accounts"`
+}
+
+// Endpoints returns every rpc url from the config without duplicates
+// the url set with rpc is always the first one
+//
+// Returns:
+//   - []string: the rpc urls
+func (c *Config) Endpoints() []string {
+	endpoints := make([]string, 0, len(c.RpcUrls)+1)
+	seen := make(map[string]struct{}, len(c.RpcUrls)+1)
+	for _, url := range append([]string{c.RpcUrl}, c.RpcUrls...) {
+		url = strings.TrimSuffix(url, "/")
+		if url == "" {
+			continue
+		}
+		if _, ok := seen[url]; ok {
+			continue
+		}
+		seen[url] = struct{}{}
+		endpoints = append(endpoints, url)
+	}
+	return endpoints
 }
@@ -0,0 +1,198 @@
+package dataprocessor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/decoder"
+)
+
+// accountQueryWorkers is the max amount of the auth/accounts abci queries running at once
+const accountQueryWorkers = 10
+
+// signerAccount is the account number and the sequence a signer signed a transaction with,
+// nil if they can't be known
+type signerAccount struct {
+	accountNumber *uint64
+	sequence      *uint64
+}
+
+// accountKey is the signer of a block, the account is read from the state before the block
+type accountKey struct {
+	height uint64
+	signer string
+}
+
+// SetAccountQuerier enables the account number and the sequence of the transaction signatures.
+// They are not part of the transaction, so they are read with the auth/accounts abci query
+// from the state before the block of the transaction.
+//
+// Parameters:
+//   - querier: the rpc client used for the abci queries
+func (d *DataProcessor) SetAccountQuerier(querier AccountQuerier) {
+	d.accountQuerier = querier
+}
+
+// signerAccounts is a private method that returns the account number and the sequence of every
+// signer of the transactions, the slice is in the same order as the transactions
+//
+// The account is queried once per block from the state before the block. The ante handler increments
+// the sequence of the signers even when the transaction fails, so the sequence of a signer is the
+// queried one increased by the earlier transactions of the block signed by the same account.
+//
+// Parameters:
+//   - transactions: the transactions of the chunk
+//   - decodedMsgs: the decoded transactions, in the same order
+//
+// Returns:
+//   - []map[string]signerAccount: the accounts of the signers of every transaction, nil without a querier
+func (d *DataProcessor) signerAccounts(
+	transactions []TransactionsData,
+	decodedMsgs []*decoder.DecodedMsg,
+) []map[string]signerAccount {
+	if d.accountQuerier == nil {
+		return nil
+	}
+
+	// the transactions of the chunk can come in any order, the sequence follows the order within the block
+	order := make([]int, len(transactions))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		ta, tb := transactions[a], transactions[b]
+		if ta.BlockHeight != tb.BlockHeight {
+			if ta.BlockHeight < tb.BlockHeight {
+				return -1
+			}
+			return 1
+		}
+		return ta.Response.GetIndex() - tb.Response.GetIndex()
+	})
+
+	keys := make([]accountKey, 0)
+	seen := make(map[accountKey]struct{})
+	for _, idx := range order {
+		for _, signer := range txSignatureSigners(decodedMsgs[idx]) {
+			key := accountKey{height: transactions[idx].BlockHeight, signer: signer}
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+	queried := d.queryAccounts(keys)
+
+	accounts := make([]map[string]signerAccount, len(transactions))
+	for _, idx := range order {
+		accounts[idx] = make(map[string]signerAccount)
+		for _, signer := range txSignatureSigners(decodedMsgs[idx]) {
+			key := accountKey{height: transactions[idx].BlockHeight, signer: signer}
+			account := queried[key]
+			accounts[idx][signer] = account
+			if account.sequence != nil {
+				next := *account.sequence + 1
+				queried[key] = signerAccount{accountNumber: account.accountNumber, sequence: &next}
+			}
+		}
+	}
+	return accounts
+}
+
+// queryAccounts is a private method that queries the accounts of the signers from the state
+// before their blocks, a failed query leaves the account unknown
+func (d *DataProcessor) queryAccounts(keys []accountKey) map[accountKey]signerAccount {
+	results := make([]signerAccount, len(keys))
+	sem := make(chan struct{}, accountQueryWorkers)
+	wg := sync.WaitGroup{}
+	for i, key := range keys {
+		// the state before the first block is not stored by the node
+		if key.height <= 1 {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			height := key.height - 1
+			result, err := d.accountQuerier.GetAbciQuery("auth/accounts/"+key.signer, "", &height, nil)
+			if err == nil {
+				results[i], err = parseAccount(result)
+			}
+			if err != nil {
+				l.Warn().Err(err).Msgf("Failed to query the account of %s at height %d", key.signer, height)
+			}
+		}()
+	}
+	wg.Wait()
+
+	accounts := make(map[accountKey]signerAccount, len(keys))
+	for i, key := range keys {
+		accounts[key] = results[i]
+	}
+	return accounts
+}
+
+// txSignatureSigners returns the unique signers of the transaction signatures
+func txSignatureSigners(decodedMsg *decoder.DecodedMsg) []string {
+	signers := make([]string, 0)
+	if decodedMsg == nil {
+		return signers
+	}
+	for _, signature := range decodedMsg.GetSignatures() {
+		if signature.Signer != "" && !slices.Contains(signers, signature.Signer) {
+			signers = append(signers, signature.Signer)
+		}
+	}
+	return signers
+}
+
+// parseAccount reads the account number and the sequence from the result of the auth/accounts abci query
+//
+// The result holds the response base with the data encoded in base64, the data is the amino JSON
+// of the account. The account that doesn't exist yet is null, its sequence starts at 0 and the
+// account number is not known until it is created.
+//
+// Returns:
+//   - signerAccount: the account number and the sequence of the account
+//   - error: if the result is not a valid auth/accounts response
+func parseAccount(result any) (signerAccount, error) {
+	resultMap, _ := result.(map[string]any)
+	response, _ := resultMap["response"].(map[string]any)
+	responseBase, ok := response["ResponseBase"].(map[string]any)
+	if !ok {
+		return signerAccount{}, errors.New("abci query returned no response")
+	}
+	if queryErr := responseBase["Error"]; queryErr != nil {
+		return signerAccount{}, fmt.Errorf("abci query failed: %v", queryErr)
+	}
+	data, _ := responseBase["Data"].(string)
+	bz, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return signerAccount{}, fmt.Errorf("failed to decode the abci query data: %w", err)
+	}
+
+	// the gno.land accounts embed the base account, the amino JSON encodes the uint64 as a string
+	var account struct {
+		BaseAccount *struct {
+			AccountNumber uint64 `json:"account_number,string"`
+			Sequence      uint64 `json:"sequence,string"`
+		} `json:"BaseAccount"`
+	}
+	if err := json.Unmarshal(bz, &account); err != nil {
+		return signerAccount{}, fmt.Errorf("failed to read the account %s: %w", string(bz), err)
+	}
+	if account.BaseAccount == nil {
+		var sequence uint64
+		return signerAccount{sequence: &sequence}, nil
+	}
+	return signerAccount{
+		accountNumber: &account.BaseAccount.AccountNumber,
+		sequence:      &account.BaseAccount.Sequence,
+	}, nil
+}
@@ -17,6 +17,12 @@ const (
 	CompressedFormat
 )
 
+// the phases of the block the block events are emitted in
+const (
+	BeginBlockPhase = "begin_block"
+	EndBlockPhase   = "end_block"
+)
+
 var dictBytes = dictloader.LoadDict()
 var zstdDict = zstd.WithEncoderDict(dictBytes)
 var zstdLvl = zstd.WithEncoderLevel(zstd.SpeedBestCompression)
@@ -78,8 +84,13 @@ func (er *EventResult) GetCompressedData() []byte {
 //   - *EventResult: contains either native events or compressed data
 //   - error: an error if the event solving fails
 func EventSolver(txResponse *rpcClient.TxResponse, useCompressed bool) (*EventResult, error) {
-	events := &txResponse.Result.TxResult.ResponseBase.Events
-	evCount := len(*events)
+	return solveEvents(txResponse.Result.TxResult.ResponseBase.Events, useCompressed)
+}
+
+// solveEvents converts the events to the native or the compressed format,
+// it is shared by the transaction and the block events
+func solveEvents(events []rpcClient.Event, useCompressed bool) (*EventResult, error) {
+	evCount := len(events)
 
 	/*
 		The reason why the program only compresses events with more than 2 elements is because of the size.
@@ -89,7 +100,7 @@ func EventSolver(txResponse *rpcClient.TxResponse, useCompressed bool) (*EventRe
 		Until the dictionary is improved and trained on a larger set of data for now it will work like this.
 	*/
 	if useCompressed && evCount >= 2 {
-		protoSerializedEv, err := serializeEvent(events)
+		protoSerializedEv, err := serializeEvent(&events)
 		if err != nil {
 			return nil, err
 		}
@@ -101,8 +112,8 @@ func EventSolver(txResponse *rpcClient.TxResponse, useCompressed bool) (*EventRe
 	}
 
 	// Native format implementation
-	nativeEvents := make([]sqlDataTypes.Event, 0, len(txResponse.Result.TxResult.ResponseBase.Events))
-	for _, event := range txResponse.Result.TxResult.ResponseBase.Events {
+	nativeEvents := make([]sqlDataTypes.Event, 0, evCount)
+	for _, event := range events {
 		attributes := make([]sqlDataTypes.Attribute, 0, len(event.Attrs))
 		for _, attribute := range event.Attrs {
 			attributes = append(attributes, sqlDataTypes.Attribute{
@@ -148,3 +159,48 @@ func serializeEvent(events *[]rpcClient.Event) ([]byte, error) {
 	}
 	return bs, nil
 }
+
+// eventRows flattens the events of a transaction to the rows of the tx_events table
+//
+// Every attribute gets its own row, an event without attributes is stored as one row
+// without a key and value so the event itself is still searchable.
+//
+// Parameters:
+//   - events: the events emitted by the transaction
+//   - txHash: the decoded hash of the transaction
+//   - chainName: the name of the chain
+//   - transaction: the transaction the events belong to
+//
+// Returns:
+//   - []sqlDataTypes.TxEvent: the flattened events
+func eventRows(
+	events []rpcClient.Event,
+	txHash []byte,
+	chainName string,
+	transaction TransactionsData,
+) []sqlDataTypes.TxEvent {
+	rows := make([]sqlDataTypes.TxEvent, 0, len(events))
+	for eventIdx, event := range events {
+		row := sqlDataTypes.TxEvent{
+			TxHash:      txHash,
+			ChainName:   chainName,
+			Timestamp:   transaction.Timestamp,
+			EventIndex:  int16(eventIdx),
+			BlockHeight: transaction.BlockHeight,
+			EventType:   event.Type,
+			AtType:      event.AtType,
+			PkgPath:     event.PkgPath,
+		}
+		if len(event.Attrs) == 0 {
+			rows = append(rows, row)
+			continue
+		}
+		for attrIdx, attr := range event.Attrs {
+			row.AttrIndex = int16(attrIdx)
+			row.AttrKey = &attr.Key
+			row.AttrValue = &attr.Value
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
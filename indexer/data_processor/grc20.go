@@ -0,0 +1,188 @@
+package dataprocessor
+
+import (
+	"encoding/base64"
+	"math/big"
+
+	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// the event types emitted by the GRC20 and GRC721 tokens, the grc20 package emits every mint
+// and burn as a Transfer with an empty address, the older tokens and the grc721 package emit
+// them as Mint and Burn
+const (
+	transferEvent = "Transfer"
+	mintEvent     = "Mint"
+	burnEvent     = "Burn"
+)
+
+// grc20Event is a GRC20 transfer, mint or burn recognised from the events of a transaction
+type grc20Event struct {
+	transaction TransactionsData
+	txHash      []byte
+	eventIndex  int16
+	eventType   string
+	tokenPath   string
+	from        string
+	to          string
+	amount      *big.Int
+}
+
+// grc20Events finds the GRC20 transfers, mints and burns within the events of the successful transactions
+//
+// An event is taken as GRC20 if its type is Transfer, Mint or Burn and it has a "value" attribute
+// with a non negative integer, so the GRC721 transfers that carry the "tokenId" are not picked up.
+// The token is the "token" attribute (realm path and symbol) and the path of the realm that
+// emitted the event for the tokens that don't emit it.
+//
+// Parameters:
+//   - transactions: the transactions of the chunk
+//   - addressesMap: the addresses of the events are added to it so they are resolved with the messages
+//
+// Returns:
+//   - []grc20Event: the recognised events
+func grc20Events(transactions []TransactionsData, addressesMap map[string]struct{}) []grc20Event {
+	events := make([]grc20Event, 0)
+	for _, transaction := range transactions {
+		if transaction.Response == nil || transaction.Response.HasError() {
+			continue
+		}
+		var txHash []byte
+		for eventIdx, event := range transaction.Response.GetEvents() {
+			grc20, ok := parseGrc20Event(event)
+			if !ok {
+				continue
+			}
+			if txHash == nil {
+				hash, err := base64.StdEncoding.DecodeString(transaction.Response.GetHash())
+				if err != nil {
+					l.Error().Msgf("Failed to decode tx hash %s: %v", transaction.Response.GetHash(), err)
+					break
+				}
+				txHash = hash
+			}
+			grc20.transaction = transaction
+			grc20.txHash = txHash
+			grc20.eventIndex = int16(eventIdx)
+			for _, address := range []string{grc20.from, grc20.to} {
+				if address != "" {
+					addressesMap[address] = struct{}{}
+				}
+			}
+			events = append(events, grc20)
+		}
+	}
+	return events
+}
+
+// parseGrc20Event reads the addresses and the amount of a GRC20 event
+func parseGrc20Event(event rpcClient.Event) (grc20Event, bool) {
+	if event.Type != transferEvent && event.Type != mintEvent && event.Type != burnEvent {
+		return grc20Event{}, false
+	}
+	attrs := eventAttrs(event)
+	value, ok := attrs["value"]
+	if !ok {
+		return grc20Event{}, false
+	}
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() < 0 {
+		return grc20Event{}, false
+	}
+
+	parsed := grc20Event{
+		tokenPath: attrs["token"],
+		from:      attrs["from"],
+		to:        attrs["to"],
+		amount:    amount,
+	}
+	if parsed.tokenPath == "" {
+		parsed.tokenPath = event.PkgPath
+	}
+	if parsed.tokenPath == "" {
+		return grc20Event{}, false
+	}
+	parsed.eventType, ok = transferType(event.Type, &parsed.from, &parsed.to)
+	return parsed, ok
+}
+
+// eventAttrs returns the attributes of the event by their key, the first value of a key is kept
+func eventAttrs(event rpcClient.Event) map[string]string {
+	attrs := make(map[string]string, len(event.Attrs))
+	for _, attr := range event.Attrs {
+		if _, ok := attrs[attr.Key]; !ok {
+			attrs[attr.Key] = attr.Value
+		}
+	}
+	return attrs
+}
+
+// transferType checks the addresses of a transfer event and returns its type
+//
+// A Mint has no sender and a Burn has no receiver whatever the event holds, a Transfer
+// with an empty address is a mint or a burn. The addresses that are set need to be valid.
+//
+// Returns:
+//   - string: Transfer, Mint or Burn
+//   - bool: false if the addresses are not valid
+func transferType(eventType string, from *string, to *string) (string, bool) {
+	switch eventType {
+	case mintEvent:
+		*from = ""
+	case burnEvent:
+		*to = ""
+	}
+	for _, address := range []string{*from, *to} {
+		if address == "" {
+			continue
+		}
+		if _, err := crypto.AddressFromBech32(address); err != nil {
+			return "", false
+		}
+	}
+
+	switch {
+	case *from == "" && *to == "":
+		return "", false
+	case *from == "":
+		return mintEvent, true
+	case *to == "":
+		return burnEvent, true
+	default:
+		return transferEvent, true
+	}
+}
+
+// grc20TransferRows converts the GRC20 events to the database rows
+//
+// The addresses need to be resolved by the address cache before this is called.
+func (d *DataProcessor) grc20TransferRows(events []grc20Event) []sqlDataTypes.Grc20Transfer {
+	rows := make([]sqlDataTypes.Grc20Transfer, len(events))
+	for i, event := range events {
+		rows[i] = sqlDataTypes.Grc20Transfer{
+			TxHash:      event.txHash,
+			ChainName:   d.chainName,
+			Timestamp:   event.transaction.Timestamp,
+			EventIndex:  event.eventIndex,
+			BlockHeight: event.transaction.BlockHeight,
+			TokenPath:   event.tokenPath,
+			EventType:   event.eventType,
+			FromAddress: d.optionalAddress(event.from),
+			ToAddress:   d.optionalAddress(event.to),
+			Amount:      pgtype.Numeric{Int: event.amount, Valid: true},
+		}
+	}
+	return rows
+}
+
+// optionalAddress returns the id of the address, nil for the empty address of a mint or burn
+func (d *DataProcessor) optionalAddress(address string) *int32 {
+	if address == "" {
+		return nil
+	}
+	id := d.addressCache.GetAddress(address)
+	return &id
+}
@@ -0,0 +1,118 @@
+package dataprocessor
+
+import (
+	"encoding/base64"
+
+	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+)
+
+// nftEvent is a GRC721 transfer, mint or burn recognised from the events of a transaction
+type nftEvent struct {
+	transaction    TransactionsData
+	txHash         []byte
+	eventIndex     int16
+	eventType      string
+	collectionPath string
+	symbol         string
+	tokenId        string
+	from           string
+	to             string
+}
+
+// nftEvents finds the GRC721 transfers, mints and burns within the events of the successful transactions
+//
+// An event is taken as GRC721 if its type is Transfer, Mint or Burn and it has the "tokenId"
+// attribute without the "value" of the GRC20 events. The collection is the realm that emitted
+// the event, so every realm is one collection.
+//
+// Parameters:
+//   - transactions: the transactions of the chunk
+//   - addressesMap: the addresses of the events are added to it so they are resolved with the messages
+//
+// Returns:
+//   - []nftEvent: the recognised events
+func nftEvents(transactions []TransactionsData, addressesMap map[string]struct{}) []nftEvent {
+	events := make([]nftEvent, 0)
+	for _, transaction := range transactions {
+		if transaction.Response == nil || transaction.Response.HasError() {
+			continue
+		}
+		var txHash []byte
+		for eventIdx, event := range transaction.Response.GetEvents() {
+			nft, ok := parseNftEvent(event)
+			if !ok {
+				continue
+			}
+			if txHash == nil {
+				hash, err := base64.StdEncoding.DecodeString(transaction.Response.GetHash())
+				if err != nil {
+					l.Error().Msgf("Failed to decode tx hash %s: %v", transaction.Response.GetHash(), err)
+					break
+				}
+				txHash = hash
+			}
+			nft.transaction = transaction
+			nft.txHash = txHash
+			nft.eventIndex = int16(eventIdx)
+			for _, address := range []string{nft.from, nft.to} {
+				if address != "" {
+					addressesMap[address] = struct{}{}
+				}
+			}
+			events = append(events, nft)
+		}
+	}
+	return events
+}
+
+// parseNftEvent reads the collection, the token id and the addresses of a GRC721 event
+func parseNftEvent(event rpcClient.Event) (nftEvent, bool) {
+	if event.Type != transferEvent && event.Type != mintEvent && event.Type != burnEvent {
+		return nftEvent{}, false
+	}
+	if event.PkgPath == "" {
+		return nftEvent{}, false
+	}
+	attrs := eventAttrs(event)
+	if _, ok := attrs["value"]; ok {
+		return nftEvent{}, false
+	}
+	tokenId, ok := attrs["tokenId"]
+	if !ok || tokenId == "" {
+		return nftEvent{}, false
+	}
+
+	parsed := nftEvent{
+		collectionPath: event.PkgPath,
+		symbol:         attrs["slug"],
+		tokenId:        tokenId,
+		from:           attrs["from"],
+		to:             attrs["to"],
+	}
+	parsed.eventType, ok = transferType(event.Type, &parsed.from, &parsed.to)
+	return parsed, ok
+}
+
+// nftTransferRows converts the GRC721 events to the database rows
+//
+// The addresses need to be resolved by the address cache before this is called.
+func (d *DataProcessor) nftTransferRows(events []nftEvent) []sqlDataTypes.NftTransfer {
+	rows := make([]sqlDataTypes.NftTransfer, len(events))
+	for i, event := range events {
+		rows[i] = sqlDataTypes.NftTransfer{
+			TxHash:         event.txHash,
+			ChainName:      d.chainName,
+			Timestamp:      event.transaction.Timestamp,
+			EventIndex:     event.eventIndex,
+			BlockHeight:    event.transaction.BlockHeight,
+			CollectionPath: event.collectionPath,
+			Symbol:         event.symbol,
+			TokenId:        event.tokenId,
+			EventType:      event.eventType,
+			FromAddress:    d.optionalAddress(event.from),
+			ToAddress:      d.optionalAddress(event.to),
+		}
+	}
+	return rows
+}
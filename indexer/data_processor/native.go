@@ -0,0 +1,196 @@
+package dataprocessor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math/big"
+	"strings"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/decoder"
+	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// the sources of the native balance changes that don't come from the messages
+const (
+	feeSource            = "fee"
+	storageDepositSource = "storage_deposit"
+	storageRefundSource  = "storage_refund"
+)
+
+// the type urls of the storage deposit events end with these names
+const (
+	storageDepositEvent = "StorageDepositEvent"
+	storageUnlockEvent  = "StorageUnlockEvent"
+)
+
+// coinMove is a move of native coins recognised from a transaction, an empty address is the
+// side of the move the indexer can't know, like the fee collector
+type coinMove struct {
+	transaction TransactionsData
+	txHash      []byte
+	from        string
+	to          string
+	denom       string
+	amount      *big.Int
+	source      string
+}
+
+// nativeMoves finds the native coins moved by the transactions
+//
+// The fee is paid by the first signer of every transaction, the failed ones included. The bank
+// sends, the coins sent with the vm messages and the storage deposits are only taken from the
+// successful transactions.
+//
+// Parameters:
+//   - transactions: the transactions of the chunk
+//   - decodedMsgs: the decoded messages at the same index as the transactions, nil entries are skipped
+//   - addressesMap: the addresses of the moves are added to it so they are resolved with the messages
+//
+// Returns:
+//   - []coinMove: the moves grouped by the transaction
+func nativeMoves(
+	transactions []TransactionsData,
+	decodedMsgs []*decoder.DecodedMsg,
+	addressesMap map[string]struct{},
+) []coinMove {
+	moves := make([]coinMove, 0)
+	for idx, transaction := range transactions {
+		if transaction.Response == nil || idx >= len(decodedMsgs) || decodedMsgs[idx] == nil {
+			continue
+		}
+		txHash, err := base64.StdEncoding.DecodeString(transaction.Response.GetHash())
+		if err != nil {
+			l.Error().Msgf("Failed to decode tx hash %s: %v", transaction.Response.GetHash(), err)
+			continue
+		}
+		decodedMsg := decodedMsgs[idx]
+		var payer string
+		if signers := decodedMsg.GetSigners(); len(signers) > 0 {
+			payer = signers[0]
+		}
+
+		txMoves := make([]coinMove, 0)
+		fee := decodedMsg.GetFee()
+		if payer != "" && fee.Amount.Int != nil && fee.Amount.Int.Sign() > 0 && fee.Denom != "" {
+			txMoves = append(txMoves, coinMove{
+				from:   payer,
+				denom:  fee.Denom,
+				amount: new(big.Int).Set(fee.Amount.Int),
+				source: feeSource,
+			})
+		}
+		if !transaction.Response.HasError() {
+			for _, transfer := range decodedMsg.GetCoinTransfers() {
+				for _, coin := range transfer.Coins {
+					txMoves = append(txMoves, coinMove{
+						from:   transfer.From,
+						to:     transfer.To,
+						denom:  coin.Denom,
+						amount: big.NewInt(coin.Amount),
+						source: transfer.Source,
+					})
+				}
+			}
+			if payer != "" {
+				for _, event := range transaction.Response.GetEvents() {
+					if move, ok := storageDepositMove(event, payer); ok {
+						txMoves = append(txMoves, move)
+					}
+				}
+			}
+		}
+
+		for _, move := range txMoves {
+			move.transaction = transaction
+			move.txHash = txHash
+			for _, address := range []string{move.from, move.to} {
+				if address != "" {
+					addressesMap[address] = struct{}{}
+				}
+			}
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// storageDepositMove reads the coins locked or refunded by a storage deposit event
+//
+// The deposit is locked from the caller to the storage deposit address of the realm and refunded
+// back to the caller. A withheld refund goes to the storage fee collector set in the vm params,
+// the indexer doesn't know it so only the deposit address side is kept.
+func storageDepositMove(event rpcClient.Event, caller string) (coinMove, bool) {
+	if event.PkgPath == "" {
+		return coinMove{}, false
+	}
+	var coin *rpcClient.EventCoin
+	var move coinMove
+	depositAddress := gnolang.DeriveStorageDepositBech32Addr(event.PkgPath).String()
+	switch {
+	case strings.HasSuffix(event.AtType, storageDepositEvent):
+		coin = event.FeeDelta
+		move = coinMove{from: caller, to: depositAddress, source: storageDepositSource}
+	case strings.HasSuffix(event.AtType, storageUnlockEvent):
+		coin = event.FeeRefund
+		move = coinMove{from: depositAddress, to: caller, source: storageRefundSource}
+		if event.RefundWithheld {
+			move.to = ""
+		}
+	default:
+		return coinMove{}, false
+	}
+	if coin == nil || coin.Denom == "" {
+		return coinMove{}, false
+	}
+	amount, ok := new(big.Int).SetString(coin.Amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		return coinMove{}, false
+	}
+	move.denom = coin.Denom
+	move.amount = amount
+	return move, true
+}
+
+// nativeBalanceRows converts the coin moves to the balance changes, every move takes the coins
+// from the sender and adds them to the receiver
+//
+// The addresses need to be resolved by the address cache before this is called.
+func (d *DataProcessor) nativeBalanceRows(moves []coinMove) []sqlDataTypes.NativeBalanceChange {
+	rows := make([]sqlDataTypes.NativeBalanceChange, 0, len(moves)*2)
+	var lastHash []byte
+	var changeIndex int16
+	for _, move := range moves {
+		if !bytes.Equal(move.txHash, lastHash) {
+			lastHash = move.txHash
+			changeIndex = 0
+		}
+		sides := []struct {
+			address string
+			amount  *big.Int
+		}{
+			{move.from, new(big.Int).Neg(move.amount)},
+			{move.to, move.amount},
+		}
+		for _, side := range sides {
+			if side.address == "" {
+				continue
+			}
+			rows = append(rows, sqlDataTypes.NativeBalanceChange{
+				TxHash:      move.txHash,
+				ChainName:   d.chainName,
+				Timestamp:   move.transaction.Timestamp,
+				ChangeIndex: changeIndex,
+				BlockHeight: move.transaction.BlockHeight,
+				Address:     d.addressCache.GetAddress(side.address),
+				Denom:       move.denom,
+				Amount:      pgtype.Numeric{Int: side.amount, Valid: true},
+				Source:      move.source,
+			})
+			changeIndex++
+		}
+	}
+	return rows
+}
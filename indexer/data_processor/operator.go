@@ -19,6 +19,9 @@ var l = logger.Get()
 
 // Constructor function for the DataProcessor struct
 //
+// If the database is a chunk batch the rows are only written on CommitChunk,
+// otherwise every process method writes its rows directly.
+//
 // Parameters:
 //   - db: the database connection interface
 //   - addressCache: the address cache interface
@@ -34,14 +37,46 @@ func NewDataProcessor(
 	addressCache AddressCache,
 	validatorCache AddressCache,
 	chainName string) *DataProcessor {
+	batch, _ := db.(ChunkBatch)
 	return &DataProcessor{
 		dbPool:         db,
+		batch:          batch,
 		addressCache:   addressCache,
 		validatorCache: validatorCache,
 		chainName:      chainName,
 	}
 }
 
+// ResetChunk drops the rows collected for the current chunk.
+// It does nothing if the data processor writes directly to the database.
+func (d *DataProcessor) ResetChunk() {
+	if d.batch != nil {
+		d.batch.Reset()
+	}
+}
+
+// CommitChunk writes all of the rows collected for the current chunk within one transaction.
+// It does nothing if the data processor writes directly to the database.
+//
+// Parameters:
+//   - skipExisting: if true, the rows already stored are kept whatever the insert mode is
+//
+// Returns:
+//   - error: if the chunk couldn't be written, nothing from the chunk is stored in that case
+func (d *DataProcessor) CommitChunk(skipExisting bool) error {
+	if d.batch == nil {
+		return nil
+	}
+	// add multiplier for the timeout depending on the amount of rows
+	timeout := 30*time.Second + (time.Duration(d.batch.Size()) * time.Second / 5)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if skipExisting {
+		return d.batch.CommitSkipExisting(ctx)
+	}
+	return d.batch.Commit(ctx)
+}
+
 // ProcessValidatorAddresses is a method to process the validator addresses from a slice of blocks
 // it will process the validator addresses from the blocks and store them in a map[string]struct{}
 // it will then extract the addresses from the map[string]struct{} and insert them into the address cache
@@ -132,8 +167,12 @@ func processPrecommits(
 // it will process the blocks using async workers and store them directly into a result slice
 // it will then insert the blocks into the database
 //
+// The proposer is resolved with the validator cache, so the validator addresses need to be
+// processed first. The gas totals of every block are summed from the transactions of the chunk.
+//
 // Parameters:
 //   - blocks: a slice of blocks
+//   - transactions: the transactions of the chunk
 //   - fromHeight: the start height
 //   - toHeight: the end height
 //
@@ -141,15 +180,21 @@ func processPrecommits(
 //   - nil
 //
 // The method will not throw an error if the blocks are not found, it will just return nil
-func (d *DataProcessor) ProcessBlocks(blocks []*rpcClient.BlockResponse, fromHeight uint64, toHeight uint64) {
+func (d *DataProcessor) ProcessBlocks(
+	blocks []*rpcClient.BlockResponse,
+	transactions []TransactionsData,
+	fromHeight uint64,
+	toHeight uint64,
+) {
 	// Preallocate slice to avoid growing allocations
 	blockAmount := len(blocks)
 	blocksData := make([]sqlDataTypes.Blocks, blockAmount)
+	gasTotals := blockGasTotals(transactions)
 	wg := sync.WaitGroup{}
 	wg.Add(blockAmount)
 
 	for idx, block := range blocks {
-		go d.processBlock(idx, block, &wg, blocksData)
+		go d.processBlock(idx, block, gasTotals, &wg, blocksData)
 	}
 
 	wg.Wait()
@@ -173,10 +218,38 @@ func (d *DataProcessor) ProcessBlocks(blocks []*rpcClient.BlockResponse, fromHei
 		)
 }
 
+// blockGas holds the gas totals of the transactions of a block
+type blockGas struct {
+	used   uint64
+	wanted uint64
+}
+
+// blockGasTotals sums the gas used and wanted of the transactions by their block height,
+// the failed transactions are counted as well since they are part of the block
+func blockGasTotals(transactions []TransactionsData) map[uint64]blockGas {
+	totals := make(map[uint64]blockGas)
+	for _, transaction := range transactions {
+		gasUsed, err := transaction.Response.GetGasUsed()
+		if err != nil {
+			l.Warn().Msgf("Failed to parse gas used of a tx at height %d: %v", transaction.BlockHeight, err)
+		}
+		gasWanted, err := transaction.Response.GetGasWanted()
+		if err != nil {
+			l.Warn().Msgf("Failed to parse gas wanted of a tx at height %d: %v", transaction.BlockHeight, err)
+		}
+		total := totals[transaction.BlockHeight]
+		total.used += gasUsed
+		total.wanted += gasWanted
+		totals[transaction.BlockHeight] = total
+	}
+	return totals
+}
+
 // processBlock is a helper method to process a block and store it at a pre-allocated slice.
 func (d *DataProcessor) processBlock(
 	idx int,
 	block *rpcClient.BlockResponse,
+	gasTotals map[uint64]blockGas,
 	wg *sync.WaitGroup,
 	blocksData []sqlDataTypes.Blocks,
 ) {
@@ -191,22 +264,63 @@ func (d *DataProcessor) processBlock(
 			)
 		return
 	}
-	height, err := strconv.ParseUint(block.Result.Block.Header.Height, 10, 64)
+	header := block.Result.Block.Header
+	height, err := strconv.ParseUint(header.Height, 10, 64)
 	if err != nil {
 		l.Error().
 			Caller().
 			Stack().
 			Msgf(
-				"Failed to parse block height %s: %v", block.Result.Block.Header.Height, err,
+				"Failed to parse block height %s: %v", header.Height, err,
 			)
 		return
 	}
+	// the counters are informative, a counter that can't be parsed is stored as zero
+	numTxs, _ := strconv.ParseUint(header.NumTxs, 10, 64)
+	totalTxs, _ := strconv.ParseUint(header.TotalTxs, 10, 64)
+
+	headerHashes := []string{
+		header.AppHash,
+		header.LastBlockID.Hash,
+		header.LastCommitHash,
+		header.DataHash,
+		header.ValidatorsHash,
+		header.NextValidatorsHash,
+		header.ConsensusHash,
+	}
+	decoded := make([][]byte, len(headerHashes))
+	for i, headerHash := range headerHashes {
+		decoded[i], err = base64.StdEncoding.DecodeString(headerHash)
+		if err != nil {
+			l.Error().
+				Caller().
+				Stack().
+				Msgf(
+					"Failed to decode header hash %s of block %d: %v", headerHash, height, err,
+				)
+			return
+		}
+	}
+
+	gas := gasTotals[height]
 	blocksData[idx] = sqlDataTypes.Blocks{
-		Hash:      hash,
-		Height:    height,
-		Timestamp: block.Result.Block.Header.Time,
-		ChainID:   block.Result.Block.Header.ChainID,
-		ChainName: d.chainName,
+		Hash:               hash,
+		Height:             height,
+		Timestamp:          header.Time,
+		ChainID:            header.ChainID,
+		ChainName:          d.chainName,
+		Proposer:           d.validatorCache.GetAddress(header.ProposerAddress),
+		NumTxs:             numTxs,
+		TotalTxs:           totalTxs,
+		AppHash:            decoded[0],
+		LastBlockHash:      decoded[1],
+		LastCommitHash:     decoded[2],
+		DataHash:           decoded[3],
+		ValidatorsHash:     decoded[4],
+		NextValidatorsHash: decoded[5],
+		ConsensusHash:      decoded[6],
+		GasUsed:            gas.used,
+		GasWanted:          gas.wanted,
 	}
 }
 
@@ -228,24 +342,45 @@ func (d *DataProcessor) ProcessTransactions(
 	fromHeight uint64,
 	toHeight uint64) {
 
+	// the signers are stored by their address ids, the cache is not safe for concurrent use
+	// so they are solved before the transactions are processed
+	decodedMsgs, signers := transactionSigners(transactions)
+	if len(signers) > 0 {
+		d.addressCache.AddressSolver(signers, d.chainName, false, 3, nil)
+	}
+
 	// Preallocate slice to avoid growing allocations
 	transactionAmount := len(transactions)
 	transactionsData := make([]sqlDataTypes.TransactionGeneral, transactionAmount)
+	eventsData := make([][]sqlDataTypes.TxEvent, transactionAmount)
+	signaturesData := make([][]sqlDataTypes.TxSignature, transactionAmount)
+	accounts := d.signerAccounts(transactions, decodedMsgs)
 	valid := make([]bool, transactionAmount)
 	wg := sync.WaitGroup{}
 	wg.Add(transactionAmount)
 
 	for idx, transaction := range transactions {
-		go d.processTransaction(idx, transaction, &wg, &valid[idx], transactionsData, compressEvents)
+		var signerAccounts map[string]signerAccount
+		if accounts != nil {
+			signerAccounts = accounts[idx]
+		}
+		go d.processTransaction(
+			idx, transaction, decodedMsgs[idx], signerAccounts, &wg, &valid[idx],
+			transactionsData, eventsData, signaturesData, compressEvents,
+		)
 	}
 
 	wg.Wait()
 
 	// Collect only the entries that were successfully processed
 	result := make([]sqlDataTypes.TransactionGeneral, 0, transactionAmount)
+	events := make([]sqlDataTypes.TxEvent, 0)
+	signatures := make([]sqlDataTypes.TxSignature, 0)
 	for idx, ok := range valid {
 		if ok {
 			result = append(result, transactionsData[idx])
+			events = append(events, eventsData[idx]...)
+			signatures = append(signatures, signaturesData[idx]...)
 		}
 	}
 
@@ -262,6 +397,24 @@ func (d *DataProcessor) ProcessTransactions(
 			)
 		return
 	}
+	if err := d.dbPool.InsertTxEvents(ctx, events); err != nil {
+		l.Error().
+			Caller().
+			Stack().
+			Msgf(
+				"Failed to insert tx events: %v", err,
+			)
+		return
+	}
+	if err := d.dbPool.InsertTxSignatures(ctx, signatures); err != nil {
+		l.Error().
+			Caller().
+			Stack().
+			Msgf(
+				"Failed to insert tx signatures: %v", err,
+			)
+		return
+	}
 	l.Info().
 		Msgf(
 			"Transactions processed from %d to %d", fromHeight, toHeight,
@@ -274,16 +427,18 @@ func (d *DataProcessor) ProcessTransactions(
 func (d *DataProcessor) processTransaction(
 	idx int,
 	transaction TransactionsData,
+	decodedMsg *decoder.DecodedMsg,
+	accounts map[string]signerAccount,
 	wg *sync.WaitGroup,
 	valid *bool,
 	transactionsData []sqlDataTypes.TransactionGeneral,
+	eventsData [][]sqlDataTypes.TxEvent,
+	signaturesData [][]sqlDataTypes.TxSignature,
 	compressEvents bool,
 ) {
 	defer wg.Done()
 	txResult := transaction.Response.Result.TxResult
 
-	decodedMsg := decoder.NewDecodedMsg(transaction.Response.Result.Tx)
-
 	fee := decodedMsg.GetFee()
 	msgTypes := decodedMsg.GetMsgTypes()
 
@@ -330,6 +485,12 @@ func (d *DataProcessor) processTransaction(
 		return
 	}
 
+	signers := decodedMsg.GetSigners()
+	signerIds := make([]int32, len(signers))
+	for k, signer := range signers {
+		signerIds[k] = d.addressCache.GetAddress(signer)
+	}
+
 	transactionsData[idx] = sqlDataTypes.TransactionGeneral{
 		TxHash:             txHash,
 		ChainName:          d.chainName,
@@ -342,10 +503,93 @@ func (d *DataProcessor) processTransaction(
 		GasUsed:            gasUsed,
 		GasWanted:          gasWanted,
 		Fee:                fee,
+		Success:            !transaction.Response.HasError(),
+		TxError:            transaction.Response.GetError(),
+		TxLog:              transaction.Response.GetLog(),
+		TxInfo:             transaction.Response.GetInfo(),
+		Memo:               decodedMsg.GetMemo(),
+		Signers:            signerIds,
+		TxIndex:            int32(transaction.Response.Result.Index),
+		GasPrice:           gasPrice(fee, gasUsed),
 	}
+	eventsData[idx] = eventRows(transaction.Response.GetEvents(), txHash, d.chainName, transaction)
+	signaturesData[idx] = d.signatureRows(decodedMsg.GetSignatures(), txHash, transaction, accounts)
 	*valid = true
 }
 
+// transactionSigners decodes the transactions and returns them with their unique signer addresses,
+// the signers of the signatures are included as well
+func transactionSigners(transactions []TransactionsData) ([]*decoder.DecodedMsg, []string) {
+	decodedMsgs := make([]*decoder.DecodedMsg, len(transactions))
+	seen := make(map[string]struct{})
+	signers := make([]string, 0)
+	addSigner := func(signer string) {
+		if _, ok := seen[signer]; ok || signer == "" {
+			return
+		}
+		seen[signer] = struct{}{}
+		signers = append(signers, signer)
+	}
+	for idx, transaction := range transactions {
+		decodedMsgs[idx] = decoder.NewDecodedMsg(transaction.Response.Result.Tx)
+		for _, signer := range decodedMsgs[idx].GetSigners() {
+			addSigner(signer)
+		}
+		for _, signature := range decodedMsgs[idx].GetSignatures() {
+			addSigner(signature.Signer)
+		}
+	}
+	return decodedMsgs, signers
+}
+
+// signatureRows converts the signatures of a transaction to the tx_signatures rows,
+// the accounts hold the account number and the sequence of the signers
+func (d *DataProcessor) signatureRows(
+	signatures []decoder.TxSignature,
+	txHash []byte,
+	transaction TransactionsData,
+	accounts map[string]signerAccount,
+) []sqlDataTypes.TxSignature {
+	rows := make([]sqlDataTypes.TxSignature, 0, len(signatures))
+	for i, signature := range signatures {
+		row := sqlDataTypes.TxSignature{
+			TxHash:         txHash,
+			ChainName:      d.chainName,
+			Timestamp:      transaction.Timestamp,
+			SignatureIndex: int16(i),
+			BlockHeight:    transaction.BlockHeight,
+			PubKey:         signature.PubKey,
+			Signature:      signature.Signature,
+		}
+		if id := d.addressCache.GetAddress(signature.Signer); signature.Signer != "" && id != 0 {
+			row.Signer = &id
+		}
+		if signature.PubKeyType != "" {
+			row.PubKeyType = &signature.PubKeyType
+		}
+		if account, ok := accounts[signature.Signer]; ok && signature.Signer != "" {
+			row.AccountNumber = account.accountNumber
+			row.Sequence = account.sequence
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// gasPrice returns the fee amount paid per unit of gas used,
+// nil when the transaction used no gas or the fee can't be read
+func gasPrice(fee sqlDataTypes.Amount, gasUsed uint64) *float64 {
+	if gasUsed == 0 || !fee.Amount.Valid {
+		return nil
+	}
+	amount, err := fee.Amount.Float64Value()
+	if err != nil || !amount.Valid {
+		return nil
+	}
+	price := amount.Float64 / float64(gasUsed)
+	return &price
+}
+
 // ProcessMessages processes all messages from transactions using concurrent "swarm method"
 // This method uses a two-phase concurrent approach:
 // 1. Collect and resolve all addresses to IDs using concurrent workers and map[string]struct{}
@@ -367,6 +611,9 @@ func (d *DataProcessor) ProcessMessages(
 	var mu sync.Mutex
 	transactionAmount := len(transactions)
 	allDecodedMsgs, addressesMap := transactionDecoding(&mu, transactions, transactionAmount)
+	grc20 := grc20Events(transactions, addressesMap)
+	nfts := nftEvents(transactions, addressesMap)
+	moves := nativeMoves(transactions, allDecodedMsgs, addressesMap)
 
 	// Extract addresses from map[string]struct{} and resolve to IDs
 	allAddresses := extractAddresses(addressesMap)
@@ -381,7 +628,7 @@ func (d *DataProcessor) ProcessMessages(
 	}
 
 	// Phase 2: Process message groups concurrently, each goroutine writes to its own index slot.
-	msgResults := make([]*decoder.DbMessageGroups, transactionAmount)
+	msgResults := make([]*decoder.MsgRows, transactionAmount)
 	wg := sync.WaitGroup{}
 	wg.Add(transactionAmount)
 
@@ -396,22 +643,12 @@ func (d *DataProcessor) ProcessMessages(
 
 	wg.Wait()
 
-	aggregatedDbGroups := &decoder.DbMessageGroups{
-		MsgSend:   make([]sqlDataTypes.MsgSend, 0),
-		MsgCall:   make([]sqlDataTypes.MsgCall, 0),
-		MsgAddPkg: make([]sqlDataTypes.MsgAddPackage, 0),
-		MsgRun:    make([]sqlDataTypes.MsgRun, 0),
-	}
+	aggregatedRows := decoder.NewMsgRows(decoder.DefaultRegistry)
 	for _, result := range msgResults {
-		if result != nil {
-			aggregatedDbGroups.MsgSend = append(aggregatedDbGroups.MsgSend, result.MsgSend...)
-			aggregatedDbGroups.MsgCall = append(aggregatedDbGroups.MsgCall, result.MsgCall...)
-			aggregatedDbGroups.MsgAddPkg = append(aggregatedDbGroups.MsgAddPkg, result.MsgAddPkg...)
-			aggregatedDbGroups.MsgRun = append(aggregatedDbGroups.MsgRun, result.MsgRun...)
-		}
+		aggregatedRows.Merge(result)
 	}
 
-	addresses := createAddressTx(aggregatedDbGroups)
+	addresses := aggregatedRows.AddressTx()
 	timeout := 10*time.Second + (time.Duration(len(addresses)) * time.Second / 5)
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	err := d.dbPool.InsertAddressTx(ctx, addresses)
@@ -420,16 +657,62 @@ func (d *DataProcessor) ProcessMessages(
 		return fmt.Errorf("failed to insert address tx: %w", err)
 	}
 
-	if err := d.insertDbMessageGroups(aggregatedDbGroups); err != nil {
+	// the package files need to be stored before the messages that point to them
+	files := packageFileRows(allDecodedMsgs, d.chainName)
+	timeout = 10*time.Second + (time.Duration(len(files)) * time.Second / 5)
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	err = d.dbPool.InsertPackageFiles(ctx, files)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to insert package files: %w", err)
+	}
+
+	packages := d.packageRows(transactions, allDecodedMsgs)
+	timeout = 10*time.Second + (time.Duration(len(packages)) * time.Second / 5)
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	err = d.dbPool.InsertPackages(ctx, packages)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to insert packages: %w", err)
+	}
+
+	transfers := d.grc20TransferRows(grc20)
+	timeout = 10*time.Second + (time.Duration(len(transfers)) * time.Second / 5)
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	err = d.dbPool.InsertGrc20Transfers(ctx, transfers)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to insert grc20 transfers: %w", err)
+	}
+
+	nftTransfers := d.nftTransferRows(nfts)
+	timeout = 10*time.Second + (time.Duration(len(nftTransfers)) * time.Second / 5)
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	err = d.dbPool.InsertNftTransfers(ctx, nftTransfers)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to insert nft transfers: %w", err)
+	}
+
+	balanceChanges := d.nativeBalanceRows(moves)
+	timeout = 10*time.Second + (time.Duration(len(balanceChanges)) * time.Second / 5)
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	err = d.dbPool.InsertNativeBalanceChanges(ctx, balanceChanges)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to insert native balance changes: %w", err)
+	}
+
+	if err := d.insertMsgRows(aggregatedRows); err != nil {
 		return fmt.Errorf("failed to insert optimized messages: %w", err)
 	}
 
-	l.Info().Msgf("Messages processed concurrently from %d to %d: MsgSend=%d, MsgCall=%d, MsgAddPkg=%d, MsgRun=%d",
-		fromHeight, toHeight,
-		len(aggregatedDbGroups.MsgSend),
-		len(aggregatedDbGroups.MsgCall),
-		len(aggregatedDbGroups.MsgAddPkg),
-		len(aggregatedDbGroups.MsgRun))
+	counts := make([]string, 0)
+	for _, msgType := range aggregatedRows.Types() {
+		counts = append(counts, fmt.Sprintf("%s=%d", msgType, aggregatedRows.Count(msgType)))
+	}
+	l.Info().Msgf("Messages processed concurrently from %d to %d: %s",
+		fromHeight, toHeight, strings.Join(counts, ", "))
 
 	return nil
 }
@@ -493,7 +776,7 @@ func (d *DataProcessor) processMessageGroup(
 	transaction TransactionsData,
 	decodedMsg *decoder.DecodedMsg,
 	wg *sync.WaitGroup,
-	results *[]*decoder.DbMessageGroups,
+	results *[]*decoder.MsgRows,
 ) {
 	defer wg.Done()
 
@@ -521,7 +804,7 @@ func (d *DataProcessor) processMessageGroup(
 		return
 	}
 
-	dbMessageGroups, err := decodedMsg.ConvertToDbMessages(
+	msgRows, err := decodedMsg.ConvertToDbMessages(
 		d.addressCache, txHash, d.chainName, transaction.Timestamp, decodedMsg.GetSigners(),
 	)
 	if err != nil {
@@ -536,88 +819,213 @@ func (d *DataProcessor) processMessageGroup(
 		return
 	}
 
-	(*results)[idx] = dbMessageGroups
+	(*results)[idx] = msgRows
 }
 
-// insertDbMessageGroups performs optimized batch insertions using address IDs
-func (d *DataProcessor) insertDbMessageGroups(groups *decoder.DbMessageGroups) error {
+// insertMsgRows inserts the rows of every message type with the insert function of the type
+func (d *DataProcessor) insertMsgRows(rows *decoder.MsgRows) error {
 	var insertErrors []error
 
-	msgSendCount := len(groups.MsgSend)
-	msgCallCount := len(groups.MsgCall)
-	msgAddPkgCount := len(groups.MsgAddPkg)
-	msgRunCount := len(groups.MsgRun)
-
-	// Insert DbMsgSend messages with address IDs
-	if msgSendCount > 0 {
-		timeout := 10*time.Second + (time.Duration(msgSendCount) * time.Second / 5)
+	for _, msgType := range rows.Types() {
+		timeout := 10*time.Second + (time.Duration(rows.Count(msgType)) * time.Second / 5)
 		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		err := d.dbPool.InsertMsgSend(ctx, groups.MsgSend)
+		err := rows.Insert(ctx, d.dbPool, msgType)
 		cancel()
 		if err != nil {
-			hashes := make([]string, 0, len(groups.MsgSend))
-			for _, msg := range groups.MsgSend {
-				hashes = append(hashes, base64.StdEncoding.EncodeToString(msg.TxHash))
+			txHashes := rows.TxHashes(msgType)
+			hashes := make([]string, 0, len(txHashes))
+			for _, txHash := range txHashes {
+				hashes = append(hashes, base64.StdEncoding.EncodeToString(txHash))
 			}
-			insertErrors = append(insertErrors, fmt.Errorf("failed to insert DbMsgSend: %w, hashes: %v", err, hashes))
+			insertErrors = append(insertErrors, fmt.Errorf("failed to insert %s: %w, hashes: %v", msgType, err, hashes))
 		}
 	}
 
-	// Insert DbMsgCall messages with address IDs
-	if msgCallCount > 0 {
-		timeout := 10*time.Second + (time.Duration(msgCallCount) * time.Second / 5)
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		err := d.dbPool.InsertMsgCall(ctx, groups.MsgCall)
-		cancel()
-		if err != nil {
-			hashes := make([]string, 0, len(groups.MsgCall))
-			for _, msg := range groups.MsgCall {
-				hashes = append(hashes, base64.StdEncoding.EncodeToString(msg.TxHash))
-			}
-			insertErrors = append(insertErrors, fmt.Errorf("failed to insert DbMsgCall: %w, hashes: %v", err, hashes))
+	// Combine all errors if any occurred
+	if len(insertErrors) > 0 {
+		var errorMessages []string
+		for _, err := range insertErrors {
+			errorMessages = append(errorMessages, err.Error())
 		}
+		return fmt.Errorf("multiple insertion errors: %s", strings.Join(errorMessages, "; "))
 	}
 
-	// Insert DbMsgAddPackage messages with address IDs
-	if msgAddPkgCount > 0 {
-		timeout := 10*time.Second + (time.Duration(msgAddPkgCount) * time.Second / 5)
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		err := d.dbPool.InsertMsgAddPackage(ctx, groups.MsgAddPkg)
-		cancel()
-		if err != nil {
-			hashes := make([]string, 0, len(groups.MsgAddPkg))
-			for _, msg := range groups.MsgAddPkg {
-				hashes = append(hashes, base64.StdEncoding.EncodeToString(msg.TxHash))
-			}
-			insertErrors = append(insertErrors, fmt.Errorf("failed to insert DbMsgAddPackage: %w, hashes: %v", err, hashes))
+	return nil
+}
+
+// ProcessValidatorSets stores the validator sets fetched at the heights where the validators hash changed
+// the addresses of the sets are solved with the validator cache first, so the validators that didn't
+// sign any block yet get their id as well
+//
+// Parameters:
+//   - validatorSets: the validator sets with the height and the timestamp of their block
+//   - fromHeight: the start height
+//   - toHeight: the end height
+//
+// The method will not throw an error if a validator set is not valid, it will just skip it
+func (d *DataProcessor) ProcessValidatorSets(validatorSets []ValidatorSetData, fromHeight uint64, toHeight uint64) {
+	if len(validatorSets) == 0 {
+		return
+	}
+
+	addressesMap := make(map[string]struct{})
+	for _, validatorSet := range validatorSets {
+		for _, validator := range validatorSet.Response.GetValidators() {
+			addressesMap[validator.Address] = struct{}{}
 		}
 	}
+	d.validatorCache.AddressSolver(extractAddresses(addressesMap), d.chainName, true, 3, nil)
 
-	// Insert DbMsgRun messages with address IDs
-	if msgRunCount > 0 {
-		timeout := 10*time.Second + (time.Duration(msgRunCount) * time.Second / 5)
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		err := d.dbPool.InsertMsgRun(ctx, groups.MsgRun)
-		cancel()
+	rows := make([]sqlDataTypes.ValidatorSet, 0)
+	for _, validatorSet := range validatorSets {
+		hash, err := base64.StdEncoding.DecodeString(validatorSet.ValidatorsHash)
 		if err != nil {
-			hashes := make([]string, 0, len(groups.MsgRun))
-			for _, msg := range groups.MsgRun {
-				hashes = append(hashes, base64.StdEncoding.EncodeToString(msg.TxHash))
+			l.Error().Msgf("Failed to decode validators hash %s: %v", validatorSet.ValidatorsHash, err)
+			continue
+		}
+		for _, validator := range validatorSet.Response.GetValidators() {
+			votingPower, err := strconv.ParseInt(validator.VotingPower, 10, 64)
+			if err != nil {
+				l.Error().Msgf("Failed to parse voting power %s of %s: %v", validator.VotingPower, validator.Address, err)
+				continue
+			}
+			pubKey, err := base64.StdEncoding.DecodeString(validator.PubKey.Value)
+			if err != nil {
+				l.Error().Msgf("Failed to decode pub key of %s: %v", validator.Address, err)
+				continue
 			}
-			insertErrors = append(insertErrors, fmt.Errorf("failed to insert DbMsgRun: %w, hashes: %v", err, hashes))
+			rows = append(rows, sqlDataTypes.ValidatorSet{
+				BlockHeight:    validatorSet.BlockHeight,
+				Timestamp:      validatorSet.Timestamp,
+				ChainName:      d.chainName,
+				Validator:      d.validatorCache.GetAddress(validator.Address),
+				ValidatorsHash: hash,
+				VotingPower:    votingPower,
+				PubKeyType:     validator.PubKey.Type,
+				PubKey:         pubKey,
+			})
 		}
 	}
 
-	// Combine all errors if any occurred
-	if len(insertErrors) > 0 {
-		var errorMessages []string
-		for _, err := range insertErrors {
-			errorMessages = append(errorMessages, err.Error())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	err := d.dbPool.InsertValidatorSets(ctx, rows)
+	cancel()
+	if err != nil {
+		l.Error().
+			Caller().
+			Stack().
+			Msgf(
+				"Failed to insert validator sets: %v", err,
+			)
+	}
+	l.Info().
+		Msgf("Validator sets processed from %d to %d, %d set(s)", fromHeight, toHeight, len(validatorSets))
+}
+
+// ProcessBlockResults stores the BeginBlock and EndBlock events and the validator updates of the blocks
+// the events of a phase are stored in one row in the native or the compressed format, the phases without
+// events are left out, the addresses of the updates are solved with the validator cache first
+//
+// Parameters:
+//   - blockResults: the block results with the height and the timestamp of their block
+//   - compressEvents: if true, compress the events
+//   - fromHeight: the start height
+//   - toHeight: the end height
+//
+// The method will not throw an error if a block result is not valid, it will just skip it
+func (d *DataProcessor) ProcessBlockResults(
+	blockResults []BlockResultsData,
+	compressEvents bool,
+	fromHeight uint64,
+	toHeight uint64) {
+	if len(blockResults) == 0 {
+		return
+	}
+
+	addressesMap := make(map[string]struct{})
+	for _, blockResult := range blockResults {
+		for _, update := range blockResult.Response.GetValidatorUpdates() {
+			addressesMap[update.Address] = struct{}{}
 		}
-		return fmt.Errorf("multiple insertion errors: %s", strings.Join(errorMessages, "; "))
+	}
+	if len(addressesMap) > 0 {
+		d.validatorCache.AddressSolver(extractAddresses(addressesMap), d.chainName, true, 3, nil)
 	}
 
-	return nil
+	blockEvents := make([]sqlDataTypes.BlockEvent, 0)
+	validatorUpdates := make([]sqlDataTypes.ValidatorUpdate, 0)
+	for _, blockResult := range blockResults {
+		phases := []struct {
+			phase  string
+			events []rpcClient.Event
+		}{
+			{BeginBlockPhase, blockResult.Response.GetBeginBlockEvents()},
+			{EndBlockPhase, blockResult.Response.GetEndBlockEvents()},
+		}
+		for _, phase := range phases {
+			if len(phase.events) == 0 {
+				continue
+			}
+			events, err := solveEvents(phase.events, compressEvents)
+			if err != nil {
+				l.Error().Msgf("Failed to solve the %s events of block %d: %v", phase.phase, blockResult.BlockHeight, err)
+				continue
+			}
+			blockEvents = append(blockEvents, sqlDataTypes.BlockEvent{
+				BlockHeight:      blockResult.BlockHeight,
+				Timestamp:        blockResult.Timestamp,
+				ChainName:        d.chainName,
+				Phase:            phase.phase,
+				Events:           events.GetNativeEvents(),
+				EventsCompressed: events.GetCompressedData(),
+				CompressionOn:    events.IsCompressed(),
+			})
+		}
+
+		for _, update := range blockResult.Response.GetValidatorUpdates() {
+			votingPower, err := strconv.ParseInt(update.Power, 10, 64)
+			if err != nil {
+				l.Error().Msgf("Failed to parse voting power %s of %s: %v", update.Power, update.Address, err)
+				continue
+			}
+			pubKey, err := base64.StdEncoding.DecodeString(update.PubKey.Value)
+			if err != nil {
+				l.Error().Msgf("Failed to decode pub key of %s: %v", update.Address, err)
+				continue
+			}
+			validatorUpdates = append(validatorUpdates, sqlDataTypes.ValidatorUpdate{
+				BlockHeight: blockResult.BlockHeight,
+				Timestamp:   blockResult.Timestamp,
+				ChainName:   d.chainName,
+				Validator:   d.validatorCache.GetAddress(update.Address),
+				VotingPower: votingPower,
+				PubKeyType:  update.PubKey.Type,
+				PubKey:      pubKey,
+			})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := d.dbPool.InsertBlockEvents(ctx, blockEvents); err != nil {
+		l.Error().
+			Caller().
+			Stack().
+			Msgf(
+				"Failed to insert block events: %v", err,
+			)
+	}
+	if err := d.dbPool.InsertValidatorUpdates(ctx, validatorUpdates); err != nil {
+		l.Error().
+			Caller().
+			Stack().
+			Msgf(
+				"Failed to insert validator updates: %v", err,
+			)
+	}
+	l.Info().
+		Msgf("Block results processed from %d to %d, %d block event row(s) and %d validator update(s)",
+			fromHeight, toHeight, len(blockEvents), len(validatorUpdates))
 }
 
 func (d *DataProcessor) ProcessValidatorSignings(
@@ -700,84 +1108,3 @@ func (d *DataProcessor) processValidatorSigning(
 	}
 	*valid = true
 }
-
-// createAddressTx builds a flat slice of AddressTx rows from all message groups.
-func createAddressTx(msgGroups *decoder.DbMessageGroups) []sqlDataTypes.AddressTx {
-	msgSendCount := len(msgGroups.MsgSend)
-	msgCallCount := len(msgGroups.MsgCall)
-	msgAddPkgCount := len(msgGroups.MsgAddPkg)
-	msgRunCount := len(msgGroups.MsgRun)
-	addresses := make([]sqlDataTypes.AddressTx, 0)
-
-	if msgSendCount > 0 {
-		for _, msgItem := range msgGroups.MsgSend {
-			addresses = append(
-				addresses, addressTxFromMsg(
-					msgItem.GetAllAddresses(),
-					msgItem.ChainName,
-					msgItem.Timestamp,
-					msgItem.TableName(),
-				)...,
-			)
-		}
-	}
-	if msgCallCount > 0 {
-		for _, msgItem := range msgGroups.MsgCall {
-			addresses = append(
-				addresses, addressTxFromMsg(
-					msgItem.GetAllAddresses(),
-					msgItem.ChainName,
-					msgItem.Timestamp,
-					msgItem.TableName(),
-				)...,
-			)
-		}
-	}
-	if msgAddPkgCount > 0 {
-		for _, msgItem := range msgGroups.MsgAddPkg {
-			addresses = append(
-				addresses, addressTxFromMsg(
-					msgItem.GetAllAddresses(),
-					msgItem.ChainName,
-					msgItem.Timestamp,
-					msgItem.TableName(),
-				)...,
-			)
-		}
-	}
-	if msgRunCount > 0 {
-		for _, msgItem := range msgGroups.MsgRun {
-			addresses = append(
-				addresses, addressTxFromMsg(
-					msgItem.GetAllAddresses(),
-					msgItem.ChainName,
-					msgItem.Timestamp,
-					msgItem.TableName(),
-				)...,
-			)
-		}
-	}
-
-	return addresses
-}
-
-// addressTxFromMsg converts the address list from a single message into AddressTx rows.
-func addressTxFromMsg(
-	txAddresses *sqlDataTypes.TxAddresses,
-	chainName string,
-	timestamp time.Time,
-	msgType string,
-) []sqlDataTypes.AddressTx {
-	addrList := txAddresses.GetAddressList()
-	result := make([]sqlDataTypes.AddressTx, len(addrList))
-	for idx, addr := range addrList {
-		result[idx] = sqlDataTypes.AddressTx{
-			Address:   addr,
-			TxHash:    txAddresses.TxHash,
-			ChainName: chainName,
-			Timestamp: timestamp,
-			MsgTypes:  []string{msgType},
-		}
-	}
-	return result
-}
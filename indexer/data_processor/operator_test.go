@@ -2,10 +2,25 @@ package dataprocessor_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	dataProcessor "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/data_processor"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/decoder"
+	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
 	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 // Simple Mock Database for basic testing
@@ -13,10 +28,26 @@ type MockDatabase struct {
 	InsertBlocksCalled       bool
 	InsertTransactionsCalled bool
 	LastInsertError          error
+	MsgSend                  []sqlDataTypes.MsgSend
+	MsgGeneric               []sqlDataTypes.MsgGeneric
+	MsgAddPackage            []sqlDataTypes.MsgAddPackage
+	PackageFiles             []sqlDataTypes.PackageFile
+	Packages                 []sqlDataTypes.Package
+	TxEvents                 []sqlDataTypes.TxEvent
+	Grc20Transfers           []sqlDataTypes.Grc20Transfer
+	NftTransfers             []sqlDataTypes.NftTransfer
+	NativeBalanceChanges     []sqlDataTypes.NativeBalanceChange
+	ValidatorSets            []sqlDataTypes.ValidatorSet
+	Blocks                   []sqlDataTypes.Blocks
+	ValidatorUpdates         []sqlDataTypes.ValidatorUpdate
+	BlockEvents              []sqlDataTypes.BlockEvent
+	TransactionsGeneral      []sqlDataTypes.TransactionGeneral
+	TxSignatures             []sqlDataTypes.TxSignature
 }
 
 func (m *MockDatabase) InsertBlocks(ctx context.Context, blocks []sqlDataTypes.Blocks) error {
 	m.InsertBlocksCalled = true
+	m.Blocks = append(m.Blocks, blocks...)
 	return m.LastInsertError
 }
 
@@ -24,12 +55,29 @@ func (m *MockDatabase) InsertValidatorBlockSignings(ctx context.Context, signing
 	return m.LastInsertError
 }
 
+func (m *MockDatabase) InsertValidatorSets(ctx context.Context, validatorSets []sqlDataTypes.ValidatorSet) error {
+	m.ValidatorSets = append(m.ValidatorSets, validatorSets...)
+	return m.LastInsertError
+}
+
+func (m *MockDatabase) InsertValidatorUpdates(ctx context.Context, validatorUpdates []sqlDataTypes.ValidatorUpdate) error {
+	m.ValidatorUpdates = append(m.ValidatorUpdates, validatorUpdates...)
+	return m.LastInsertError
+}
+
+func (m *MockDatabase) InsertBlockEvents(ctx context.Context, blockEvents []sqlDataTypes.BlockEvent) error {
+	m.BlockEvents = append(m.BlockEvents, blockEvents...)
+	return m.LastInsertError
+}
+
 func (m *MockDatabase) InsertTransactionsGeneral(ctx context.Context, transactions []sqlDataTypes.TransactionGeneral) error {
 	m.InsertTransactionsCalled = true
+	m.TransactionsGeneral = append(m.TransactionsGeneral, transactions...)
 	return m.LastInsertError
 }
 
 func (m *MockDatabase) InsertMsgSend(ctx context.Context, messages []sqlDataTypes.MsgSend) error {
+	m.MsgSend = append(m.MsgSend, messages...)
 	return m.LastInsertError
 }
 
@@ -38,6 +86,7 @@ func (m *MockDatabase) InsertMsgCall(ctx context.Context, messages []sqlDataType
 }
 
 func (m *MockDatabase) InsertMsgAddPackage(ctx context.Context, messages []sqlDataTypes.MsgAddPackage) error {
+	m.MsgAddPackage = append(m.MsgAddPackage, messages...)
 	return m.LastInsertError
 }
 
@@ -45,10 +94,77 @@ func (m *MockDatabase) InsertMsgRun(ctx context.Context, messages []sqlDataTypes
 	return m.LastInsertError
 }
 
+func (m *MockDatabase) InsertMsgGeneric(ctx context.Context, messages []sqlDataTypes.MsgGeneric) error {
+	m.MsgGeneric = append(m.MsgGeneric, messages...)
+	return m.LastInsertError
+}
+
+func (m *MockDatabase) InsertTxEvents(ctx context.Context, events []sqlDataTypes.TxEvent) error {
+	m.TxEvents = append(m.TxEvents, events...)
+	return m.LastInsertError
+}
+
+func (m *MockDatabase) InsertTxSignatures(ctx context.Context, signatures []sqlDataTypes.TxSignature) error {
+	m.TxSignatures = append(m.TxSignatures, signatures...)
+	return m.LastInsertError
+}
+
 func (m *MockDatabase) InsertAddressTx(ctx context.Context, addresses []sqlDataTypes.AddressTx) error {
 	return m.LastInsertError
 }
 
+func (m *MockDatabase) InsertPackageFiles(ctx context.Context, files []sqlDataTypes.PackageFile) error {
+	m.PackageFiles = append(m.PackageFiles, files...)
+	return m.LastInsertError
+}
+
+func (m *MockDatabase) InsertPackages(ctx context.Context, packages []sqlDataTypes.Package) error {
+	m.Packages = append(m.Packages, packages...)
+	return m.LastInsertError
+}
+
+func (m *MockDatabase) InsertGrc20Transfers(ctx context.Context, transfers []sqlDataTypes.Grc20Transfer) error {
+	m.Grc20Transfers = append(m.Grc20Transfers, transfers...)
+	return m.LastInsertError
+}
+
+func (m *MockDatabase) InsertNftTransfers(ctx context.Context, transfers []sqlDataTypes.NftTransfer) error {
+	m.NftTransfers = append(m.NftTransfers, transfers...)
+	return m.LastInsertError
+}
+
+func (m *MockDatabase) InsertNativeBalanceChanges(ctx context.Context, changes []sqlDataTypes.NativeBalanceChange) error {
+	m.NativeBalanceChanges = append(m.NativeBalanceChanges, changes...)
+	return m.LastInsertError
+}
+
+// Mock chunk batch, records the commits and the resets
+type MockChunkBatch struct {
+	MockDatabase
+	CommitCalls     int
+	SkipCommitCalls int
+	ResetCalls      int
+	CommitError     error
+}
+
+func (m *MockChunkBatch) Size() int {
+	return 0
+}
+
+func (m *MockChunkBatch) Reset() {
+	m.ResetCalls++
+}
+
+func (m *MockChunkBatch) Commit(ctx context.Context) error {
+	m.CommitCalls++
+	return m.CommitError
+}
+
+func (m *MockChunkBatch) CommitSkipExisting(ctx context.Context) error {
+	m.SkipCommitCalls++
+	return m.CommitError
+}
+
 // Simple Mock AddressCache
 type MockAddressCache struct {
 	ReturnID int32
@@ -158,6 +274,735 @@ func TestDataProcessor_WithDatabaseError(t *testing.T) {
 	}
 }
 
+// Test the chunk is committed through the batch when the database supports it
+func TestDataProcessor_CommitChunk(t *testing.T) {
+	mockBatch := &MockChunkBatch{}
+	dp := dataProcessor.NewDataProcessor(mockBatch, &MockAddressCache{}, &MockAddressCache{}, "test-chain")
+
+	dp.ResetChunk()
+	if err := dp.CommitChunk(false); err != nil {
+		t.Errorf("CommitChunk should not return error, got: %v", err)
+	}
+	if mockBatch.ResetCalls != 1 || mockBatch.CommitCalls != 1 {
+		t.Errorf("expected one reset and one commit, got %d and %d", mockBatch.ResetCalls, mockBatch.CommitCalls)
+	}
+
+	// the backfill keeps the rows that are already stored
+	if err := dp.CommitChunk(true); err != nil {
+		t.Errorf("CommitChunk should not return error, got: %v", err)
+	}
+	if mockBatch.CommitCalls != 1 || mockBatch.SkipCommitCalls != 1 {
+		t.Errorf("expected one commit and one skip commit, got %d and %d", mockBatch.CommitCalls, mockBatch.SkipCommitCalls)
+	}
+
+	mockBatch.CommitError = &TestError{"commit failed"}
+	if err := dp.CommitChunk(false); err == nil {
+		t.Error("expected CommitChunk to return the commit error")
+	}
+
+	// a plain database writes directly, there is nothing to commit
+	direct := dataProcessor.NewDataProcessor(&MockDatabase{}, &MockAddressCache{}, &MockAddressCache{}, "test-chain")
+	direct.ResetChunk()
+	if err := direct.CommitChunk(false); err != nil {
+		t.Errorf("CommitChunk without a batch should not return error, got: %v", err)
+	}
+}
+
+// rawTestTx has the same amino layout as std.Tx, it is used to build a tx with a message
+// that is not registered with amino
+type rawTestTx struct {
+	Msgs       []rawTestAny
+	Fee        std.Fee
+	Signatures []std.Signature
+	Memo       string
+}
+
+type rawTestAny struct {
+	TypeURL string
+	Value   []byte
+}
+
+// txData builds the transaction data of the tx with its result at the height,
+// the hash is the sha256 of the amino bytes like the one computed from the block
+func txData(t *testing.T, tx any, result rpcClient.TxResult, height uint64) dataProcessor.TransactionsData {
+	t.Helper()
+	bz, err := amino.Marshal(tx)
+	if err != nil {
+		t.Fatalf("failed to marshal the tx: %v", err)
+	}
+	txHash := sha256.Sum256(bz)
+	return dataProcessor.TransactionsData{
+		Response: &rpcClient.TxResponse{Result: rpcClient.TxResultData{
+			Hash:     base64.StdEncoding.EncodeToString(txHash[:]),
+			Tx:       base64.StdEncoding.EncodeToString(bz),
+			TxResult: result,
+		}},
+		Timestamp:   time.Now(),
+		BlockHeight: height,
+	}
+}
+
+// Test the messages without their own table are stored as generic messages
+// and the known messages of the same tx are still decoded
+func TestDataProcessor_GenericMessages(t *testing.T) {
+	fromAddress := crypto.AddressFromPreimage([]byte("from"))
+	toAddress := crypto.AddressFromPreimage([]byte("to"))
+	msgSend := bank.MsgSend{
+		FromAddress: fromAddress,
+		ToAddress:   toAddress,
+		Amount:      std.NewCoins(std.NewCoin("ugnot", 1000)),
+	}
+
+	tx := rawTestTx{
+		Msgs: []rawTestAny{
+			{TypeURL: "/bank.MsgSend", Value: amino.MustMarshal(msgSend)},
+			{TypeURL: "/test.MsgUnknown", Value: []byte{0x0a, 0x03, 'a', 'b', 'c'}},
+		},
+		Fee:  std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+		Memo: "generic",
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 7}, &MockAddressCache{}, "test-chain")
+
+	transactions := []dataProcessor.TransactionsData{txData(t, tx, rpcClient.TxResult{}, 1)}
+
+	if err := dp.ProcessMessages(transactions, 1, 1); err != nil {
+		t.Fatalf("ProcessMessages should not return error, got: %v", err)
+	}
+
+	if len(mockDB.MsgSend) != 1 {
+		t.Fatalf("expected 1 MsgSend, got %d", len(mockDB.MsgSend))
+	}
+	if len(mockDB.MsgGeneric) != 1 {
+		t.Fatalf("expected 1 MsgGeneric, got %d", len(mockDB.MsgGeneric))
+	}
+	generic := mockDB.MsgGeneric[0]
+	if generic.TypeUrl != "/test.MsgUnknown" {
+		t.Errorf("expected type url /test.MsgUnknown, got %s", generic.TypeUrl)
+	}
+	if generic.MessageCounter != 1 {
+		t.Errorf("expected message counter 1, got %d", generic.MessageCounter)
+	}
+	if len(generic.Signers) != 1 || generic.Signers[0] != 7 {
+		t.Errorf("expected the signer of the MsgSend, got %v", generic.Signers)
+	}
+	if !json.Valid([]byte(generic.Payload)) {
+		t.Errorf("expected a json payload, got %s", generic.Payload)
+	}
+}
+
+// msgTest is an app specific message registered with amino only by the test
+type msgTest struct {
+	Sender crypto.Address
+	Value  string
+}
+
+func (m msgTest) Route() string                { return "test" }
+func (m msgTest) Type() string                 { return "test" }
+func (m msgTest) ValidateBasic() error         { return nil }
+func (m msgTest) GetSignBytes() []byte         { return nil }
+func (m msgTest) GetSigners() []crypto.Address { return []crypto.Address{m.Sender} }
+
+var _ = amino.RegisterPackage(amino.NewPackage(
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/data_processor_test",
+	"dataprocessortest",
+	amino.GetCallersDirname(),
+).WithDependencies().WithTypes(
+	msgTest{}, "MsgTest",
+))
+
+// msgTestRow is the database row of the msgTest
+type msgTestRow struct {
+	TxHash []byte
+	Sender int32
+	Value  string
+}
+
+func (r msgTestRow) TableName() string {
+	return "test_msg"
+}
+
+func (r msgTestRow) GetAllAddresses() *sqlDataTypes.TxAddresses {
+	txAddresses := sqlDataTypes.NewTxAddresses(r.TxHash)
+	txAddresses.AddAddress(r.Sender)
+	return txAddresses
+}
+
+// Mock database that can also store the rows of the registered tables
+type MockTestMsgDatabase struct {
+	MockDatabase
+	TableRows []database.TableRows
+}
+
+func (m *MockTestMsgDatabase) InsertRows(ctx context.Context, rows database.TableRows) error {
+	m.TableRows = append(m.TableRows, rows)
+	return nil
+}
+
+// Test an app specific message can be added to the registry without changing the data processor
+func TestDataProcessor_RegisteredMessage(t *testing.T) {
+	err := decoder.Register(decoder.DefaultRegistry, decoder.MessageType[msgTest, msgTestRow]{
+		Name: "test_msg",
+		Decode: func(msg msgTest) map[string]any {
+			return map[string]any{"sender": msg.Sender.String(), "value": msg.Value}
+		},
+		Addresses: func(msgMap map[string]any) []string {
+			return []string{msgMap["sender"].(string)}
+		},
+		Convert: func(msgMap map[string]any, tx decoder.MsgTx) (msgTestRow, error) {
+			return msgTestRow{
+				TxHash: tx.TxHash,
+				Sender: tx.Addresses.GetAddress(msgMap["sender"].(string)),
+				Value:  msgMap["value"].(string),
+			}, nil
+		},
+		Table: database.Table{
+			Name:    "test_msg",
+			Columns: []string{"tx_hash", "chain_name", "timestamp", "sender", "value"},
+			Key:     []string{"tx_hash", "chain_name", "timestamp"},
+		},
+		Values: func(row msgTestRow) []any {
+			return []any{row.TxHash, "test-chain", time.Time{}, row.Sender, row.Value}
+		},
+		Transfers: func(msgMap map[string]any) []decoder.CoinTransfer {
+			return []decoder.CoinTransfer{{
+				From:   msgMap["sender"].(string),
+				To:     crypto.AddressFromPreimage([]byte("receiver")).String(),
+				Coins:  []decoder.Coin{{Amount: 25, Denom: "ugnot"}},
+				Source: "test_send",
+			}}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Register should not return error, got: %v", err)
+	}
+
+	// the same name can't be registered twice
+	err = decoder.Register(decoder.DefaultRegistry, decoder.MessageType[msgTest, msgTestRow]{Name: "test_msg"})
+	if err == nil {
+		t.Error("expected Register to fail without the functions")
+	}
+
+	tx := std.Tx{
+		Msgs: []std.Msg{msgTest{Sender: crypto.AddressFromPreimage([]byte("sender")), Value: "hello"}},
+		Fee:  std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+	}
+
+	mockDB := &MockTestMsgDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 3}, &MockAddressCache{}, "test-chain")
+	transactions := []dataProcessor.TransactionsData{txData(t, tx, rpcClient.TxResult{}, 1)}
+
+	if err := dp.ProcessMessages(transactions, 1, 1); err != nil {
+		t.Fatalf("ProcessMessages should not return error, got: %v", err)
+	}
+	if len(mockDB.TableRows) != 1 || mockDB.TableRows[0].Table != "test_msg" || len(mockDB.TableRows[0].Rows) != 1 {
+		t.Fatalf("expected the test message to be stored, got %v", mockDB.TableRows)
+	}
+	values := mockDB.TableRows[0].Rows[0]
+	if values[3] != int32(3) || values[4] != "hello" {
+		t.Errorf("expected the sender and the value of the test message, got %v", values)
+	}
+	if len(mockDB.MsgGeneric) != 0 {
+		t.Errorf("expected no generic messages, got %d", len(mockDB.MsgGeneric))
+	}
+
+	// the coins moved by the registered message are stored after the fee
+	changes := mockDB.NativeBalanceChanges
+	if len(changes) != 3 {
+		t.Fatalf("expected the fee and the test transfer balance changes, got %d", len(changes))
+	}
+	if changes[1].Source != "test_send" || changes[1].Amount.Int.Int64() != -25 ||
+		changes[2].Source != "test_send" || changes[2].Amount.Int.Int64() != 25 {
+		t.Errorf("unexpected balance changes of the test transfer %+v %+v", changes[1], changes[2])
+	}
+}
+
+func TestDataProcessor_PackageFiles(t *testing.T) {
+	creator := crypto.AddressFromPreimage([]byte("creator"))
+	shared := &std.MemFile{Name: "gnomod.toml", Body: "module = \"gno.land/r/test/pkg\""}
+	tx := std.Tx{
+		Msgs: []std.Msg{
+			vm.MsgAddPackage{Creator: creator, Package: &std.MemPackage{
+				Name: "pkg", Path: "gno.land/r/test/pkg",
+				Files: []*std.MemFile{{Name: "pkg.gno", Body: "package pkg"}, shared},
+			}},
+			vm.MsgAddPackage{Creator: creator, Package: &std.MemPackage{
+				Name: "other", Path: "gno.land/r/test/other",
+				Files: []*std.MemFile{{Name: "other.gno", Body: "package other"}, shared},
+			}},
+		},
+		Fee: std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 1}, &MockAddressCache{}, "test-chain")
+	transactions := []dataProcessor.TransactionsData{txData(t, tx, rpcClient.TxResult{}, 1)}
+
+	if err := dp.ProcessMessages(transactions, 1, 1); err != nil {
+		t.Fatalf("ProcessMessages should not return error, got: %v", err)
+	}
+
+	// the shared file is stored only once
+	if len(mockDB.PackageFiles) != 3 {
+		t.Fatalf("expected 3 package files, got %d", len(mockDB.PackageFiles))
+	}
+	stored := make(map[string]sqlDataTypes.PackageFile)
+	for _, file := range mockDB.PackageFiles {
+		stored[string(file.FileHash)] = file
+	}
+
+	if len(mockDB.MsgAddPackage) != 2 {
+		t.Fatalf("expected 2 MsgAddPackage, got %d", len(mockDB.MsgAddPackage))
+	}
+	for _, msg := range mockDB.MsgAddPackage {
+		if len(msg.PkgFileNames) != 2 || len(msg.PkgFileHashes) != 2 {
+			t.Fatalf("expected 2 file names and hashes, got %v and %d", msg.PkgFileNames, len(msg.PkgFileHashes))
+		}
+		for _, hash := range msg.PkgFileHashes {
+			file, ok := stored[string(hash)]
+			if !ok {
+				t.Errorf("the file hash of %s is not stored", msg.PkgPath)
+				continue
+			}
+			if file.ChainName != "test-chain" || file.Size <= 0 || len(file.Content) == 0 {
+				t.Errorf("unexpected package file %+v", file)
+			}
+		}
+	}
+	sharedHash := sha256.Sum256([]byte(shared.Body))
+	if string(mockDB.MsgAddPackage[0].PkgFileHashes[1]) != string(sharedHash[:]) {
+		t.Error("expected the hashes to follow the order of the file names")
+	}
+}
+
+func TestDataProcessor_Packages(t *testing.T) {
+	creator := crypto.AddressFromPreimage([]byte("creator"))
+	addPackageTx := func(path string, memo string) std.Tx {
+		return std.Tx{
+			Msgs: []std.Msg{vm.MsgAddPackage{Creator: creator, Package: &std.MemPackage{
+				Name: "pkg", Path: path,
+				Files: []*std.MemFile{{Name: "pkg.gno", Body: "package pkg"}, {Name: "gnomod.toml", Body: path}},
+			}}},
+			Fee:  std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+			Memo: memo,
+		}
+	}
+
+	first := txData(t, addPackageTx("gno.land/r/test/pkg", "first"), rpcClient.TxResult{}, 10)
+	transactions := []dataProcessor.TransactionsData{
+		txData(t, addPackageTx("gno.land/r/test/pkg", "later"), rpcClient.TxResult{}, 20),
+		first,
+		// the failed deployment doesn't add the package
+		txData(t, addPackageTx("gno.land/r/test/failed", "failed"), rpcClient.TxResult{
+			ResponseBase: rpcClient.ResponseBase{Error: map[string]any{"@type": "/vm.TypeCheckError"}},
+		}, 15),
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 7}, &MockAddressCache{}, "test-chain")
+	if err := dp.ProcessMessages(transactions, 10, 20); err != nil {
+		t.Fatalf("ProcessMessages should not return error, got: %v", err)
+	}
+
+	if len(mockDB.Packages) != 1 {
+		t.Fatalf("expected 1 package, got %d", len(mockDB.Packages))
+	}
+	pkg := mockDB.Packages[0]
+	if pkg.PkgPath != "gno.land/r/test/pkg" || pkg.PkgName != "pkg" || pkg.Creator != 7 {
+		t.Errorf("unexpected package %+v", pkg)
+	}
+	if pkg.FirstHeight != 10 || base64.StdEncoding.EncodeToString(pkg.TxHash) != first.Response.Result.Hash {
+		t.Errorf("expected the first deployment to be kept, got height %d", pkg.FirstHeight)
+	}
+	if pkg.FileCount != 2 || pkg.ChainName != "test-chain" {
+		t.Errorf("unexpected file count %d or chain name %s", pkg.FileCount, pkg.ChainName)
+	}
+}
+
+func TestDataProcessor_TxEvents(t *testing.T) {
+	tx := std.Tx{Fee: std.NewFee(100000, std.NewCoin("ugnot", 1000)), Memo: "events"}
+	transactions := []dataProcessor.TransactionsData{txData(t, tx, rpcClient.TxResult{
+		GasWanted: "100000",
+		GasUsed:   "50000",
+		ResponseBase: rpcClient.ResponseBase{Events: []rpcClient.Event{
+			{
+				AtType:  "/tm.GnoEvent",
+				Type:    "Transfer",
+				PkgPath: "gno.land/r/demo/foo20",
+				Attrs: []rpcClient.EventAttribute{
+					{Key: "from", Value: "g1from"},
+					{Key: "to", Value: "g1to"},
+				},
+			},
+			{AtType: "/tm.GnoEvent", Type: "Ping", PkgPath: "gno.land/r/demo/ping"},
+		}},
+	}, 5)}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{}, &MockAddressCache{}, "test-chain")
+	dp.ProcessTransactions(transactions, false, 5, 5)
+
+	if len(mockDB.TxEvents) != 3 {
+		t.Fatalf("expected 3 event rows, got %d", len(mockDB.TxEvents))
+	}
+	to := mockDB.TxEvents[1]
+	if to.EventIndex != 0 || to.AttrIndex != 1 || to.AttrKey == nil || *to.AttrKey != "to" || *to.AttrValue != "g1to" {
+		t.Errorf("unexpected attribute row %+v", to)
+	}
+	if to.EventType != "Transfer" || to.PkgPath != "gno.land/r/demo/foo20" || to.BlockHeight != 5 {
+		t.Errorf("unexpected event row %+v", to)
+	}
+	// the event without attributes still gets a row
+	ping := mockDB.TxEvents[2]
+	if ping.EventIndex != 1 || ping.AttrIndex != 0 || ping.AttrKey != nil || ping.AttrValue != nil {
+		t.Errorf("unexpected row for the event without attributes %+v", ping)
+	}
+}
+
+// Test the memo, the signers, the index within the block and the gas price are stored
+func TestDataProcessor_TransactionGeneral(t *testing.T) {
+	msgSend := bank.MsgSend{
+		FromAddress: crypto.AddressFromPreimage([]byte("from")),
+		ToAddress:   crypto.AddressFromPreimage([]byte("to")),
+		Amount:      std.NewCoins(std.NewCoin("ugnot", 1000)),
+	}
+	tx := std.Tx{
+		Msgs: []std.Msg{msgSend},
+		Fee:  std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+		Memo: "general",
+	}
+
+	transaction := func(gasUsed string, index int) dataProcessor.TransactionsData {
+		data := txData(t, tx, rpcClient.TxResult{GasWanted: "100000", GasUsed: gasUsed}, 5)
+		data.Response.Result.Index = index
+		return data
+	}
+	transactions := []dataProcessor.TransactionsData{transaction("50000", 2), transaction("0", 3)}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 7}, &MockAddressCache{}, "test-chain")
+	dp.ProcessTransactions(transactions, false, 5, 5)
+
+	if len(mockDB.TransactionsGeneral) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(mockDB.TransactionsGeneral))
+	}
+	general := mockDB.TransactionsGeneral[0]
+	if general.Memo != "general" || general.TxIndex != 2 {
+		t.Errorf("unexpected memo %q or tx index %d", general.Memo, general.TxIndex)
+	}
+	if len(general.Signers) != 1 || general.Signers[0] != 7 {
+		t.Errorf("expected the signer id 7, got %v", general.Signers)
+	}
+	if general.GasPrice == nil || *general.GasPrice != 0.02 {
+		t.Errorf("expected the gas price 0.02, got %v", general.GasPrice)
+	}
+	// a transaction without used gas has no gas price
+	if noGas := mockDB.TransactionsGeneral[1]; noGas.GasPrice != nil || noGas.TxIndex != 3 {
+		t.Errorf("unexpected gas price %v or tx index %d", noGas.GasPrice, noGas.TxIndex)
+	}
+}
+
+// Test the signatures are stored in the order of the signers, with the public key when it is included
+func TestDataProcessor_TxSignatures(t *testing.T) {
+	firstKey := secp256k1.GenPrivKey()
+	secondKey := secp256k1.GenPrivKey()
+	send := func(from crypto.Address) bank.MsgSend {
+		return bank.MsgSend{
+			FromAddress: from,
+			ToAddress:   crypto.AddressFromPreimage([]byte("to")),
+			Amount:      std.NewCoins(std.NewCoin("ugnot", 1000)),
+		}
+	}
+	tx := std.Tx{
+		Msgs: []std.Msg{send(firstKey.PubKey().Address()), send(secondKey.PubKey().Address())},
+		Fee:  std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+		// the second signer already has the public key stored on the chain
+		Signatures: []std.Signature{
+			{PubKey: firstKey.PubKey(), Signature: []byte("first")},
+			{Signature: []byte("second")},
+		},
+	}
+	transactions := []dataProcessor.TransactionsData{
+		txData(t, tx, rpcClient.TxResult{GasWanted: "100000", GasUsed: "50000"}, 5),
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 7}, &MockAddressCache{}, "test-chain")
+	dp.ProcessTransactions(transactions, false, 5, 5)
+
+	if len(mockDB.TxSignatures) != 2 {
+		t.Fatalf("expected 2 signatures, got %d", len(mockDB.TxSignatures))
+	}
+	first := mockDB.TxSignatures[0]
+	if first.SignatureIndex != 0 || first.Signer == nil || *first.Signer != 7 || string(first.Signature) != "first" {
+		t.Errorf("unexpected first signature %+v", first)
+	}
+	if first.PubKeyType == nil || *first.PubKeyType != "/tm.PubKeySecp256k1" || len(first.PubKey) != 33 {
+		t.Errorf("unexpected public key %v %x", first.PubKeyType, first.PubKey)
+	}
+	// the signer of a signature without the public key is taken from the signers of the messages
+	second := mockDB.TxSignatures[1]
+	if second.SignatureIndex != 1 || second.Signer == nil || second.PubKeyType != nil || second.PubKey != nil {
+		t.Errorf("unexpected second signature %+v", second)
+	}
+}
+
+// Mock abci querier that returns the amino JSON of the account for the path
+type MockAccountQuerier struct {
+	Accounts map[string]string
+	Heights  map[string]uint64
+	mu       sync.Mutex
+}
+
+func (m *MockAccountQuerier) GetAbciQuery(path string, data string, height *uint64, prove *bool) (any, error) {
+	m.mu.Lock()
+	m.Heights[path] = *height
+	m.mu.Unlock()
+	account, ok := m.Accounts[path]
+	if !ok {
+		account = "null"
+	}
+	return map[string]any{"response": map[string]any{"ResponseBase": map[string]any{
+		"Error": nil,
+		"Data":  base64.StdEncoding.EncodeToString([]byte(account)),
+	}}}, nil
+}
+
+// Test the account number and the sequence of the signatures are read from the state before the block
+// and the sequence grows with every earlier transaction of the block signed by the same account
+func TestDataProcessor_TxSignatureAccounts(t *testing.T) {
+	first := secp256k1.GenPrivKey()
+	second := secp256k1.GenPrivKey()
+	signed := func(key secp256k1.PrivKeySecp256k1, memo string) std.Tx {
+		return std.Tx{
+			Msgs: []std.Msg{bank.MsgSend{
+				FromAddress: key.PubKey().Address(),
+				ToAddress:   crypto.AddressFromPreimage([]byte("to")),
+				Amount:      std.NewCoins(std.NewCoin("ugnot", 1000)),
+			}},
+			Fee:        std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+			Signatures: []std.Signature{{PubKey: key.PubKey(), Signature: []byte(memo)}},
+			Memo:       memo,
+		}
+	}
+	result := rpcClient.TxResult{GasWanted: "100000", GasUsed: "50000"}
+	earlier := txData(t, signed(first, "earlier"), result, 5)
+	later := txData(t, signed(first, "later"), result, 5)
+	later.Response.Result.Index = 1
+	// the second account is created within the block, so it doesn't exist in the state before it
+	created := txData(t, signed(second, "created"), result, 6)
+
+	firstPath := "auth/accounts/" + first.PubKey().Address().String()
+	secondPath := "auth/accounts/" + second.PubKey().Address().String()
+	querier := &MockAccountQuerier{
+		Accounts: map[string]string{
+			firstPath: `{"BaseAccount": {"account_number": "12", "sequence": "3"}}`,
+		},
+		Heights: make(map[string]uint64),
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 7}, &MockAddressCache{}, "test-chain")
+	dp.SetAccountQuerier(querier)
+	// the transactions of the chunk are not in the block order
+	dp.ProcessTransactions([]dataProcessor.TransactionsData{later, created, earlier}, false, 5, 6)
+
+	if querier.Heights[firstPath] != 4 || querier.Heights[secondPath] != 5 {
+		t.Errorf("expected the accounts to be queried before their blocks, got %v", querier.Heights)
+	}
+	if len(mockDB.TxSignatures) != 3 {
+		t.Fatalf("expected 3 signatures, got %d", len(mockDB.TxSignatures))
+	}
+	accountNumber := uint64(12)
+	expected := []struct {
+		accountNumber *uint64
+		sequence      uint64
+	}{
+		{accountNumber: &accountNumber, sequence: 4},
+		{accountNumber: nil, sequence: 0},
+		{accountNumber: &accountNumber, sequence: 3},
+	}
+	for i, want := range expected {
+		got := mockDB.TxSignatures[i]
+		if got.Sequence == nil || *got.Sequence != want.sequence {
+			t.Errorf("signature %d: expected sequence %d, got %v", i, want.sequence, got.Sequence)
+		}
+		if (want.accountNumber == nil) != (got.AccountNumber == nil) ||
+			(want.accountNumber != nil && *got.AccountNumber != *want.accountNumber) {
+			t.Errorf("signature %d: expected account number %v, got %v", i, want.accountNumber, got.AccountNumber)
+		}
+	}
+}
+
+func TestDataProcessor_Grc20Transfers(t *testing.T) {
+	alice := crypto.AddressFromPreimage([]byte("alice")).String()
+	bob := crypto.AddressFromPreimage([]byte("bob")).String()
+	tx := std.Tx{Fee: std.NewFee(100000, std.NewCoin("ugnot", 1000)), Memo: "grc20"}
+	attrs := func(kv ...string) []rpcClient.EventAttribute {
+		result := make([]rpcClient.EventAttribute, 0, len(kv)/2)
+		for i := 0; i < len(kv); i += 2 {
+			result = append(result, rpcClient.EventAttribute{Key: kv[i], Value: kv[i+1]})
+		}
+		return result
+	}
+	events := []rpcClient.Event{
+		{Type: "Transfer", PkgPath: "gno.land/r/demo/foo20", Attrs: attrs(
+			"token", "gno.land/r/demo/foo20.FOO", "from", "", "to", alice, "value", "1000")},
+		{Type: "Transfer", PkgPath: "gno.land/r/demo/foo20", Attrs: attrs(
+			"token", "gno.land/r/demo/foo20.FOO", "from", alice, "to", bob, "value", "250")},
+		{Type: "Burn", PkgPath: "gno.land/r/demo/old20", Attrs: attrs("from", bob, "value", "5")},
+		// not a GRC20 event: an approval, a GRC721 transfer and a transfer with a broken amount
+		{Type: "Approval", PkgPath: "gno.land/r/demo/foo20", Attrs: attrs("owner", alice, "spender", bob, "value", "1")},
+		{Type: "Transfer", PkgPath: "gno.land/r/demo/nft", Attrs: attrs("from", alice, "to", bob, "tokenId", "1")},
+		{Type: "Transfer", PkgPath: "gno.land/r/demo/foo20", Attrs: attrs("from", alice, "to", bob, "value", "-1")},
+	}
+	transactions := []dataProcessor.TransactionsData{
+		txData(t, tx, rpcClient.TxResult{ResponseBase: rpcClient.ResponseBase{Events: events}}, 3),
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 7}, &MockAddressCache{}, "test-chain")
+	if err := dp.ProcessMessages(transactions, 3, 3); err != nil {
+		t.Fatalf("ProcessMessages should not return error, got: %v", err)
+	}
+
+	if len(mockDB.Grc20Transfers) != 3 {
+		t.Fatalf("expected 3 grc20 transfers, got %d", len(mockDB.Grc20Transfers))
+	}
+	mint := mockDB.Grc20Transfers[0]
+	if mint.EventType != "Mint" || mint.FromAddress != nil || mint.ToAddress == nil || *mint.ToAddress != 7 {
+		t.Errorf("unexpected mint %+v", mint)
+	}
+	if mint.TokenPath != "gno.land/r/demo/foo20.FOO" || mint.Amount.Int.Int64() != 1000 {
+		t.Errorf("unexpected mint token %s or amount %v", mint.TokenPath, mint.Amount.Int)
+	}
+	transfer := mockDB.Grc20Transfers[1]
+	if transfer.EventType != "Transfer" || transfer.EventIndex != 1 || transfer.FromAddress == nil || transfer.ToAddress == nil {
+		t.Errorf("unexpected transfer %+v", transfer)
+	}
+	// the token without the token attribute is known by the path of its realm
+	burn := mockDB.Grc20Transfers[2]
+	if burn.EventType != "Burn" || burn.TokenPath != "gno.land/r/demo/old20" || burn.ToAddress != nil {
+		t.Errorf("unexpected burn %+v", burn)
+	}
+}
+
+func TestDataProcessor_NftTransfers(t *testing.T) {
+	alice := crypto.AddressFromPreimage([]byte("alice")).String()
+	bob := crypto.AddressFromPreimage([]byte("bob")).String()
+	tx := std.Tx{Fee: std.NewFee(100000, std.NewCoin("ugnot", 1000)), Memo: "grc721"}
+	nftEvent := func(eventType string, kv ...string) rpcClient.Event {
+		event := rpcClient.Event{Type: eventType, PkgPath: "gno.land/r/demo/nft"}
+		for i := 0; i < len(kv); i += 2 {
+			event.Attrs = append(event.Attrs, rpcClient.EventAttribute{Key: kv[i], Value: kv[i+1]})
+		}
+		return event
+	}
+	events := []rpcClient.Event{
+		nftEvent("Mint", "slug", "NFT", "to", alice, "tokenId", "1"),
+		nftEvent("Transfer", "slug", "NFT", "from", alice, "to", bob, "tokenId", "1"),
+		nftEvent("Burn", "slug", "NFT", "from", bob, "tokenId", "1"),
+		// not a GRC721 event: an approval, a GRC20 transfer and a transfer with a broken address
+		nftEvent("Approval", "slug", "NFT", "owner", alice, "to", bob, "tokenId", "2"),
+		nftEvent("Transfer", "from", alice, "to", bob, "value", "10"),
+		nftEvent("Transfer", "slug", "NFT", "from", alice, "to", "not an address", "tokenId", "3"),
+	}
+	transactions := []dataProcessor.TransactionsData{
+		txData(t, tx, rpcClient.TxResult{ResponseBase: rpcClient.ResponseBase{Events: events}}, 4),
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 7}, &MockAddressCache{}, "test-chain")
+	if err := dp.ProcessMessages(transactions, 4, 4); err != nil {
+		t.Fatalf("ProcessMessages should not return error, got: %v", err)
+	}
+
+	if len(mockDB.NftTransfers) != 3 {
+		t.Fatalf("expected 3 nft transfers, got %d", len(mockDB.NftTransfers))
+	}
+	for i, eventType := range []string{"Mint", "Transfer", "Burn"} {
+		transfer := mockDB.NftTransfers[i]
+		if transfer.EventType != eventType || transfer.EventIndex != int16(i) || transfer.TokenId != "1" {
+			t.Errorf("unexpected transfer %d: %+v", i, transfer)
+		}
+		if transfer.CollectionPath != "gno.land/r/demo/nft" || transfer.Symbol != "NFT" {
+			t.Errorf("unexpected collection %s or symbol %s", transfer.CollectionPath, transfer.Symbol)
+		}
+	}
+	if mockDB.NftTransfers[0].FromAddress != nil || mockDB.NftTransfers[2].ToAddress != nil {
+		t.Errorf("the mint and the burn should have no sender and receiver")
+	}
+	// the GRC20 transfer is not taken as a NFT and the NFT events are not taken as GRC20
+	if len(mockDB.Grc20Transfers) != 1 {
+		t.Errorf("expected 1 grc20 transfer, got %d", len(mockDB.Grc20Transfers))
+	}
+}
+
+func TestDataProcessor_NativeBalanceChanges(t *testing.T) {
+	alice := crypto.AddressFromPreimage([]byte("alice"))
+	bob := crypto.AddressFromPreimage([]byte("bob"))
+	tx := std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{FromAddress: alice, ToAddress: bob, Amount: std.NewCoins(std.NewCoin("ugnot", 500))},
+			vm.MsgCall{Caller: alice, PkgPath: "gno.land/r/demo/foo", Func: "Buy", Send: std.NewCoins(std.NewCoin("ugnot", 200))},
+		},
+		Fee: std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+	}
+
+	// the storage deposit events carry the coins in their own fields, amino encodes the amount as a string
+	var deposit rpcClient.Event
+	err := json.Unmarshal([]byte(`{"@type":"/tm.StorageDepositEvent","bytes_delta":"12",`+
+		`"fee_delta":{"denom":"ugnot","amount":"300"},"pkg_path":"gno.land/r/demo/foo"}`), &deposit)
+	if err != nil {
+		t.Fatalf("failed to unmarshal the storage deposit event: %v", err)
+	}
+
+	newTransaction := func(txErr any, height uint64) dataProcessor.TransactionsData {
+		// the memo keeps the hashes of the transactions apart
+		tx.Memo = fmt.Sprintf("native %d", height)
+		return txData(t, tx, rpcClient.TxResult{ResponseBase: rpcClient.ResponseBase{
+			Error:  txErr,
+			Events: []rpcClient.Event{deposit},
+		}}, height)
+	}
+	// the failed transaction only pays the fee
+	transactions := []dataProcessor.TransactionsData{
+		newTransaction(nil, 5),
+		newTransaction(map[string]any{"@type": "/std.InternalError"}, 6),
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{ReturnID: 7}, &MockAddressCache{}, "test-chain")
+	if err := dp.ProcessMessages(transactions, 5, 6); err != nil {
+		t.Fatalf("ProcessMessages should not return error, got: %v", err)
+	}
+
+	expected := []struct {
+		source string
+		amount int64
+	}{
+		{"fee", -1000},
+		{"send", -500}, {"send", 500},
+		{"call_send", -200}, {"call_send", 200},
+		{"storage_deposit", -300}, {"storage_deposit", 300},
+		{"fee", -1000},
+	}
+	if len(mockDB.NativeBalanceChanges) != len(expected) {
+		t.Fatalf("expected %d balance changes, got %d", len(expected), len(mockDB.NativeBalanceChanges))
+	}
+	for i, want := range expected {
+		change := mockDB.NativeBalanceChanges[i]
+		if change.Source != want.source || change.Amount.Int.Int64() != want.amount || change.Denom != "ugnot" {
+			t.Errorf("unexpected change %d: %s %v %s", i, change.Source, change.Amount.Int, change.Denom)
+		}
+	}
+	// the change index starts again for every transaction
+	if last := mockDB.NativeBalanceChanges[len(expected)-1]; last.ChangeIndex != 0 || last.BlockHeight != 6 {
+		t.Errorf("unexpected change of the failed transaction %+v", last)
+	}
+}
+
 // Custom error for testing
 type TestError struct {
 	Message string
@@ -166,3 +1011,155 @@ type TestError struct {
 func (e *TestError) Error() string {
 	return e.Message
 }
+
+func TestDataProcessor_ValidatorSets(t *testing.T) {
+	pubKey := base64.StdEncoding.EncodeToString([]byte("pubkey"))
+	validatorsHash := base64.StdEncoding.EncodeToString([]byte("valhash"))
+	validators := &rpcClient.ValidatorsResponse{}
+	validators.Result.Validators = []rpcClient.ValidatorsSlice{
+		{Address: "g1val1", PubKey: rpcClient.ValPubKey{Type: "/tm.PubKeyEd25519", Value: pubKey}, VotingPower: "10"},
+		{Address: "g1val2", PubKey: rpcClient.ValPubKey{Type: "/tm.PubKeyEd25519", Value: pubKey}, VotingPower: "5"},
+		// a broken voting power is skipped
+		{Address: "g1val3", PubKey: rpcClient.ValPubKey{Type: "/tm.PubKeyEd25519", Value: pubKey}, VotingPower: "x"},
+	}
+	validatorSets := []dataProcessor.ValidatorSetData{
+		{Response: validators, Timestamp: time.Now(), BlockHeight: 12, ValidatorsHash: validatorsHash},
+		// the set that failed to be fetched is skipped
+		{Response: nil, Timestamp: time.Now(), BlockHeight: 15, ValidatorsHash: validatorsHash},
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{}, &MockAddressCache{ReturnID: 3}, "test-chain")
+	dp.ProcessValidatorSets(validatorSets, 10, 20)
+
+	if len(mockDB.ValidatorSets) != 2 {
+		t.Fatalf("expected 2 validator set rows, got %d", len(mockDB.ValidatorSets))
+	}
+	for i, power := range []int64{10, 5} {
+		row := mockDB.ValidatorSets[i]
+		if row.BlockHeight != 12 || row.Validator != 3 || row.VotingPower != power {
+			t.Errorf("unexpected validator set row %d: %+v", i, row)
+		}
+		if string(row.PubKey) != "pubkey" || string(row.ValidatorsHash) != "valhash" || row.PubKeyType != "/tm.PubKeyEd25519" {
+			t.Errorf("unexpected pub key or hash of row %d: %+v", i, row)
+		}
+	}
+}
+
+func TestDataProcessor_BlockResults(t *testing.T) {
+	pubKey := base64.StdEncoding.EncodeToString([]byte("pubkey"))
+	event := func(eventType string) rpcClient.Event {
+		return rpcClient.Event{
+			AtType: "/tm.gnoEvent",
+			Type:   eventType,
+			Attrs:  []rpcClient.EventAttribute{{Key: "key", Value: "value"}},
+		}
+	}
+	results := &rpcClient.BlockResultsResponse{}
+	results.Result.Results.BeginBlock.ResponseBase.Events = []rpcClient.Event{event("begin")}
+	results.Result.Results.EndBlock.ResponseBase.Events = []rpcClient.Event{event("end")}
+	results.Result.Results.EndBlock.Events = []rpcClient.Event{event("end-own")}
+	results.Result.Results.EndBlock.ValidatorUpdates = []rpcClient.ValidatorUpdate{
+		{Address: "g1val1", PubKey: rpcClient.ValPubKey{Type: "/tm.PubKeyEd25519", Value: pubKey}, Power: "10"},
+		// a removed validator has zero power
+		{Address: "g1val2", PubKey: rpcClient.ValPubKey{Type: "/tm.PubKeyEd25519", Value: pubKey}, Power: "0"},
+		// a broken power is skipped
+		{Address: "g1val3", PubKey: rpcClient.ValPubKey{Type: "/tm.PubKeyEd25519", Value: pubKey}, Power: "x"},
+	}
+	blockResults := []dataProcessor.BlockResultsData{
+		{Response: results, Timestamp: time.Now(), BlockHeight: 12},
+		// a block without any events or updates stores nothing
+		{Response: &rpcClient.BlockResultsResponse{}, Timestamp: time.Now(), BlockHeight: 13},
+	}
+
+	t.Run("native", func(t *testing.T) {
+		mockDB := &MockDatabase{}
+		dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{}, &MockAddressCache{ReturnID: 3}, "test-chain")
+		dp.ProcessBlockResults(blockResults, false, 10, 20)
+
+		if len(mockDB.BlockEvents) != 2 {
+			t.Fatalf("expected 2 block event rows, got %d", len(mockDB.BlockEvents))
+		}
+		begin, end := mockDB.BlockEvents[0], mockDB.BlockEvents[1]
+		if begin.Phase != dataProcessor.BeginBlockPhase || len(begin.Events) != 1 || begin.Events[0].Type != "begin" {
+			t.Errorf("unexpected begin block events %+v", begin)
+		}
+		if end.Phase != dataProcessor.EndBlockPhase || len(end.Events) != 2 || end.Events[1].Type != "end-own" {
+			t.Errorf("unexpected end block events %+v", end)
+		}
+		if end.BlockHeight != 12 || end.CompressionOn || end.EventsCompressed != nil {
+			t.Errorf("expected native events at height 12, got %+v", end)
+		}
+
+		if len(mockDB.ValidatorUpdates) != 2 {
+			t.Fatalf("expected 2 validator updates, got %d", len(mockDB.ValidatorUpdates))
+		}
+		for i, power := range []int64{10, 0} {
+			row := mockDB.ValidatorUpdates[i]
+			if row.BlockHeight != 12 || row.Validator != 3 || row.VotingPower != power || string(row.PubKey) != "pubkey" {
+				t.Errorf("unexpected validator update %d: %+v", i, row)
+			}
+		}
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		mockDB := &MockDatabase{}
+		dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{}, &MockAddressCache{ReturnID: 3}, "test-chain")
+		dp.ProcessBlockResults(blockResults, true, 10, 20)
+
+		if len(mockDB.BlockEvents) != 2 {
+			t.Fatalf("expected 2 block event rows, got %d", len(mockDB.BlockEvents))
+		}
+		// a single event is not worth compressing
+		if mockDB.BlockEvents[0].CompressionOn {
+			t.Errorf("expected the single begin block event to stay native")
+		}
+		end := mockDB.BlockEvents[1]
+		if !end.CompressionOn || len(end.EventsCompressed) == 0 || end.Events != nil {
+			t.Errorf("expected compressed end block events, got %+v", end)
+		}
+	})
+}
+
+func TestDataProcessor_BlockHeader(t *testing.T) {
+	appHash := base64.StdEncoding.EncodeToString([]byte("apphash"))
+	lastBlockHash := base64.StdEncoding.EncodeToString([]byte("lastblock"))
+	validatorsHash := base64.StdEncoding.EncodeToString([]byte("valhash"))
+
+	block := rpcClient.NewTestBlockResponse(12, "test-chain").WithTransactions([]string{"tx1", "tx2"})
+	block.Result.BlockMeta.BlockID.Hash = base64.StdEncoding.EncodeToString([]byte("blockhash"))
+	header := &block.Result.Block.Header
+	header.AppHash = appHash
+	header.LastBlockID.Hash = lastBlockHash
+	header.ValidatorsHash = validatorsHash
+	header.NextValidatorsHash = validatorsHash
+
+	// the gas of both transactions of the block is summed, the failed one included
+	transactions := []dataProcessor.TransactionsData{
+		{Response: rpcClient.NewTestTxResponse("tx1", 12), Timestamp: time.Now(), BlockHeight: 12},
+		{Response: rpcClient.NewTestTxResponse("tx2", 12).WithError("failed"), Timestamp: time.Now(), BlockHeight: 12},
+		{Response: rpcClient.NewTestTxResponse("tx3", 13), Timestamp: time.Now(), BlockHeight: 13},
+	}
+
+	mockDB := &MockDatabase{}
+	dp := dataProcessor.NewDataProcessor(mockDB, &MockAddressCache{}, &MockAddressCache{ReturnID: 4}, "test-chain")
+	dp.ProcessBlocks([]*rpcClient.BlockResponse{block}, transactions, 12, 12)
+
+	if len(mockDB.Blocks) != 1 {
+		t.Fatalf("expected 1 block, got %d", len(mockDB.Blocks))
+	}
+	row := mockDB.Blocks[0]
+	if row.Height != 12 || row.Proposer != 4 || row.NumTxs != 2 || row.TotalTxs != 12 {
+		t.Errorf("unexpected block header: %+v", row)
+	}
+	if string(row.AppHash) != "apphash" || string(row.LastBlockHash) != "lastblock" ||
+		string(row.ValidatorsHash) != "valhash" || string(row.NextValidatorsHash) != "valhash" {
+		t.Errorf("unexpected header hashes: %+v", row)
+	}
+	if len(row.DataHash) != 0 {
+		t.Errorf("expected an empty data hash, got %x", row.DataHash)
+	}
+	if row.GasUsed != 100000 || row.GasWanted != 200000 {
+		t.Errorf("expected the gas totals of the block transactions, got used %d wanted %d", row.GasUsed, row.GasWanted)
+	}
+}
@@ -0,0 +1,110 @@
+package dataprocessor
+
+import (
+	"encoding/base64"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/decoder"
+	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+	"github.com/klauspost/compress/zstd"
+)
+
+// the events dictionary is trained on the events and doesn't help with the source code,
+// so the package files are compressed without any dictionary
+var pkgZstdWriter *zstd.Encoder
+
+func init() {
+	var err error
+	pkgZstdWriter, err = zstd.NewWriter(nil, zstdLvl)
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to initialize package zstd writer")
+	}
+}
+
+// packageFileRows converts the package files from the decoded messages to the database rows
+//
+// The same file is often deployed many times, within the same chunk the files are
+// deduplicated by the hash of their body so every file is compressed only once.
+//
+// Parameters:
+//   - decodedMsgs: the decoded messages of the transactions, nil entries are skipped
+//   - chainName: the name of the chain
+//
+// Returns:
+//   - []sqlDataTypes.PackageFile: the unique package files
+func packageFileRows(decodedMsgs []*decoder.DecodedMsg, chainName string) []sqlDataTypes.PackageFile {
+	seen := make(map[string]struct{})
+	rows := make([]sqlDataTypes.PackageFile, 0)
+	for _, decodedMsg := range decodedMsgs {
+		if decodedMsg == nil {
+			continue
+		}
+		for _, file := range decodedMsg.GetPackageFiles() {
+			hash := file.Hash()
+			if _, ok := seen[string(hash)]; ok {
+				continue
+			}
+			seen[string(hash)] = struct{}{}
+			rows = append(rows, sqlDataTypes.PackageFile{
+				FileHash:  hash,
+				ChainName: chainName,
+				Size:      int32(len(file.Body)),
+				Content:   pkgZstdWriter.EncodeAll([]byte(file.Body), nil),
+			})
+		}
+	}
+	return rows
+}
+
+// packageRows converts the packages added by the successful transactions to the database rows
+//
+// The creator addresses need to be resolved by the address cache before this is called.
+// Within the chunk only the earliest deployment of every package is kept.
+//
+// Parameters:
+//   - transactions: the transactions of the chunk
+//   - decodedMsgs: the decoded messages at the same index as the transactions, nil entries are skipped
+//
+// Returns:
+//   - []sqlDataTypes.Package: the packages with their first deployment
+func (d *DataProcessor) packageRows(
+	transactions []TransactionsData,
+	decodedMsgs []*decoder.DecodedMsg,
+) []sqlDataTypes.Package {
+	byPath := make(map[string]int)
+	rows := make([]sqlDataTypes.Package, 0)
+	for idx, transaction := range transactions {
+		if idx >= len(decodedMsgs) || decodedMsgs[idx] == nil || transaction.Response.HasError() {
+			continue
+		}
+		deployed := decodedMsgs[idx].GetDeployedPackages()
+		if len(deployed) == 0 {
+			continue
+		}
+		txHash, err := base64.StdEncoding.DecodeString(transaction.Response.GetHash())
+		if err != nil {
+			l.Error().Msgf("Failed to decode tx hash %s: %v", transaction.Response.GetHash(), err)
+			continue
+		}
+		for _, pkg := range deployed {
+			row := sqlDataTypes.Package{
+				PkgPath:        pkg.PkgPath,
+				ChainName:      d.chainName,
+				PkgName:        pkg.PkgName,
+				Creator:        d.addressCache.GetAddress(pkg.Creator),
+				FirstHeight:    transaction.BlockHeight,
+				FirstTimestamp: transaction.Timestamp,
+				TxHash:         txHash,
+				FileCount:      int32(pkg.FileCount),
+			}
+			if i, ok := byPath[pkg.PkgPath]; ok {
+				if row.FirstHeight < rows[i].FirstHeight {
+					rows[i] = row
+				}
+				continue
+			}
+			byPath[pkg.PkgPath] = len(rows)
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
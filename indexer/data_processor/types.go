@@ -9,15 +9,40 @@ import (
 )
 
 // Define interface for what DataProcessor needs from database
+//
+// The messages are written by the insert functions of their registered message types,
+// the database is passed to them so it also needs the insert methods those functions use.
 type Database interface {
 	InsertBlocks(ctx context.Context, blocks []sqlDataTypes.Blocks) error
 	InsertValidatorBlockSignings(ctx context.Context, validatorBlockSignings []sqlDataTypes.ValidatorBlockSigning) error
+	InsertValidatorSets(ctx context.Context, validatorSets []sqlDataTypes.ValidatorSet) error
+	InsertValidatorUpdates(ctx context.Context, validatorUpdates []sqlDataTypes.ValidatorUpdate) error
+	InsertBlockEvents(ctx context.Context, blockEvents []sqlDataTypes.BlockEvent) error
 	InsertTransactionsGeneral(ctx context.Context, transactionsGeneral []sqlDataTypes.TransactionGeneral) error
-	InsertMsgSend(ctx context.Context, messages []sqlDataTypes.MsgSend) error
-	InsertMsgCall(ctx context.Context, messages []sqlDataTypes.MsgCall) error
-	InsertMsgAddPackage(ctx context.Context, messages []sqlDataTypes.MsgAddPackage) error
-	InsertMsgRun(ctx context.Context, messages []sqlDataTypes.MsgRun) error
+	InsertTxEvents(ctx context.Context, events []sqlDataTypes.TxEvent) error
+	InsertTxSignatures(ctx context.Context, signatures []sqlDataTypes.TxSignature) error
 	InsertAddressTx(ctx context.Context, addresses []sqlDataTypes.AddressTx) error
+	InsertPackageFiles(ctx context.Context, files []sqlDataTypes.PackageFile) error
+	InsertPackages(ctx context.Context, packages []sqlDataTypes.Package) error
+	InsertGrc20Transfers(ctx context.Context, transfers []sqlDataTypes.Grc20Transfer) error
+	InsertNftTransfers(ctx context.Context, transfers []sqlDataTypes.NftTransfer) error
+	InsertNativeBalanceChanges(ctx context.Context, changes []sqlDataTypes.NativeBalanceChange) error
+}
+
+// Optional, implemented by the database that collects the rows of a chunk
+// and writes them all within one transaction
+type ChunkBatch interface {
+	Database
+	Size() int
+	Reset()
+	Commit(ctx context.Context) error
+	CommitSkipExisting(ctx context.Context) error
+}
+
+// Optional, used to read the account number and the sequence of the signatures
+// Part of the rpc client interface
+type AccountQuerier interface {
+	GetAbciQuery(path string, data string, height *uint64, prove *bool) (any, error)
 }
 
 // Define interface for what DataProcessor needs from AddressCache
@@ -28,9 +53,11 @@ type AddressCache interface {
 
 type DataProcessor struct {
 	dbPool         Database
+	batch          ChunkBatch
 	addressCache   AddressCache
 	validatorCache AddressCache
 	chainName      string
+	accountQuerier AccountQuerier
 }
 
 type TransactionsData struct {
@@ -38,3 +65,19 @@ type TransactionsData struct {
 	Timestamp   time.Time
 	BlockHeight uint64
 }
+
+// ValidatorSetData is the validator set fetched at a height where the validators hash changed
+type ValidatorSetData struct {
+	Response       *rpcClient.ValidatorsResponse
+	Timestamp      time.Time
+	BlockHeight    uint64
+	ValidatorsHash string
+}
+
+// BlockResultsData is the result of the block_results method of a block,
+// it holds the BeginBlock and EndBlock events and the validator updates
+type BlockResultsData struct {
+	Response    *rpcClient.BlockResultsResponse
+	Timestamp   time.Time
+	BlockHeight uint64
+}
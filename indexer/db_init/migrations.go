@@ -0,0 +1,362 @@
+package dbinit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Migration changes the schema of a database created by an older version of the indexer
+type Migration struct {
+	// Name of the migration, used for the logs
+	Name string
+	// Applied checks if the database already has the change
+	Applied func(ctx context.Context, tx pgx.Tx) (bool, error)
+	// Apply changes the schema and migrates the existing rows
+	Apply func(ctx context.Context, tx pgx.Tx) error
+}
+
+// Migrations holds every migration in the order they need to be applied
+var Migrations = []Migration{
+	{
+		// the execution result of the transactions, the rows stored before can't be told apart
+		// so they count as successful until their range is indexed again
+		Name:    "transaction_general execution result",
+		Applied: columnExists("transaction_general", "success"),
+		Apply: func(ctx context.Context, tx pgx.Tx) error {
+			err := alterHypertableColumn(ctx, tx, "transaction_general",
+				`ALTER TABLE transaction_general ADD COLUMN IF NOT EXISTS success BOOLEAN NOT NULL DEFAULT true`)
+			if err != nil {
+				return err
+			}
+			for _, column := range []string{"tx_error TEXT NULL", "tx_log TEXT NULL", "tx_info TEXT NULL"} {
+				if err := addColumn("transaction_general", column)(ctx, tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// the args used to be joined with a comma, the args that hold a comma can't be split back
+		// correctly so the old rows are split on every comma
+		Name:    "vm_msg_call args as TEXT[]",
+		Applied: columnHasType("vm_msg_call", "args", "ARRAY"),
+		Apply: func(ctx context.Context, tx pgx.Tx) error {
+			return alterHypertableColumn(ctx, tx, "vm_msg_call", `
+				ALTER TABLE vm_msg_call ALTER COLUMN args TYPE TEXT[] USING
+				CASE
+					WHEN args IS NULL THEN NULL
+					WHEN args = '' THEN '{}'::TEXT[]
+					ELSE string_to_array(args, ',')
+				END
+			`)
+		},
+	},
+	{
+		Name:    "vm_msg_add_package pkg_file_hashes",
+		Applied: columnExists("vm_msg_add_package", "pkg_file_hashes"),
+		Apply:   addColumn("vm_msg_add_package", "pkg_file_hashes BYTEA[] NULL"),
+	},
+	{
+		Name:    "vm_msg_run pkg_file_hashes",
+		Applied: columnExists("vm_msg_run", "pkg_file_hashes"),
+		Apply:   addColumn("vm_msg_run", "pkg_file_hashes BYTEA[] NULL"),
+	},
+	{
+		// the packages table is created by the migrate command, it is filled from the deployments already stored
+		// only the successful deployments are kept, it needs the success flag of the first migration
+		Name: "packages from vm_msg_add_package",
+		Applied: func(ctx context.Context, tx pgx.Tx) (bool, error) {
+			var empty bool
+			err := tx.QueryRow(ctx, `
+				SELECT NOT EXISTS (SELECT 1 FROM vm_msg_add_package) OR EXISTS (SELECT 1 FROM packages)
+			`).Scan(&empty)
+			if err != nil {
+				return false, fmt.Errorf("failed to check the packages: %w", err)
+			}
+			return empty, nil
+		},
+		Apply: func(ctx context.Context, tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO packages (pkg_path, chain_name, pkg_name, creator, first_height, first_timestamp, tx_hash, file_count)
+				SELECT DISTINCT ON (vmap.pkg_path, vmap.chain_name)
+				vmap.pkg_path, vmap.chain_name, vmap.pkg_name, vmap.creator, tx.block_height, vmap.timestamp,
+				vmap.tx_hash, COALESCE(cardinality(vmap.pkg_file_names), 0)
+				FROM vm_msg_add_package vmap
+				JOIN transaction_general tx
+				ON tx.tx_hash = vmap.tx_hash AND tx.timestamp = vmap.timestamp AND tx.chain_name = vmap.chain_name
+				WHERE tx.success AND vmap.pkg_path IS NOT NULL
+				ORDER BY vmap.pkg_path, vmap.chain_name, tx.block_height, vmap.message_counter
+				ON CONFLICT (pkg_path, chain_name) DO NOTHING
+			`)
+			if err != nil {
+				return fmt.Errorf("failed to fill the packages: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		// the tx_events table is created by the migrate command, it is filled from the events stored
+		// without compression, the compressed events can only be read by the indexer
+		Name: "tx_events from transaction_general",
+		Applied: func(ctx context.Context, tx pgx.Tx) (bool, error) {
+			var empty bool
+			err := tx.QueryRow(ctx, `
+				SELECT NOT EXISTS (SELECT 1 FROM transaction_general WHERE tx_events IS NOT NULL)
+				OR EXISTS (SELECT 1 FROM tx_events)
+			`).Scan(&empty)
+			if err != nil {
+				return false, fmt.Errorf("failed to check the tx events: %w", err)
+			}
+			return empty, nil
+		},
+		Apply: func(ctx context.Context, tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO tx_events (tx_hash, chain_name, timestamp, event_index, attr_index, block_height,
+				event_type, at_type, pkg_path, attr_key, attr_value)
+				SELECT tx.tx_hash, tx.chain_name, tx.timestamp, (ev.idx - 1)::SMALLINT,
+				COALESCE(attr.idx - 1, 0)::SMALLINT, tx.block_height,
+				COALESCE(ev.type, ''), ev.at_type, ev.pkg_path, attr.key, attr.value
+				FROM transaction_general tx
+				CROSS JOIN LATERAL unnest(tx.tx_events) WITH ORDINALITY AS ev(at_type, type, attributes, pkg_path, idx)
+				LEFT JOIN LATERAL unnest(ev.attributes) WITH ORDINALITY AS attr(key, value, idx) ON TRUE
+				WHERE tx.tx_events IS NOT NULL
+				ON CONFLICT DO NOTHING
+			`)
+			if err != nil {
+				return fmt.Errorf("failed to fill the tx events: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		// the header columns of the blocks, the blocks indexed before keep null values
+		Name:    "blocks header columns",
+		Applied: columnExists("blocks", "gas_wanted"),
+		Apply: func(ctx context.Context, tx pgx.Tx) error {
+			columns := []string{
+				"proposer INTEGER NULL",
+				"num_txs BIGINT NULL",
+				"total_txs BIGINT NULL",
+				"app_hash BYTEA NULL",
+				"last_block_hash BYTEA NULL",
+				"last_commit_hash BYTEA NULL",
+				"data_hash BYTEA NULL",
+				"validators_hash BYTEA NULL",
+				"next_validators_hash BYTEA NULL",
+				"consensus_hash BYTEA NULL",
+				"gas_used BIGINT NULL",
+				"gas_wanted BIGINT NULL",
+			}
+			for _, column := range columns {
+				if err := addColumn("blocks", column)(ctx, tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// the transactions indexed before keep null values
+		Name:    "transaction_general memo, signers, tx index and gas price",
+		Applied: columnExists("transaction_general", "gas_price"),
+		Apply: func(ctx context.Context, tx pgx.Tx) error {
+			columns := []string{
+				"memo TEXT NULL",
+				"signers INTEGER[] NULL",
+				"tx_index INTEGER NULL",
+				"gas_price DOUBLE PRECISION NULL",
+			}
+			for _, column := range columns {
+				if err := addColumn("transaction_general", column)(ctx, tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// the public keys are filled when the signatures of the addresses are indexed
+		Name:    "gno_addresses pub key",
+		Applied: columnExists("gno_addresses", "pub_key"),
+		Apply: func(ctx context.Context, tx pgx.Tx) error {
+			if err := addColumn("gno_addresses", "pub_key_type TEXT NULL")(ctx, tx); err != nil {
+				return err
+			}
+			return addColumn("gno_addresses", "pub_key BYTEA NULL")(ctx, tx)
+		},
+	},
+}
+
+// RunMigrations applies every migration that is not applied yet
+//
+// Every migration runs within its own transaction, so a failed migration leaves the database
+// as it was and the ones before it stay applied.
+//
+// Returns:
+//   - int: the amount of applied migrations
+//   - error: if any of the migrations fails
+func (db *DBInitializer) RunMigrations() (int, error) {
+	ctx := context.Background()
+	applied := 0
+	for _, migration := range Migrations {
+		done, err := db.runMigration(ctx, migration)
+		if err != nil {
+			return applied, fmt.Errorf("migration %q failed: %w", migration.Name, err)
+		}
+		if done {
+			applied++
+			l.Info().Str("migration", migration.Name).Msg("migration applied")
+		} else {
+			l.Info().Str("migration", migration.Name).Msg("migration already applied, skipping")
+		}
+	}
+	return applied, nil
+}
+
+// runMigration applies a single migration within a transaction
+// it returns false if the migration was already applied
+func (db *DBInitializer) runMigration(ctx context.Context, migration Migration) (done bool, err error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin migration transaction: %w", err)
+	}
+	defer func() {
+		if err != nil || !done {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback migration transaction")
+			}
+		}
+	}()
+
+	applied, err := migration.Applied(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	if applied {
+		return false, nil
+	}
+	if err = migration.Apply(ctx, tx); err != nil {
+		return false, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit migration: %w", err)
+	}
+	return true, nil
+}
+
+// columnHasType checks if the column already has the data type from the information schema
+// a missing table or column counts as applied since there is nothing to migrate
+func columnHasType(table string, column string, dataType string) func(ctx context.Context, tx pgx.Tx) (bool, error) {
+	return func(ctx context.Context, tx pgx.Tx) (bool, error) {
+		var current string
+		err := tx.QueryRow(ctx, `
+			SELECT data_type FROM information_schema.columns
+			WHERE table_name = $1 AND column_name = $2
+		`, table, column).Scan(&current)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read the type of %s.%s: %w", table, column, err)
+		}
+		return current == dataType, nil
+	}
+}
+
+// columnExists checks if the column is already in the information schema
+// a missing table counts as applied since it is created with the column
+func columnExists(table string, column string) func(ctx context.Context, tx pgx.Tx) (bool, error) {
+	return func(ctx context.Context, tx pgx.Tx) (bool, error) {
+		var tableExists, exists bool
+		err := tx.QueryRow(ctx, `
+			SELECT
+				EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = $1),
+				EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = $1 AND column_name = $2)
+		`, table, column).Scan(&tableExists, &exists)
+		if err != nil {
+			return false, fmt.Errorf("failed to check the column %s.%s: %w", table, column, err)
+		}
+		return !tableExists || exists, nil
+	}
+}
+
+// addColumn adds a new column to the table
+// a nullable column without a default can be added even while the columnstore is enabled,
+// the existing rows get a null value
+func addColumn(table string, columnDef string) func(ctx context.Context, tx pgx.Tx) error {
+	return func(ctx context.Context, tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s`, table, columnDef)); err != nil {
+			return fmt.Errorf("failed to add the column to %s: %w", table, err)
+		}
+		return nil
+	}
+}
+
+// alterHypertableColumn runs the alter statement on a hypertable
+//
+// TimescaleDB doesn't allow changing the column type while the columnstore is enabled, so the chunks
+// are converted back to the rowstore and the columnstore is disabled first. Once the column is changed
+// the columnstore and its policy are enabled again.
+//
+// Parameters:
+//   - ctx: the context to use
+//   - tx: the migration transaction
+//   - table: the name of the hypertable
+//   - alter: the alter statement
+//
+// Returns:
+//   - error: if any of the steps fails
+func alterHypertableColumn(ctx context.Context, tx pgx.Tx, table string, alter string) error {
+	var columnstore bool
+	err := tx.QueryRow(ctx, `
+		SELECT compression_enabled FROM timescaledb_information.hypertables
+		WHERE hypertable_name = $1
+	`, table).Scan(&columnstore)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to read the columnstore settings of %s: %w", table, err)
+	}
+
+	var compressAfter *string
+	if columnstore {
+		err = tx.QueryRow(ctx, `
+			SELECT config->>'compress_after' FROM timescaledb_information.jobs
+			WHERE proc_name = 'policy_compression' AND hypertable_name = $1
+		`, table).Scan(&compressAfter)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to read the columnstore policy of %s: %w", table, err)
+		}
+
+		steps := []string{
+			fmt.Sprintf(`CALL remove_columnstore_policy('%s', if_exists => true)`, table),
+			fmt.Sprintf(`SELECT decompress_chunk(c, true) FROM show_chunks('%s') c`, table),
+			fmt.Sprintf(`ALTER TABLE %s SET (timescaledb.enable_columnstore = false)`, table),
+		}
+		for _, step := range steps {
+			if _, err := tx.Exec(ctx, step); err != nil {
+				return fmt.Errorf("failed to disable the columnstore of %s: %w", table, err)
+			}
+		}
+	}
+
+	if _, err := tx.Exec(ctx, alter); err != nil {
+		return fmt.Errorf("failed to alter %s: %w", table, err)
+	}
+
+	if columnstore {
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`ALTER TABLE %s SET (timescaledb.enable_columnstore)`, table)); err != nil {
+			return fmt.Errorf("failed to enable the columnstore of %s: %w", table, err)
+		}
+		if compressAfter != nil {
+			if _, err := tx.Exec(ctx, fmt.Sprintf(
+				`CALL add_columnstore_policy('%s', INTERVAL '%s')`, table, *compressAfter,
+			)); err != nil {
+				return fmt.Errorf("failed to add the columnstore policy of %s: %w", table, err)
+			}
+		}
+	}
+	return nil
+}
@@ -509,7 +509,8 @@ func (db *DBInitializer) createHypertableLegacy(tableInfo *TableInfo, partitionC
 	}
 
 	// Step 2: Convert to hypertable
-	hypertableSQL := fmt.Sprintf("SELECT create_hypertable('%s', '%s', chunk_time_interval => INTERVAL '%s')",
+	// if_not_exists allows the migrate command to run it on the tables that already exist
+	hypertableSQL := fmt.Sprintf("SELECT create_hypertable('%s', '%s', chunk_time_interval => INTERVAL '%s', if_not_exists => TRUE)",
 		tableInfo.TableName, partitionColumn, chunkInterval)
 	_, err = db.pool.Exec(context.Background(), hypertableSQL)
 	if err != nil {
@@ -520,6 +521,25 @@ func (db *DBInitializer) createHypertableLegacy(tableInfo *TableInfo, partitionC
 	return nil
 }
 
+// CreateIndex creates an index on the table if it doesn't exist yet
+//
+// Parameters:
+// - indexName: the name of the index
+// - tableName: the name of the table
+// - columns: the columns of the index, a column can have the sort order (e.g. "timestamp DESC")
+//
+// Returns:
+// - nil: if the function is successful
+// - error: if the function fails
+func (db *DBInitializer) CreateIndex(indexName string, tableName string, columns []string) error {
+	sql := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", indexName, tableName, strings.Join(columns, ", "))
+	_, err := db.pool.Exec(context.Background(), sql)
+	if err != nil {
+		return fmt.Errorf("failed to create index %s on %s: %w", indexName, tableName, err)
+	}
+	return nil
+}
+
 // CreateTypeEnum creates a type enum in the database
 // This function should be used to create type enums, for now only one enum is created at a time
 func (db *DBInitializer) CreateChainTypeEnum(enumValues []string) error {
@@ -585,7 +605,8 @@ func (db *DBInitializer) AppointPrivileges(
 		}
 	case "writer":
 		for _, tableName := range tableNames {
-			fmt.Fprintf(&sql, "GRANT SELECT, INSERT, UPDATE ON TABLE %s TO %s;\n", tableName, userName)
+			// delete is needed to rewind the chain data in case of a fork
+			fmt.Fprintf(&sql, "GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE %s TO %s;\n", tableName, userName)
 		}
 	case "keymgr":
 		fmt.Fprintf(&sql, "GRANT SELECT, INSERT, UPDATE ON TABLE api_keys TO %s;\n", userName)
@@ -5,12 +5,12 @@ import (
 	"encoding/base64"
 	"fmt"
 	"math/big"
-	"strings"
 
 	dataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
-	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/tm2/pkg/amino"
-	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -61,6 +61,9 @@ func (d *Decoder) DecodeStdTxFromBase64() (*std.Tx, error) {
 //
 // # The use case of this function is to decode the raw tx data and gather information about the transaction
 //
+// If the transaction holds a message that is not registered with amino the whole transaction can't be decoded,
+// in that case the messages are decoded one by one and the unknown ones are kept as generic messages.
+//
 // Parameters:
 //   - none
 //
@@ -69,11 +72,6 @@ func (d *Decoder) DecodeStdTxFromBase64() (*std.Tx, error) {
 //   - []map[string]any: messages data in a map
 //   - error: if the decoding or unmarshaling fails
 func (d *Decoder) GetMessageFromStdTx() (BasicTxData, []map[string]any, error) {
-	tx, err := d.DecodeStdTxFromBase64()
-	if err != nil {
-		return BasicTxData{}, nil, err
-	}
-
 	// Get transaction hash
 	bz, err := base64.StdEncoding.DecodeString(d.encodedTx)
 	if err != nil {
@@ -83,17 +81,16 @@ func (d *Decoder) GetMessageFromStdTx() (BasicTxData, []map[string]any, error) {
 	// Use sha256 and then we will use the hash as the primary key for the transaction
 	txHash := sha256.Sum256(bz)
 
+	tx, err := d.DecodeStdTxFromBase64()
+	if err != nil {
+		return d.getMessageFromRawTx(bz, txHash[:])
+	}
+
 	signers := tx.GetSigners()
 	signersString := make([]string, len(signers))
 	for i, signer := range signers {
 		signersString[i] = signer.String()
 	}
-	bigInt := big.NewInt(tx.Fee.GasFee.Amount)
-	feeAmount := pgtype.Numeric{Int: bigInt, Valid: true}
-	fee := dataTypes.Amount{
-		Amount: feeAmount,
-		Denom:  tx.Fee.GasFee.Denom,
-	}
 
 	msgCount := len(tx.GetMsgs())
 
@@ -101,8 +98,9 @@ func (d *Decoder) GetMessageFromStdTx() (BasicTxData, []map[string]any, error) {
 		TxHash:        txHash[:],
 		Signers:       signersString,
 		Memo:          tx.GetMemo(),
-		Fee:           fee,
+		Fee:           extractFee(tx.Fee),
 		TotalMsgCount: msgCount,
+		Signatures:    extractSignatures(tx.Signatures, signersString),
 	}
 
 	var messages = make([]map[string]any, msgCount)
@@ -115,6 +113,68 @@ func (d *Decoder) GetMessageFromStdTx() (BasicTxData, []map[string]any, error) {
 	return basicTxData, messages, nil
 }
 
+// getMessageFromRawTx decodes the transaction with the messages left as raw amino Any values
+//
+// The messages registered with amino are decoded as usual, the rest are kept as generic messages
+// with the type url and the raw value. Since the signers of the unknown messages can't be known
+// the signers are collected from the known messages and the public keys of the signatures.
+//
+// Parameters:
+//   - bz: the amino encoded transaction
+//   - txHash: the hash of the transaction
+//
+// Returns:
+//   - BasicTxData: basic tx data
+//   - []map[string]any: messages data in a map
+//   - error: if the raw decoding fails as well
+func (d *Decoder) getMessageFromRawTx(bz []byte, txHash []byte) (BasicTxData, []map[string]any, error) {
+	var tx rawTx
+	if err := amino.Unmarshal(bz, &tx); err != nil {
+		return BasicTxData{}, nil, err
+	}
+	if len(tx.Msgs) > 32768 {
+		return BasicTxData{}, nil, fmt.Errorf("transaction message count exceeds maximum: %d", len(tx.Msgs))
+	}
+
+	signerSet := make(map[string]struct{})
+	signersString := make([]string, 0)
+	addSigner := func(signer string) {
+		if _, ok := signerSet[signer]; !ok {
+			signerSet[signer] = struct{}{}
+			signersString = append(signersString, signer)
+		}
+	}
+
+	messages := make([]map[string]any, len(tx.Msgs))
+	for i, rawMsg := range tx.Msgs {
+		var msg std.Msg
+		if err := amino.UnmarshalAny2(rawMsg.TypeURL, rawMsg.Value, &msg); err != nil {
+			messages[i] = rawGenericMsg(rawMsg, int16(i))
+			continue
+		}
+		for _, signer := range msg.GetSigners() {
+			addSigner(signer.String())
+		}
+		messages[i] = processMsg(msg, int16(i))
+	}
+	for _, signature := range tx.Signatures {
+		if signature.PubKey != nil {
+			addSigner(signature.PubKey.Address().String())
+		}
+	}
+
+	basicTxData := BasicTxData{
+		TxHash:        txHash,
+		Signers:       signersString,
+		Memo:          tx.Memo,
+		Fee:           extractFee(tx.Fee),
+		TotalMsgCount: len(tx.Msgs),
+		// the order of the signers is not known without all of the messages
+		Signatures: extractSignatures(tx.Signatures, nil),
+	}
+	return basicTxData, messages, nil
+}
+
 func processMsgs(
 	tx *std.Tx,
 	messages *[]map[string]any,
@@ -123,104 +183,59 @@ func processMsgs(
 		if i > 32767 {
 			return fmt.Errorf("transaction message count exceeds maximum: %d", i)
 		}
-		messageCounter := int16(i)
-		switch m := msg.(type) {
-		case bank.MsgSend:
-			// amount should have something like 1000000 ugnot we just need to split it and convert it to uint64
-			amount, err := extractCoins(m.Amount)
-			if err != nil {
-				amount = []Coin{}
-			}
-			(*messages)[i] = map[string]any{
-				"msg_type":        "bank_msg_send",
-				"from_address":    m.FromAddress.String(),
-				"to_address":      m.ToAddress.String(),
-				"amount":          amount,
-				"message_counter": messageCounter,
-			}
-		case vm.MsgCall:
-			caller := m.Caller.String()
-			send, err := extractCoins(m.Send)
-			if err != nil {
-				send = []Coin{}
-			}
-			pkgPath := m.PkgPath
-			// max deposit could be empty and there is a chance it will return an error
-			// so we need to handle that
-			maxDeposit, err := extractCoins(m.MaxDeposit)
-			if err != nil {
-				maxDeposit = []Coin{}
-			}
-			funcName := m.Func
-			// combine the args into a string
-			args := strings.Join(m.Args, ",")
-			(*messages)[i] = map[string]any{
-				"msg_type":        "vm_msg_call",
-				"caller":          caller,
-				"pkg_path":        pkgPath,
-				"func_name":       funcName,
-				"args":            args,
-				"send":            send,
-				"max_deposit":     maxDeposit,
-				"message_counter": messageCounter,
-			}
-		case vm.MsgAddPackage:
-			pkgPath := m.Package.Path
-			pkgName := m.Package.Name
-			pkgFileNames := m.Package.FileNames()
-			creator := m.Creator.String()
-			send, err := extractCoins(m.Send)
-			if err != nil {
-				send = []Coin{}
-			}
-			maxDeposit, err := extractCoins(m.MaxDeposit)
-			if err != nil {
-				maxDeposit = []Coin{}
-			}
-			(*messages)[i] = map[string]any{
-				"msg_type":        "vm_msg_add_package",
-				"pkg_path":        pkgPath,
-				"pkg_name":        pkgName,
-				"pkg_file_names":  pkgFileNames,
-				"creator":         creator,
-				"send":            send,
-				"max_deposit":     maxDeposit,
-				"message_counter": messageCounter,
-			}
-
-		case vm.MsgRun:
-			caller := m.Caller.String()
-			pkgPath := m.Package.Path
-			pkgName := m.Package.Name
-			pkgFileNames := m.Package.FileNames()
-			send, err := extractCoins(m.Send)
-			if err != nil {
-				send = []Coin{}
-			}
-			// max deposit could be empty and there is a chance it will return an error
-			// so we need to handle that
-			maxDeposit, err := extractCoins(m.MaxDeposit)
-			if err != nil {
-				maxDeposit = []Coin{}
-			}
-			(*messages)[i] = map[string]any{
-				"msg_type":        "vm_msg_run",
-				"caller":          caller,
-				"pkg_path":        pkgPath,
-				"pkg_name":        pkgName,
-				"pkg_file_names":  pkgFileNames,
-				"send":            send,
-				"max_deposit":     maxDeposit,
-				"message_counter": messageCounter,
-			}
-		// case for AnyNewMessage add here:
-		default:
-			return fmt.Errorf("unknown or unsupported message type: %T", m)
-		}
+		(*messages)[i] = processMsg(msg, int16(i))
 	}
 	return nil
 }
 
+// processMsg converts a single message to the map data type with its registered message type
+// the messages without a registered type are stored as generic messages
+func processMsg(msg std.Msg, messageCounter int16) map[string]any {
+	return DefaultRegistry.decode(msg, messageCounter)
+}
+
+// extractSignatures converts the signatures of the transaction, the signatures are in the same order
+// as the signers of the messages so the signer is taken from them when the public key is not included
+func extractSignatures(signatures []std.Signature, signers []string) []TxSignature {
+	txSignatures := make([]TxSignature, len(signatures))
+	for i, signature := range signatures {
+		txSignatures[i].Signature = signature.Signature
+		if i < len(signers) {
+			txSignatures[i].Signer = signers[i]
+		}
+		if signature.PubKey == nil {
+			continue
+		}
+		txSignatures[i].Signer = signature.PubKey.Address().String()
+		txSignatures[i].PubKeyType, txSignatures[i].PubKey = pubKeyData(signature.PubKey)
+	}
+	return txSignatures
+}
+
+// pubKeyData returns the type url and the bytes of the public key, the single keys are returned
+// as the raw key the same way as the validator keys and the rest with their amino encoding
+func pubKeyData(pubKey crypto.PubKey) (string, []byte) {
+	typeURL := amino.GetTypeURL(pubKey)
+	switch key := pubKey.(type) {
+	case secp256k1.PubKeySecp256k1:
+		return typeURL, key[:]
+	case ed25519.PubKeyEd25519:
+		return typeURL, key[:]
+	default:
+		return typeURL, pubKey.Bytes()
+	}
+}
+
+// extractFee converts the gas fee to the database amount
+func extractFee(fee std.Fee) dataTypes.Amount {
+	bigInt := big.NewInt(fee.GasFee.Amount)
+	feeAmount := pgtype.Numeric{Int: bigInt, Valid: true}
+	return dataTypes.Amount{
+		Amount: feeAmount,
+		Denom:  fee.GasFee.Denom,
+	}
+}
+
 // Local function to split the amount and denom
 func extractCoins(amount std.Coins) ([]Coin, error) {
 	// make a string and split it by space
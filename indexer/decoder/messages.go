@@ -0,0 +1,502 @@
+package decoder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	dataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// the insert methods of the database used by the built in message types
+type (
+	msgSendInserter interface {
+		InsertMsgSend(ctx context.Context, messages []dataTypes.MsgSend) error
+	}
+	msgCallInserter interface {
+		InsertMsgCall(ctx context.Context, messages []dataTypes.MsgCall) error
+	}
+	msgAddPackageInserter interface {
+		InsertMsgAddPackage(ctx context.Context, messages []dataTypes.MsgAddPackage) error
+	}
+	msgRunInserter interface {
+		InsertMsgRun(ctx context.Context, messages []dataTypes.MsgRun) error
+	}
+	msgGenericInserter interface {
+		InsertMsgGeneric(ctx context.Context, messages []dataTypes.MsgGeneric) error
+	}
+)
+
+// the built in message types
+func init() {
+	mustRegister(Register(DefaultRegistry, MessageType[bank.MsgSend, dataTypes.MsgSend]{
+		Name:      "bank_msg_send",
+		Decode:    decodeMsgSend,
+		Addresses: stringAddresses("from_address", "to_address"),
+		Convert:   convertToDbMsgSend,
+		Insert:    insertWith("bank_msg_send", msgSendInserter.InsertMsgSend),
+		Transfers: msgSendTransfers,
+	}))
+	mustRegister(Register(DefaultRegistry, MessageType[vm.MsgCall, dataTypes.MsgCall]{
+		Name:      "vm_msg_call",
+		Decode:    decodeMsgCall,
+		Addresses: stringAddresses("caller"),
+		Convert:   convertToDbMsgCall,
+		Insert:    insertWith("vm_msg_call", msgCallInserter.InsertMsgCall),
+		Transfers: realmSendTransfers("caller", "call_send"),
+	}))
+	mustRegister(Register(DefaultRegistry, MessageType[vm.MsgAddPackage, dataTypes.MsgAddPackage]{
+		Name:      "vm_msg_add_package",
+		Decode:    decodeMsgAddPackage,
+		Addresses: stringAddresses("creator"),
+		Convert:   convertToDbMsgAddPackage,
+		Insert:    insertWith("vm_msg_add_package", msgAddPackageInserter.InsertMsgAddPackage),
+		Transfers: realmSendTransfers("creator", "add_package_send"),
+	}))
+	mustRegister(Register(DefaultRegistry, MessageType[vm.MsgRun, dataTypes.MsgRun]{
+		Name:      "vm_msg_run",
+		Decode:    decodeMsgRun,
+		Addresses: stringAddresses("caller"),
+		Convert:   convertToDbMsgRun,
+		Insert:    insertWith("vm_msg_run", msgRunInserter.InsertMsgRun),
+	}))
+	// std.Msg never matches the amino type of a message, so every message
+	// without a registered type is decoded with this one
+	mustRegister(Register(DefaultRegistry, MessageType[std.Msg, dataTypes.MsgGeneric]{
+		Name:      GenericMsgType,
+		Decode:    decodeMsgGeneric,
+		Addresses: genericAddresses,
+		Convert:   convertToDbMsgGeneric,
+		Insert:    insertWith(GenericMsgType, msgGenericInserter.InsertMsgGeneric),
+	}))
+}
+
+// mustRegister panics if one of the built in message types can't be registered
+func mustRegister(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// stringAddresses returns the address extractor that reads the given keys of the decoded message
+func stringAddresses(keys ...string) func(msgMap map[string]any) []string {
+	return func(msgMap map[string]any) []string {
+		addresses := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if address, ok := msgMap[key].(string); ok {
+				addresses = append(addresses, address)
+			}
+		}
+		return addresses
+	}
+}
+
+// genericAddresses returns the addresses collected from the generic message
+func genericAddresses(msgMap map[string]any) []string {
+	addresses, _ := msgMap["addresses"].([]string)
+	return addresses
+}
+
+// msgSendTransfers returns the amount the bank send moves to the receiver
+func msgSendTransfers(msgMap map[string]any) []CoinTransfer {
+	from, _ := msgMap["from_address"].(string)
+	to, _ := msgMap["to_address"].(string)
+	coins, _ := msgMap["amount"].([]Coin)
+	return []CoinTransfer{{From: from, To: to, Coins: coins, Source: "send"}}
+}
+
+// realmSendTransfers returns the transfer extractor of the messages that move the send coins
+// to the address of the realm, the sender is read from the given key of the decoded message
+//
+// The MsgRun package runs under the caller address, so its send coins never leave the caller
+// and it has no transfers.
+func realmSendTransfers(senderKey string, source string) func(msgMap map[string]any) []CoinTransfer {
+	return func(msgMap map[string]any) []CoinTransfer {
+		from, _ := msgMap[senderKey].(string)
+		pkgPath, _ := msgMap["pkg_path"].(string)
+		if pkgPath == "" {
+			return nil
+		}
+		coins, _ := msgMap["send"].([]Coin)
+		return []CoinTransfer{{
+			From:   from,
+			To:     gnolang.DerivePkgBech32Addr(pkgPath).String(),
+			Coins:  coins,
+			Source: source,
+		}}
+	}
+}
+
+func decodeMsgSend(m bank.MsgSend) map[string]any {
+	// amount should have something like 1000000 ugnot we just need to split it and convert it to uint64
+	amount, err := extractCoins(m.Amount)
+	if err != nil {
+		amount = []Coin{}
+	}
+	return map[string]any{
+		"from_address": m.FromAddress.String(),
+		"to_address":   m.ToAddress.String(),
+		"amount":       amount,
+	}
+}
+
+func decodeMsgCall(m vm.MsgCall) map[string]any {
+	send, err := extractCoins(m.Send)
+	if err != nil {
+		send = []Coin{}
+	}
+	// max deposit could be empty and there is a chance it will return an error
+	// so we need to handle that
+	maxDeposit, err := extractCoins(m.MaxDeposit)
+	if err != nil {
+		maxDeposit = []Coin{}
+	}
+	// keep the args as a list, an arg can hold a comma so they can't be joined
+	args := append([]string{}, m.Args...)
+	return map[string]any{
+		"caller":      m.Caller.String(),
+		"pkg_path":    m.PkgPath,
+		"func_name":   m.Func,
+		"args":        args,
+		"send":        send,
+		"max_deposit": maxDeposit,
+	}
+}
+
+func decodeMsgAddPackage(m vm.MsgAddPackage) map[string]any {
+	send, err := extractCoins(m.Send)
+	if err != nil {
+		send = []Coin{}
+	}
+	maxDeposit, err := extractCoins(m.MaxDeposit)
+	if err != nil {
+		maxDeposit = []Coin{}
+	}
+	return map[string]any{
+		"pkg_path":       m.Package.Path,
+		"pkg_name":       m.Package.Name,
+		"pkg_file_names": m.Package.FileNames(),
+		"pkg_files":      packageFiles(m.Package),
+		"creator":        m.Creator.String(),
+		"send":           send,
+		"max_deposit":    maxDeposit,
+	}
+}
+
+func decodeMsgRun(m vm.MsgRun) map[string]any {
+	send, err := extractCoins(m.Send)
+	if err != nil {
+		send = []Coin{}
+	}
+	// max deposit could be empty and there is a chance it will return an error
+	// so we need to handle that
+	maxDeposit, err := extractCoins(m.MaxDeposit)
+	if err != nil {
+		maxDeposit = []Coin{}
+	}
+	return map[string]any{
+		"caller":         m.Caller.String(),
+		"pkg_path":       m.Package.Path,
+		"pkg_name":       m.Package.Name,
+		"pkg_file_names": m.Package.FileNames(),
+		"pkg_files":      packageFiles(m.Package),
+		"send":           send,
+		"max_deposit":    maxDeposit,
+	}
+}
+
+// packageFiles copies the source files of the package, they are archived apart from the message
+func packageFiles(pkg *std.MemPackage) []PackageFile {
+	files := make([]PackageFile, 0, len(pkg.Files))
+	for _, file := range pkg.Files {
+		if file == nil {
+			continue
+		}
+		files = append(files, PackageFile{Name: file.Name, Body: file.Body})
+	}
+	return files
+}
+
+// decodeMsgGeneric stores any message registered with amino as its type url and amino JSON
+func decodeMsgGeneric(msg std.Msg) map[string]any {
+	payload, err := amino.MarshalJSON(msg)
+	if err != nil {
+		payload = []byte("null")
+	}
+	signers := msg.GetSigners()
+	addresses := make([]string, len(signers))
+	for i, signer := range signers {
+		addresses[i] = signer.String()
+	}
+	return map[string]any{
+		"type_url":  amino.GetTypeURL(msg),
+		"payload":   string(payload),
+		"addresses": addresses,
+	}
+}
+
+// rawGenericMsg stores the message that is not registered with amino
+// the value can't be decoded so it is kept as base64 within the payload
+func rawGenericMsg(msg rawAny, messageCounter int16) map[string]any {
+	payload, err := json.Marshal(map[string]any{
+		"@type": msg.TypeURL,
+		"value": msg.Value,
+	})
+	if err != nil {
+		payload = []byte("null")
+	}
+	return map[string]any{
+		"msg_type":        GenericMsgType,
+		"type_url":        msg.TypeURL,
+		"payload":         string(payload),
+		"addresses":       []string{},
+		"message_counter": messageCounter,
+	}
+}
+
+// convertToDbMsgSend converts a map data type directly to a database-ready MsgSend struct
+func convertToDbMsgSend(msgMap map[string]any, tx MsgTx) (dataTypes.MsgSend, error) {
+	fromAddress, ok := msgMap["from_address"].(string)
+	if !ok {
+		return dataTypes.MsgSend{}, fmt.Errorf("missing from_address")
+	}
+
+	toAddress, ok := msgMap["to_address"].(string)
+	if !ok {
+		return dataTypes.MsgSend{}, fmt.Errorf("missing to_address")
+	}
+
+	amount, err := amountsFromMap(msgMap, "amount")
+	if err != nil {
+		return dataTypes.MsgSend{}, err
+	}
+
+	return dataTypes.MsgSend{
+		TxHash:         tx.TxHash,
+		ChainName:      tx.ChainName,
+		FromAddress:    tx.Addresses.GetAddress(fromAddress),
+		ToAddress:      tx.Addresses.GetAddress(toAddress),
+		Amount:         amount,
+		Signers:        tx.SignerIds,
+		Timestamp:      tx.Timestamp,
+		MessageCounter: tx.MessageCounter,
+	}, nil
+}
+
+// convertToDbMsgCall converts a map data type directly to a database-ready MsgCall struct
+func convertToDbMsgCall(msgMap map[string]any, tx MsgTx) (dataTypes.MsgCall, error) {
+	caller, ok := msgMap["caller"].(string)
+	if !ok {
+		return dataTypes.MsgCall{}, fmt.Errorf("missing caller")
+	}
+
+	pkgPath, ok := msgMap["pkg_path"].(string)
+	if !ok {
+		return dataTypes.MsgCall{}, fmt.Errorf("missing pkg_path")
+	}
+
+	funcName, ok := msgMap["func_name"].(string)
+	if !ok {
+		return dataTypes.MsgCall{}, fmt.Errorf("missing func_name")
+	}
+
+	args, ok := msgMap["args"].([]string)
+	if !ok {
+		return dataTypes.MsgCall{}, fmt.Errorf("missing args")
+	}
+
+	send, err := amountsFromMap(msgMap, "send")
+	if err != nil {
+		return dataTypes.MsgCall{}, err
+	}
+
+	maxDeposit, err := amountsFromMap(msgMap, "max_deposit")
+	if err != nil {
+		return dataTypes.MsgCall{}, err
+	}
+
+	return dataTypes.MsgCall{
+		TxHash:         tx.TxHash,
+		MessageCounter: tx.MessageCounter,
+		ChainName:      tx.ChainName,
+		Caller:         tx.Addresses.GetAddress(caller),
+		Send:           send,
+		PkgPath:        pkgPath,
+		FuncName:       funcName,
+		Args:           args,
+		MaxDeposit:     maxDeposit,
+		Signers:        tx.SignerIds,
+		Timestamp:      tx.Timestamp,
+	}, nil
+}
+
+// convertToDbMsgAddPackage converts a map data type directly to a database-ready MsgAddPackage struct
+func convertToDbMsgAddPackage(msgMap map[string]any, tx MsgTx) (dataTypes.MsgAddPackage, error) {
+	creator, ok := msgMap["creator"].(string)
+	if !ok {
+		return dataTypes.MsgAddPackage{}, fmt.Errorf("missing creator")
+	}
+
+	pkgPath, ok := msgMap["pkg_path"].(string)
+	if !ok {
+		return dataTypes.MsgAddPackage{}, fmt.Errorf("missing pkg_path")
+	}
+
+	pkgName, ok := msgMap["pkg_name"].(string)
+	if !ok {
+		return dataTypes.MsgAddPackage{}, fmt.Errorf("missing pkg_name")
+	}
+
+	fileNames, fileHashes, err := packageFilesFromMap(msgMap)
+	if err != nil {
+		return dataTypes.MsgAddPackage{}, err
+	}
+
+	send, err := amountsFromMap(msgMap, "send")
+	if err != nil {
+		return dataTypes.MsgAddPackage{}, err
+	}
+
+	maxDeposit, err := amountsFromMap(msgMap, "max_deposit")
+	if err != nil {
+		return dataTypes.MsgAddPackage{}, err
+	}
+
+	return dataTypes.MsgAddPackage{
+		TxHash:         tx.TxHash,
+		MessageCounter: tx.MessageCounter,
+		ChainName:      tx.ChainName,
+		Creator:        tx.Addresses.GetAddress(creator),
+		PkgPath:        pkgPath,
+		PkgName:        pkgName,
+		PkgFileNames:   fileNames,
+		PkgFileHashes:  fileHashes,
+		Send:           send,
+		MaxDeposit:     maxDeposit,
+		Signers:        tx.SignerIds,
+		Timestamp:      tx.Timestamp,
+	}, nil
+}
+
+// convertToDbMsgRun converts a map data type directly to a database-ready MsgRun struct
+func convertToDbMsgRun(msgMap map[string]any, tx MsgTx) (dataTypes.MsgRun, error) {
+	caller, ok := msgMap["caller"].(string)
+	if !ok {
+		return dataTypes.MsgRun{}, fmt.Errorf("missing caller")
+	}
+
+	pkgPath, ok := msgMap["pkg_path"].(string)
+	if !ok {
+		return dataTypes.MsgRun{}, fmt.Errorf("missing pkg_path")
+	}
+
+	pkgName, ok := msgMap["pkg_name"].(string)
+	if !ok {
+		return dataTypes.MsgRun{}, fmt.Errorf("missing pkg_name")
+	}
+
+	fileNames, fileHashes, err := packageFilesFromMap(msgMap)
+	if err != nil {
+		return dataTypes.MsgRun{}, err
+	}
+
+	send, err := amountsFromMap(msgMap, "send")
+	if err != nil {
+		return dataTypes.MsgRun{}, err
+	}
+
+	maxDeposit, err := amountsFromMap(msgMap, "max_deposit")
+	if err != nil {
+		return dataTypes.MsgRun{}, err
+	}
+
+	return dataTypes.MsgRun{
+		TxHash:         tx.TxHash,
+		MessageCounter: tx.MessageCounter,
+		ChainName:      tx.ChainName,
+		Caller:         tx.Addresses.GetAddress(caller),
+		PkgPath:        pkgPath,
+		PkgName:        pkgName,
+		PkgFileNames:   fileNames,
+		PkgFileHashes:  fileHashes,
+		Send:           send,
+		MaxDeposit:     maxDeposit,
+		Signers:        tx.SignerIds,
+		Timestamp:      tx.Timestamp,
+	}, nil
+}
+
+// convertToDbMsgGeneric converts a map data type directly to a database-ready MsgGeneric struct
+func convertToDbMsgGeneric(msgMap map[string]any, tx MsgTx) (dataTypes.MsgGeneric, error) {
+	typeUrl, ok := msgMap["type_url"].(string)
+	if !ok {
+		return dataTypes.MsgGeneric{}, fmt.Errorf("missing type_url")
+	}
+
+	payload, ok := msgMap["payload"].(string)
+	if !ok {
+		return dataTypes.MsgGeneric{}, fmt.Errorf("missing payload")
+	}
+
+	addresses, ok := msgMap["addresses"].([]string)
+	if !ok {
+		return dataTypes.MsgGeneric{}, fmt.Errorf("missing addresses")
+	}
+
+	addressIds := make([]int32, len(addresses))
+	for j, address := range addresses {
+		addressIds[j] = tx.Addresses.GetAddress(address)
+	}
+
+	return dataTypes.MsgGeneric{
+		TxHash:         tx.TxHash,
+		Timestamp:      tx.Timestamp,
+		ChainName:      tx.ChainName,
+		TypeUrl:        typeUrl,
+		Payload:        payload,
+		Addresses:      addressIds,
+		Signers:        tx.SignerIds,
+		MessageCounter: tx.MessageCounter,
+	}, nil
+}
+
+// packageFilesFromMap returns the names and the hashes of the package files in the same order
+func packageFilesFromMap(msgMap map[string]any) ([]string, [][]byte, error) {
+	files, ok := msgMap["pkg_files"].([]PackageFile)
+	if !ok {
+		return nil, nil, fmt.Errorf("missing pkg_files")
+	}
+
+	names := make([]string, len(files))
+	hashes := make([][]byte, len(files))
+	for j, file := range files {
+		names[j] = file.Name
+		hashes[j] = file.Hash()
+	}
+	return names, hashes, nil
+}
+
+// amountsFromMap converts the coins of the decoded message to the database amounts
+func amountsFromMap(msgMap map[string]any, key string) ([]dataTypes.Amount, error) {
+	coins, ok := msgMap[key].([]Coin)
+	if !ok {
+		return nil, fmt.Errorf("missing %s", key)
+	}
+
+	amounts := make([]dataTypes.Amount, len(coins))
+	for j, amt := range coins {
+		bigInt := big.NewInt(amt.Amount)
+		amounts[j] = dataTypes.Amount{
+			Amount: pgtype.Numeric{Int: bigInt, Valid: true},
+			Denom:  amt.Denom,
+		}
+	}
+	return amounts, nil
+}
@@ -2,11 +2,9 @@ package decoder
 
 import (
 	"fmt"
-	"math/big"
 	"time"
 
 	dataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
-	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // NewDecodedMsg creates a new DecodedMsg struct
@@ -75,6 +73,16 @@ func (dm *DecodedMsg) GetSigners() []string {
 	return dm.BasicData.Signers
 }
 
+// GetSignatures returns the signatures of the decoded message
+//
+// Returns:
+//   - []TxSignature: the signatures with the public keys of the signers
+//
+// The method will not throw an error if the signatures are not found, it will just return nil
+func (dm *DecodedMsg) GetSignatures() []TxSignature {
+	return dm.BasicData.Signatures
+}
+
 // GetMemo returns the memo of the decoded message
 //
 // Returns:
@@ -105,6 +113,95 @@ func (dm *DecodedMsg) GetTotalMsgCount() int {
 	return dm.BasicData.TotalMsgCount
 }
 
+// GetPackageFiles returns the source files of every package deployed by the decoded message
+//
+// Returns:
+//   - []PackageFile: the files of the MsgAddPackage and MsgRun messages
+//
+// The method will not throw an error if the message has no packages, it will just return an empty slice
+func (dm *DecodedMsg) GetPackageFiles() []PackageFile {
+	files := make([]PackageFile, 0)
+	for _, msgMap := range dm.Messages {
+		if pkgFiles, ok := msgMap["pkg_files"].([]PackageFile); ok {
+			files = append(files, pkgFiles...)
+		}
+	}
+	return files
+}
+
+// GetDeployedPackages returns the packages added to the chain by the decoded message
+//
+// Returns:
+//   - []DeployedPackage: a package for every MsgAddPackage message
+//
+// The method will not throw an error if the message has no packages, it will just return an empty slice
+func (dm *DecodedMsg) GetDeployedPackages() []DeployedPackage {
+	packages := make([]DeployedPackage, 0)
+	for _, msgMap := range dm.Messages {
+		if msgType, _ := msgMap["msg_type"].(string); msgType != "vm_msg_add_package" {
+			continue
+		}
+		pkgPath, _ := msgMap["pkg_path"].(string)
+		pkgName, _ := msgMap["pkg_name"].(string)
+		creator, _ := msgMap["creator"].(string)
+		fileNames, _ := msgMap["pkg_file_names"].([]string)
+		messageCounter, _ := msgMap["message_counter"].(int16)
+		packages = append(packages, DeployedPackage{
+			PkgPath:        pkgPath,
+			PkgName:        pkgName,
+			Creator:        creator,
+			FileCount:      len(fileNames),
+			MessageCounter: messageCounter,
+		})
+	}
+	return packages
+}
+
+// GetCoinTransfers returns the native coins moved by the messages of the decoded message
+//
+// The transfers come from the transfer extractor of the registered message type,
+// the transfers without any coins or addresses are dropped.
+//
+// Returns:
+//   - []CoinTransfer: the transfers in the order of the messages
+//
+// The method will not throw an error if the message has no transfers, it will just return an empty slice
+func (dm *DecodedMsg) GetCoinTransfers() []CoinTransfer {
+	transfers := make([]CoinTransfer, 0)
+	for _, msgMap := range dm.Messages {
+		msgType, ok := msgMap["msg_type"].(string)
+		if !ok {
+			continue
+		}
+		entry, ok := DefaultRegistry.get(msgType)
+		if !ok {
+			continue
+		}
+		messageCounter, _ := msgMap["message_counter"].(int16)
+		for _, transfer := range entry.transfers(msgMap) {
+			transfer.Coins = nonZeroCoins(transfer.Coins)
+			if len(transfer.Coins) == 0 || transfer.From == "" || transfer.To == "" {
+				continue
+			}
+			transfer.MessageCounter = messageCounter
+			transfers = append(transfers, transfer)
+		}
+	}
+	return transfers
+}
+
+// nonZeroCoins drops the coins without a denom or an amount
+func nonZeroCoins(coins []Coin) []Coin {
+	result := make([]Coin, 0, len(coins))
+	for _, coin := range coins {
+		if coin.Denom == "" || coin.Amount == 0 {
+			continue
+		}
+		result = append(result, coin)
+	}
+	return result
+}
+
 // CollectAllAddresses extracts all unique addresses from the decoded message
 // This includes signers and all addresses from individual messages
 func (dm *DecodedMsg) CollectAllAddresses() []string {
@@ -115,36 +212,18 @@ func (dm *DecodedMsg) CollectAllAddresses() []string {
 		addressSet[signer] = true
 	}
 
-	// Add addresses from each message
+	// Add addresses from each message with the address extractor of its type
 	for _, msgMap := range dm.Messages {
 		msgType, ok := msgMap["msg_type"].(string)
 		if !ok {
 			continue
 		}
-
-		switch msgType {
-		case "bank_msg_send":
-			if fromAddr, ok := msgMap["from_address"].(string); ok {
-				addressSet[fromAddr] = true
-			}
-			if toAddr, ok := msgMap["to_address"].(string); ok {
-				addressSet[toAddr] = true
-			}
-
-		case "vm_msg_call":
-			if caller, ok := msgMap["caller"].(string); ok {
-				addressSet[caller] = true
-			}
-
-		case "vm_msg_add_package":
-			if creator, ok := msgMap["creator"].(string); ok {
-				addressSet[creator] = true
-			}
-
-		case "vm_msg_run":
-			if caller, ok := msgMap["caller"].(string); ok {
-				addressSet[caller] = true
-			}
+		entry, ok := DefaultRegistry.get(msgType)
+		if !ok {
+			continue
+		}
+		for _, address := range entry.addresses(msgMap) {
+			addressSet[address] = true
 		}
 	}
 
@@ -157,351 +236,60 @@ func (dm *DecodedMsg) CollectAllAddresses() []string {
 	return addresses
 }
 
-// DbMessageGroups holds database-ready message types with address IDs
-type DbMessageGroups struct {
-	MsgSend   []dataTypes.MsgSend
-	MsgCall   []dataTypes.MsgCall
-	MsgAddPkg []dataTypes.MsgAddPackage
-	MsgRun    []dataTypes.MsgRun
-}
-
-// ConvertToDbMessages directly converts the decoded message maps to database-ready message types
-// This method combines the previous two-step conversion into a single step for better performance
+// ConvertToDbMessages directly converts the decoded message maps to database-ready rows
+// every message is converted with the converter of its registered type
+//
+// Parameters:
+//   - addressResolver: resolves the addresses to their ids
+//   - txHash: the hash of the transaction
+//   - chainName: the name of the chain
+//   - timestamp: the timestamp of the transaction
+//   - signers: the signers of the transaction
+//
+// Returns:
+//   - *MsgRows: the rows grouped by the message type
+//   - error: if the message type is not registered or the conversion fails
 func (dm *DecodedMsg) ConvertToDbMessages(
 	addressResolver AddressResolver,
 	txHash []byte,
 	chainName string,
 	timestamp time.Time,
 	signers []string,
-) (*DbMessageGroups, error) {
+) (*MsgRows, error) {
 	// Convert signers to address IDs once
 	signerIds := make([]int32, len(signers))
 	for k, signer := range signers {
 		signerIds[k] = addressResolver.GetAddress(signer)
 	}
 
-	dbGroups := &DbMessageGroups{
-		MsgSend:   make([]dataTypes.MsgSend, 0),
-		MsgCall:   make([]dataTypes.MsgCall, 0),
-		MsgAddPkg: make([]dataTypes.MsgAddPackage, 0),
-		MsgRun:    make([]dataTypes.MsgRun, 0),
-	}
-
+	rows := NewMsgRows(DefaultRegistry)
 	for _, msgMap := range dm.Messages {
 		msgType, ok := msgMap["msg_type"].(string)
 		if !ok {
 			return nil, fmt.Errorf("missing or invalid msg_type")
 		}
-
-		switch msgType {
-		case "bank_msg_send":
-			msg, err := dm.convertToDbMsgSend(msgMap, addressResolver, txHash, chainName, timestamp, signerIds)
-			if err != nil {
-				return nil, fmt.Errorf("failed to convert bank_msg_send: %w", err)
-			}
-			dbGroups.MsgSend = append(dbGroups.MsgSend, *msg)
-
-		case "vm_msg_call":
-			msg, err := dm.convertToDbMsgCall(msgMap, addressResolver, txHash, chainName, timestamp, signerIds)
-			if err != nil {
-				return nil, fmt.Errorf("failed to convert vm_msg_call: %w", err)
-			}
-			dbGroups.MsgCall = append(dbGroups.MsgCall, *msg)
-
-		case "vm_msg_add_package":
-			msg, err := dm.convertToDbMsgAddPackage(msgMap, addressResolver, txHash, chainName, timestamp, signerIds)
-			if err != nil {
-				return nil, fmt.Errorf("failed to convert vm_msg_add_package: %w", err)
-			}
-			dbGroups.MsgAddPkg = append(dbGroups.MsgAddPkg, *msg)
-
-		case "vm_msg_run":
-			msg, err := dm.convertToDbMsgRun(msgMap, addressResolver, txHash, chainName, timestamp, signerIds)
-			if err != nil {
-				return nil, fmt.Errorf("failed to convert vm_msg_run: %w", err)
-			}
-			dbGroups.MsgRun = append(dbGroups.MsgRun, *msg)
-
-		default:
+		entry, ok := DefaultRegistry.get(msgType)
+		if !ok {
 			return nil, fmt.Errorf("unknown message type: %s", msgType)
 		}
-	}
-
-	return dbGroups, nil
-}
-
-// convertToDbMsgSend converts a map data type directly to a database-ready MsgSend struct
-func (dm *DecodedMsg) convertToDbMsgSend(
-	msgMap map[string]any,
-	addressResolver AddressResolver,
-	txHash []byte,
-	chainName string,
-	timestamp time.Time,
-	signerIds []int32,
-) (*dataTypes.MsgSend, error) {
-	messageCounter, ok := msgMap["message_counter"].(int16)
-	if !ok {
-		return nil, fmt.Errorf("missing message_counter")
-	}
-
-	fromAddress, ok := msgMap["from_address"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing from_address")
-	}
-
-	toAddress, ok := msgMap["to_address"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing to_address")
-	}
-
-	// Convert amount from []Coin to dataTypes.Amount
-	coinAmount, ok := msgMap["amount"].([]Coin)
-	if !ok {
-		return nil, fmt.Errorf("missing amount")
-	}
-
-	amount := make([]dataTypes.Amount, len(coinAmount))
-	for j, amt := range coinAmount {
-		bigInt := big.NewInt(amt.Amount)
-		amount[j] = dataTypes.Amount{
-			Amount: pgtype.Numeric{Int: bigInt, Valid: true},
-			Denom:  amt.Denom,
-		}
-	}
-
-	return &dataTypes.MsgSend{
-		TxHash:         txHash,
-		ChainName:      chainName,
-		FromAddress:    addressResolver.GetAddress(fromAddress),
-		ToAddress:      addressResolver.GetAddress(toAddress),
-		Amount:         amount,
-		Signers:        signerIds,
-		Timestamp:      timestamp,
-		MessageCounter: messageCounter,
-	}, nil
-}
-
-// convertToDbMsgCall converts a map data type directly to a database-ready MsgCall struct
-func (dm *DecodedMsg) convertToDbMsgCall(
-	msgMap map[string]any,
-	addressResolver AddressResolver,
-	txHash []byte,
-	chainName string,
-	timestamp time.Time,
-	signerIds []int32,
-) (*dataTypes.MsgCall, error) {
-	messageCounter, ok := msgMap["message_counter"].(int16)
-	if !ok {
-		return nil, fmt.Errorf("missing message_counter")
-	}
-
-	caller, ok := msgMap["caller"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing caller")
-	}
-
-	pkgPath, ok := msgMap["pkg_path"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing pkg_path")
-	}
-
-	funcName, ok := msgMap["func_name"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing func_name")
-	}
-
-	argsStr, ok := msgMap["args"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing args")
-	}
-
-	// Convert send from []Coin to dataTypes.Amount
-	coinSend, ok := msgMap["send"].([]Coin)
-	if !ok {
-		return nil, fmt.Errorf("missing send")
-	}
-
-	send := make([]dataTypes.Amount, len(coinSend))
-	for j, amt := range coinSend {
-		bigInt := big.NewInt(amt.Amount)
-		send[j] = dataTypes.Amount{
-			Amount: pgtype.Numeric{Int: bigInt, Valid: true},
-			Denom:  amt.Denom,
-		}
-	}
-
-	// Convert maxDeposit from []Coin to dataTypes.Amount
-	coinMaxDeposit, ok := msgMap["max_deposit"].([]Coin)
-	if !ok {
-		return nil, fmt.Errorf("missing max_deposit")
-	}
-
-	maxDeposit := make([]dataTypes.Amount, len(coinMaxDeposit))
-	for j, amt := range coinMaxDeposit {
-		bigInt := big.NewInt(amt.Amount)
-		maxDeposit[j] = dataTypes.Amount{
-			Amount: pgtype.Numeric{Int: bigInt, Valid: true},
-			Denom:  amt.Denom,
-		}
-	}
-
-	return &dataTypes.MsgCall{
-		TxHash:         txHash,
-		MessageCounter: messageCounter,
-		ChainName:      chainName,
-		Caller:         addressResolver.GetAddress(caller),
-		Send:           send,
-		PkgPath:        pkgPath,
-		FuncName:       funcName,
-		Args:           argsStr,
-		MaxDeposit:     maxDeposit,
-		Signers:        signerIds,
-		Timestamp:      timestamp,
-	}, nil
-}
-
-// convertToDbMsgAddPackage converts a map data type directly to a database-ready MsgAddPackage struct
-func (dm *DecodedMsg) convertToDbMsgAddPackage(
-	msgMap map[string]any,
-	addressResolver AddressResolver,
-	txHash []byte,
-	chainName string,
-	timestamp time.Time,
-	signerIds []int32,
-) (*dataTypes.MsgAddPackage, error) {
-	messageCounter, ok := msgMap["message_counter"].(int16)
-	if !ok {
-		return nil, fmt.Errorf("missing message_counter")
-	}
-
-	creator, ok := msgMap["creator"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing creator")
-	}
-
-	pkgPath, ok := msgMap["pkg_path"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing pkg_path")
-	}
-
-	pkgName, ok := msgMap["pkg_name"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing pkg_name")
-	}
-
-	// Convert send from []Coin to dataTypes.Amount
-	coinSend, ok := msgMap["send"].([]Coin)
-	if !ok {
-		return nil, fmt.Errorf("missing send")
-	}
-
-	send := make([]dataTypes.Amount, len(coinSend))
-	for j, amt := range coinSend {
-		bigInt := big.NewInt(amt.Amount)
-		send[j] = dataTypes.Amount{
-			Amount: pgtype.Numeric{Int: bigInt, Valid: true},
-			Denom:  amt.Denom,
-		}
-	}
-
-	// Convert maxDeposit from []Coin to dataTypes.Amount
-	coinMaxDeposit, ok := msgMap["max_deposit"].([]Coin)
-	if !ok {
-		return nil, fmt.Errorf("missing max_deposit")
-	}
-
-	maxDeposit := make([]dataTypes.Amount, len(coinMaxDeposit))
-	for j, amt := range coinMaxDeposit {
-		bigInt := big.NewInt(amt.Amount)
-		maxDeposit[j] = dataTypes.Amount{
-			Amount: pgtype.Numeric{Int: bigInt, Valid: true},
-			Denom:  amt.Denom,
-		}
-	}
-
-	return &dataTypes.MsgAddPackage{
-		TxHash:         txHash,
-		MessageCounter: messageCounter,
-		ChainName:      chainName,
-		Creator:        addressResolver.GetAddress(creator),
-		PkgPath:        pkgPath,
-		PkgName:        pkgName,
-		Send:           send,
-		MaxDeposit:     maxDeposit,
-		Signers:        signerIds,
-		Timestamp:      timestamp,
-	}, nil
-}
-
-// convertToDbMsgRun converts a map data type directly to a database-ready MsgRun struct
-func (dm *DecodedMsg) convertToDbMsgRun(
-	msgMap map[string]any,
-	addressResolver AddressResolver,
-	txHash []byte,
-	chainName string,
-	timestamp time.Time,
-	signerIds []int32,
-) (*dataTypes.MsgRun, error) {
-	messageCounter, ok := msgMap["message_counter"].(int16)
-	if !ok {
-		return nil, fmt.Errorf("missing message_counter")
-	}
-
-	caller, ok := msgMap["caller"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing caller")
-	}
-
-	pkgPath, ok := msgMap["pkg_path"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing pkg_path")
-	}
-
-	pkgName, ok := msgMap["pkg_name"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing pkg_name")
-	}
-
-	// Convert send from []Coin to dataTypes.Amount
-	coinSend, ok := msgMap["send"].([]Coin)
-	if !ok {
-		return nil, fmt.Errorf("missing send")
-	}
-
-	send := make([]dataTypes.Amount, len(coinSend))
-	for j, amt := range coinSend {
-		bigInt := big.NewInt(amt.Amount)
-		send[j] = dataTypes.Amount{
-			Amount: pgtype.Numeric{Int: bigInt, Valid: true},
-			Denom:  amt.Denom,
+		messageCounter, ok := msgMap["message_counter"].(int16)
+		if !ok {
+			return nil, fmt.Errorf("failed to convert %s: missing message_counter", msgType)
 		}
-	}
-
-	// Convert maxDeposit from []Coin to dataTypes.Amount
-	coinMaxDeposit, ok := msgMap["max_deposit"].([]Coin)
-	if !ok {
-		return nil, fmt.Errorf("missing max_deposit")
-	}
 
-	maxDeposit := make([]dataTypes.Amount, len(coinMaxDeposit))
-	for j, amt := range coinMaxDeposit {
-		bigInt := big.NewInt(amt.Amount)
-		maxDeposit[j] = dataTypes.Amount{
-			Amount: pgtype.Numeric{Int: bigInt, Valid: true},
-			Denom:  amt.Denom,
+		row, err := entry.convert(msgMap, MsgTx{
+			TxHash:         txHash,
+			ChainName:      chainName,
+			Timestamp:      timestamp,
+			MessageCounter: messageCounter,
+			SignerIds:      signerIds,
+			Addresses:      addressResolver,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s: %w", msgType, err)
 		}
+		rows.add(msgType, row, chainName, timestamp)
 	}
 
-	return &dataTypes.MsgRun{
-		TxHash:         txHash,
-		MessageCounter: messageCounter,
-		ChainName:      chainName,
-		Caller:         addressResolver.GetAddress(caller),
-		PkgPath:        pkgPath,
-		PkgName:        pkgName,
-		Send:           send,
-		MaxDeposit:     maxDeposit,
-		Signers:        signerIds,
-		Timestamp:      timestamp,
-	}, nil
+	return rows, nil
 }
@@ -0,0 +1,353 @@
+package decoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	dataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// DefaultRegistry holds every message type the indexer knows about.
+//
+// The built in message types are registered when the package is loaded, the app specific
+// message types can be added with Register before the indexer is started.
+var DefaultRegistry = NewRegistry()
+
+// MsgRow is the database row of a message
+type MsgRow interface {
+	TableName() string
+	GetAllAddresses() *dataTypes.TxAddresses
+}
+
+// MsgTx holds the data of the transaction the message belongs to, used to build the database row
+type MsgTx struct {
+	TxHash         []byte
+	ChainName      string
+	Timestamp      time.Time
+	MessageCounter int16
+	// the address ids of the transaction signers
+	SignerIds []int32
+	// resolves the addresses of the message to their ids
+	Addresses AddressResolver
+}
+
+// MessageType describes how a single message type is decoded, converted and stored
+//
+// M is the amino type of the message, it needs to be registered with amino so the transaction
+// can be decoded. R is the database row of the message.
+type MessageType[M std.Msg, R MsgRow] struct {
+	// Name of the message type, it is stored within the msg types of the transaction
+	// and it should be the same as the table name of the row
+	Name string
+	// Decode converts the message to the map data type, the msg_type and the message_counter are added to it
+	Decode func(msg M) map[string]any
+	// Addresses returns every address of the decoded message, they are resolved to ids before the Convert
+	Addresses func(msgMap map[string]any) []string
+	// Convert converts the decoded message to the database row
+	Convert func(msgMap map[string]any, tx MsgTx) (R, error)
+	// Insert writes the rows of the chunk to the database, db is the database of the data processor.
+	// It can be left out when the Table and the Values are set.
+	Insert func(ctx context.Context, db any, rows []R) error
+	// Table of the rows, it is registered with the database so the rows are written by the chunk batch,
+	// skipped or updated by the insert mode and removed on a rewind without changes to the database
+	Table database.Table
+	// Values returns the values of the row in the order of the table columns
+	Values func(row R) []any
+	// Transfers returns the native coins moved by the decoded message, they are stored as the native
+	// balance changes of the transaction. It can be left out when the message doesn't move any coins.
+	Transfers func(msgMap map[string]any) []CoinTransfer
+}
+
+// rowsInserter is the database method that writes the rows of the registered tables
+type rowsInserter interface {
+	InsertRows(ctx context.Context, rows database.TableRows) error
+}
+
+// Registry holds the registered message types
+//
+// The messages are matched to their type by the amino type, the ones without a registered type
+// are decoded with the msg_generic type.
+type Registry struct {
+	mu     sync.RWMutex
+	byType map[reflect.Type]*msgEntry
+	byName map[string]*msgEntry
+	order  []string
+}
+
+// msgEntry is the registered message type with its name
+type msgEntry struct {
+	name string
+	registeredMsg
+}
+
+// registeredMsg is the MessageType without the type parameters
+type registeredMsg interface {
+	decode(msg std.Msg) map[string]any
+	addresses(msgMap map[string]any) []string
+	convert(msgMap map[string]any, tx MsgTx) (MsgRow, error)
+	insert(ctx context.Context, db any, rows []MsgRow) error
+	transfers(msgMap map[string]any) []CoinTransfer
+}
+
+// NewRegistry creates a new empty registry
+//
+// Returns:
+//   - *Registry: the registry without any message types
+func NewRegistry() *Registry {
+	return &Registry{
+		byType: make(map[reflect.Type]*msgEntry),
+		byName: make(map[string]*msgEntry),
+		order:  make([]string, 0),
+	}
+}
+
+// Register adds the message type to the registry
+//
+// Usage:
+//
+// # Used to add the app specific messages before the indexer is started
+//
+// Parameters:
+//   - r: the registry to add the message type to
+//   - msgType: the message type
+//
+// Returns:
+//   - error: if any of the functions is missing, the table is not valid
+//     or the message type is already registered
+func Register[M std.Msg, R MsgRow](r *Registry, msgType MessageType[M, R]) error {
+	if msgType.Name == "" {
+		return errors.New("message type name is required")
+	}
+	if msgType.Decode == nil || msgType.Addresses == nil || msgType.Convert == nil {
+		return fmt.Errorf("message type %s needs the decode, addresses and convert functions", msgType.Name)
+	}
+	hasTable := msgType.Table.Name != ""
+	if hasTable != (msgType.Values != nil) {
+		return fmt.Errorf("message type %s needs both the table and the values function", msgType.Name)
+	}
+	if msgType.Insert == nil && !hasTable {
+		return fmt.Errorf("message type %s needs the insert function or the table", msgType.Name)
+	}
+
+	aminoType := reflect.TypeFor[M]()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byName[msgType.Name]; ok {
+		return fmt.Errorf("message type %s is already registered", msgType.Name)
+	}
+	if _, ok := r.byType[aminoType]; ok {
+		return fmt.Errorf("amino type %s is already registered", aminoType)
+	}
+	if hasTable {
+		if err := database.RegisterTable(msgType.Table); err != nil {
+			return fmt.Errorf("failed to register the table of message type %s: %w", msgType.Name, err)
+		}
+	}
+	entry := &msgEntry{name: msgType.Name, registeredMsg: msgType}
+	r.byType[aminoType] = entry
+	r.byName[msgType.Name] = entry
+	r.order = append(r.order, msgType.Name)
+	return nil
+}
+
+// Types returns the names of the registered message types in the order they were registered
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, len(r.order))
+	copy(types, r.order)
+	return types
+}
+
+// decode converts the message to the map data type with its registered type
+func (r *Registry) decode(msg std.Msg, messageCounter int16) map[string]any {
+	r.mu.RLock()
+	entry, ok := r.byType[reflect.TypeOf(msg)]
+	if !ok {
+		entry, ok = r.byName[GenericMsgType]
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	msgMap := entry.decode(msg)
+	msgMap["msg_type"] = entry.name
+	msgMap["message_counter"] = messageCounter
+	return msgMap
+}
+
+// get returns the message type with the given name
+func (r *Registry) get(name string) (*msgEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.byName[name]
+	return entry, ok
+}
+
+func (t MessageType[M, R]) decode(msg std.Msg) map[string]any {
+	return t.Decode(msg.(M))
+}
+
+func (t MessageType[M, R]) addresses(msgMap map[string]any) []string {
+	return t.Addresses(msgMap)
+}
+
+func (t MessageType[M, R]) convert(msgMap map[string]any, tx MsgTx) (MsgRow, error) {
+	return t.Convert(msgMap, tx)
+}
+
+func (t MessageType[M, R]) insert(ctx context.Context, db any, rows []MsgRow) error {
+	if t.Insert == nil {
+		return t.insertRows(ctx, db, rows)
+	}
+	typed := make([]R, len(rows))
+	for i, row := range rows {
+		typed[i] = row.(R)
+	}
+	return t.Insert(ctx, db, typed)
+}
+
+func (t MessageType[M, R]) transfers(msgMap map[string]any) []CoinTransfer {
+	if t.Transfers == nil {
+		return nil
+	}
+	return t.Transfers(msgMap)
+}
+
+// insertRows writes the rows to the registered table of the message type
+func (t MessageType[M, R]) insertRows(ctx context.Context, db any, rows []MsgRow) error {
+	inserter, ok := db.(rowsInserter)
+	if !ok {
+		return fmt.Errorf("the database doesn't support the %s messages", t.Name)
+	}
+	tableRows := database.TableRows{Table: t.Table.Name, Rows: make([][]any, len(rows))}
+	for i, row := range rows {
+		tableRows.Rows[i] = t.Values(row.(R))
+	}
+	return inserter.InsertRows(ctx, tableRows)
+}
+
+// MsgRows holds the database rows of the messages grouped by the message type
+type MsgRows struct {
+	registry  *Registry
+	rows      map[string][]MsgRow
+	addressTx []dataTypes.AddressTx
+}
+
+// NewMsgRows creates an empty group of rows for the message types of the registry
+//
+// Parameters:
+//   - registry: the registry used to insert the rows
+//
+// Returns:
+//   - *MsgRows: the empty rows
+func NewMsgRows(registry *Registry) *MsgRows {
+	return &MsgRows{
+		registry:  registry,
+		rows:      make(map[string][]MsgRow),
+		addressTx: make([]dataTypes.AddressTx, 0),
+	}
+}
+
+// add appends the row and the address tx rows of its addresses
+func (r *MsgRows) add(msgType string, row MsgRow, chainName string, timestamp time.Time) {
+	r.rows[msgType] = append(r.rows[msgType], row)
+
+	txAddresses := row.GetAllAddresses()
+	for _, address := range txAddresses.GetAddressList() {
+		r.addressTx = append(r.addressTx, dataTypes.AddressTx{
+			Address:   address,
+			TxHash:    txAddresses.TxHash,
+			ChainName: chainName,
+			Timestamp: timestamp,
+			MsgTypes:  []string{row.TableName()},
+		})
+	}
+}
+
+// Merge appends all of the rows from the other group
+func (r *MsgRows) Merge(other *MsgRows) {
+	if other == nil {
+		return
+	}
+	for msgType, rows := range other.rows {
+		r.rows[msgType] = append(r.rows[msgType], rows...)
+	}
+	r.addressTx = append(r.addressTx, other.addressTx...)
+}
+
+// Types returns the message types that have any rows, in the order they were registered
+func (r *MsgRows) Types() []string {
+	types := make([]string, 0, len(r.rows))
+	for _, msgType := range r.registry.Types() {
+		if len(r.rows[msgType]) > 0 {
+			types = append(types, msgType)
+		}
+	}
+	return types
+}
+
+// Count returns the amount of rows of the message type
+func (r *MsgRows) Count(msgType string) int {
+	return len(r.rows[msgType])
+}
+
+// AddressTx returns the address tx rows of every message
+func (r *MsgRows) AddressTx() []dataTypes.AddressTx {
+	return r.addressTx
+}
+
+// TxHashes returns the tx hashes of the rows of the message type
+func (r *MsgRows) TxHashes(msgType string) [][]byte {
+	rows := r.rows[msgType]
+	hashes := make([][]byte, len(rows))
+	for i, row := range rows {
+		hashes[i] = row.GetAllAddresses().TxHash
+	}
+	return hashes
+}
+
+// Insert writes the rows of the message type with its registered insert function
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - db: the database of the data processor
+//   - msgType: the name of the message type
+//
+// Returns:
+//   - error: if the message type is not registered or the insert fails
+func (r *MsgRows) Insert(ctx context.Context, db any, msgType string) error {
+	registered, ok := r.registry.get(msgType)
+	if !ok {
+		return fmt.Errorf("message type %s is not registered", msgType)
+	}
+	return registered.insert(ctx, db, r.rows[msgType])
+}
+
+// insertWith creates the insert function that asserts the database to the interface with the insert method
+//
+// Parameters:
+//   - name: the name of the message type, used for the error
+//   - insert: the insert method expression of the interface
+//
+// Returns:
+//   - func(ctx context.Context, db any, rows []R) error: the insert function of the message type
+func insertWith[I any, R any](
+	name string,
+	insert func(I, context.Context, []R) error,
+) func(ctx context.Context, db any, rows []R) error {
+	return func(ctx context.Context, db any, rows []R) error {
+		inserter, ok := db.(I)
+		if !ok {
+			return fmt.Errorf("the database doesn't support the %s messages", name)
+		}
+		return insert(inserter, ctx, rows)
+	}
+}
@@ -1,9 +1,15 @@
 package decoder
 
 import (
+	"crypto/sha256"
+
 	datatypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
+// GenericMsgType is the msg_type of the messages that don't have their own table
+const GenericMsgType = "msg_generic"
+
 type BasicTxData struct {
 	TxHash []byte
 	// gno addresses
@@ -11,6 +17,50 @@ type BasicTxData struct {
 	Memo          string
 	Fee           datatypes.Amount
 	TotalMsgCount int
+	Signatures    []TxSignature
+}
+
+// TxSignature is a signature of the transaction with the public key of its signer
+//
+// The public key is only included in the first transactions of an account, after that the chain
+// has it stored and the signature comes without it. The account number and the sequence are
+// part of the signed bytes but not of the transaction, the data processor reads them from the node.
+type TxSignature struct {
+	// gno address of the signer, empty if it can't be known
+	Signer     string
+	PubKeyType string
+	PubKey     []byte
+	Signature  []byte
+}
+
+// PackageFile is a source file of the package deployed with MsgAddPackage or MsgRun
+type PackageFile struct {
+	Name string
+	Body string
+}
+
+// Hash returns the sha256 hash of the file body, the archived files are stored by this hash
+func (f PackageFile) Hash() []byte {
+	hash := sha256.Sum256([]byte(f.Body))
+	return hash[:]
+}
+
+// DeployedPackage is a package added to the chain with MsgAddPackage
+type DeployedPackage struct {
+	PkgPath        string
+	PkgName        string
+	Creator        string
+	FileCount      int
+	MessageCounter int16
+}
+
+// CoinTransfer is a move of native coins between two addresses made by a message
+type CoinTransfer struct {
+	From           string
+	To             string
+	Coins          []Coin
+	Source         string
+	MessageCounter int16
 }
 
 type Coin struct {
@@ -36,3 +86,18 @@ type DecodedMsg struct {
 	BasicData BasicTxData
 	Messages  []map[string]any
 }
+
+// rawAny is the amino Any value of a message, the type url and the encoded message
+type rawAny struct {
+	TypeURL string
+	Value   []byte
+}
+
+// rawTx has the same layout as std.Tx but it keeps the messages as raw Any values
+// so the transaction can be decoded even if some of the messages are not registered with amino
+type rawTx struct {
+	Msgs       []rawAny
+	Fee        std.Fee
+	Signatures []std.Signature
+	Memo       string
+}
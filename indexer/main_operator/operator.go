@@ -45,10 +45,29 @@ func InitMainOperator(
 
 	mc := initializeMajorConstructors(conf, env, *chainName, rpcFlags)
 
+	// the skip and update insert modes allow re-indexing of the already stored ranges
+	insertMode, err := database.ParseInsertMode(runningFlags.InsertMode)
+	if err != nil {
+		l.Fatal().Caller().Stack().Err(err).Msg("failed to parse insert mode")
+	}
+	mc.db.SetInsertMode(insertMode)
+
 	// initialize the orchestrator
 	orch := orchestrator.NewOrchestrator(
 		runningFlags.RunningMode, conf, *chainName, mc.db, mc.gnoRpcClient, mc.dataProcessor, mc.queryOperator,
 	)
+	// live mode can be driven by the websocket new block events instead of polling
+	if runningFlags.UseWebsocket {
+		orch.SetBlockSubscriber(mc.gnoRpcClient)
+	}
+	// live mode can also backfill the gaps left by the failed chunks
+	if runningFlags.GapCheckInterval > 0 {
+		orch.SetGapCheckInterval(runningFlags.GapCheckInterval)
+	}
+	// and compare the indexed native balances with the balances of the node
+	if runningFlags.BalanceReconcileInterval > 0 {
+		orch.SetBalanceReconciler(mc.db, mc.gnoRpcClient, runningFlags.BalanceReconcileInterval)
+	}
 
 	// Setup signal handling with proper cleanup and state dump functions
 	signalHandler := contextHook.NewSignalHandler(
@@ -104,9 +123,22 @@ func InitMainOperator(
 			<-signalHandler.Context().Done()
 			l.Info().Msg("Shutdown signal received during historic processing")
 		}()
-		orch.HistoricProcess(runningFlags.FromHeight, runningFlags.ToHeight, runningFlags.CompressEvents)
+		orch.HistoricProcess(
+			runningFlags.FromHeight, runningFlags.ToHeight, runningFlags.CompressEvents, runningFlags.Resume,
+		)
+	case "gaps":
+		if runningFlags.ToHeight != 0 && runningFlags.FromHeight > runningFlags.ToHeight {
+			l.Fatal().Caller().Stack().Msg("from height must be less than to height")
+		}
+		go func() {
+			<-signalHandler.Context().Done()
+			l.Info().Msg("Shutdown signal received during gap processing")
+		}()
+		orch.GapProcess(
+			runningFlags.FromHeight, runningFlags.ToHeight, runningFlags.CompressEvents, runningFlags.DryRun,
+		)
 	default:
-		l.Fatal().Caller().Stack().Msg("invalid running mode, please choose between live and historic")
+		l.Fatal().Caller().Stack().Msg("invalid running mode, please choose between live, historic and gaps")
 	}
 }
 
@@ -123,7 +155,7 @@ func InitMainOperator(
 func initializeDatabase(conf *config.Config, env *config.Environment) *database.TimescaleDb {
 	// check if the config has any null
 	// if rpc is null throw an error and exit
-	if conf.RpcUrl == "" {
+	if len(conf.Endpoints()) == 0 {
 		l.Fatal().Caller().Stack().Msg("rpc url is required")
 	}
 	// if pool max connections is 0 or nil set a default of 100
@@ -210,27 +242,50 @@ func initializeMajorConstructors(
 	// initialize the database
 	db := initializeDatabase(conf, env)
 
-	// initialize the rpc client
-	gnoRpcClient, err := rpcClient.NewRateLimitedRpcClient(
-		conf.RpcUrl, nil, rpcFlags.RequestsPerWindow, rpcFlags.TimeWindow,
-	)
+	// initialize the rpc client for every endpoint, each one has its own rate limiter
+	endpoints := make([]query.PoolEndpoint, 0, len(conf.Endpoints()))
+	for _, rpcUrl := range conf.Endpoints() {
+		client, err := rpcClient.NewRateLimitedRpcClient(
+			rpcUrl, nil, rpcFlags.RequestsPerWindow, rpcFlags.TimeWindow,
+		)
+		if err != nil {
+			l.Fatal().Caller().Stack().Err(err).Str("rpc", rpcUrl).Msg("failed to initialize rpc client")
+		}
+		endpoints = append(endpoints, query.PoolEndpoint{Url: rpcUrl, Client: client})
+	}
+
+	rpcSelection, err := query.ParseSelectionStrategy(conf.RpcSelection)
 	if err != nil {
-		l.Fatal().Caller().Stack().Err(err).Msg("failed to initialize rpc client")
+		l.Fatal().Caller().Stack().Err(err).Msg("invalid rpc selection")
 	}
 
+	// the pool balances the requests between the endpoints and evicts the unhealthy ones
+	gnoRpcClient, err := query.NewRpcPool(endpoints, rpcSelection, conf.RpcMaxLag, conf.RpcMaxFailures)
+	if err != nil {
+		l.Fatal().Caller().Stack().Err(err).Msg("failed to initialize rpc pool")
+	}
+	gnoRpcClient.StartHealthCheck(conf.RpcHealthCheckInterval)
+	l.Info().Strs("healthy", gnoRpcClient.Healthy()).Int("endpoints", len(endpoints)).Msg("rpc pool initialized")
+
 	// initialize the validator cache
 	validatorCache := addressCache.NewAddressCache(chainName, db, true)
 
 	// initialize the address cache
 	addressCache := addressCache.NewAddressCache(chainName, db, false)
 
-	// initialize the data processor
-	dataProcessor := dp.NewDataProcessor(db, addressCache, validatorCache, chainName)
+	// initialize the data processor, the rows of every chunk are written within one transaction
+	dataProcessor := dp.NewDataProcessor(db.NewChunkBatch(), addressCache, validatorCache, chainName)
+	// the account number and the sequence of the signatures are read from the node, it needs an archive node
+	if conf.QuerySignerAccounts {
+		dataProcessor.SetAccountQuerier(gnoRpcClient)
+	}
 
 	// initialize the query operator
 	queryOperator := query.NewQueryOperator(
 		gnoRpcClient, conf.RetryAmount, conf.Pause, conf.PauseTime, conf.ExponentialBackoff,
 	)
+	// request the blocks, commits and transactions with the JSON-RPC batches
+	queryOperator.SetBatchSize(conf.MaxTransactionChunkSize)
 
 	return &MajorConstructors{
 		db:             db,
@@ -253,7 +308,7 @@ func (mc *MajorConstructors) cleanup() error {
 		l.Info().Msg("Database connection pool closed successfully")
 	}
 
-	// Close RPC client (closes the rate limiter)
+	// Close RPC pool (stops the health check and closes the rate limiters)
 	if mc.gnoRpcClient != nil {
 		l.Info().Msg("Closing RPC client...")
 		mc.gnoRpcClient.Close()
@@ -4,7 +4,6 @@ import (
 	addressCache "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/address_cache"
 	dataProcessor "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/data_processor"
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/query"
-	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
 )
 
@@ -12,7 +11,7 @@ import (
 // for the indexer
 type MajorConstructors struct {
 	db             *database.TimescaleDb
-	gnoRpcClient   *rpcClient.RateLimitedRpcClient
+	gnoRpcClient   *query.RpcPool
 	validatorCache *addressCache.AddressCache
 	addressCache   *addressCache.AddressCache
 	dataProcessor  *dataProcessor.DataProcessor
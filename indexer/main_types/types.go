@@ -9,9 +9,15 @@ type RpcFlags struct {
 }
 
 type RunningFlags struct {
-	RunningMode        string
-	SkipInitialDbCheck bool
-	CompressEvents     bool
-	FromHeight         uint64
-	ToHeight           uint64
+	RunningMode              string
+	SkipInitialDbCheck       bool
+	UseWebsocket             bool
+	CompressEvents           bool
+	FromHeight               uint64
+	ToHeight                 uint64
+	Resume                   bool
+	DryRun                   bool
+	GapCheckInterval         time.Duration
+	BalanceReconcileInterval time.Duration
+	InsertMode               string
 }
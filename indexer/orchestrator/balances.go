@@ -0,0 +1,221 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// reconcileBatchSize is the max amount of addresses read from the database in one page,
+// every address is one abci query
+const reconcileBatchSize uint64 = 100
+
+// reconcileSource is the source of the balance changes stored by the reconciliation
+const reconcileSource = "reconcile"
+
+// SetBalanceReconciler enables the live process to periodically compare the native balances of the
+// recently changed addresses with the bank/balances abci query of the node. The differences are stored
+// as balance changes at the compared height. Zero interval disables the check.
+//
+// Parameters:
+//   - db: the database to read the indexed balances from and to store the corrections
+//   - querier: the rpc client used for the abci queries
+//   - interval: the interval between the checks
+func (or *Orchestrator) SetBalanceReconciler(db BalanceDatabase, querier AbciQuerier, interval time.Duration) {
+	or.balanceDb = db
+	or.abciQuerier = querier
+	or.reconcileInterval = interval
+	or.nextReconcile = time.Now().Add(interval)
+}
+
+// checkBalances is a private method used by the live process to reconcile the balances of the
+// addresses changed since the last check once the reconcile interval passes.
+//
+// The changed addresses are read in pages, the reconciled height only moves forward once all of
+// them are compared, so a failed check is done again with the next interval.
+//
+// Parameters:
+//   - lastProcessedHeight: the last height processed by the live process
+func (or *Orchestrator) checkBalances(lastProcessedHeight uint64) {
+	if or.reconcileInterval <= 0 || or.balanceDb == nil || or.abciQuerier == nil || lastProcessedHeight == 0 {
+		return
+	}
+	// the first check covers the addresses changed since the live process started
+	if or.reconciledHeight == 0 {
+		or.reconciledHeight = lastProcessedHeight
+	}
+	if time.Now().Before(or.nextReconcile) || lastProcessedHeight <= or.reconciledHeight {
+		return
+	}
+	or.nextReconcile = time.Now().Add(or.reconcileInterval)
+
+	adjustments := make([]sqlDataTypes.NativeBalanceChange, 0)
+	checked := 0
+	var afterAddressId int32
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		balances, err := or.balanceDb.GetChangedNativeBalances(
+			ctx, or.chainName, or.reconciledHeight, lastProcessedHeight, afterAddressId, reconcileBatchSize,
+		)
+		cancel()
+		if err != nil {
+			l.Error().Caller().Stack().Err(err).Msg("Failed to get the changed native balances")
+			return
+		}
+		adjustments = or.compareBalances(balances, lastProcessedHeight, adjustments)
+		checked += len(balances)
+		if uint64(len(balances)) < reconcileBatchSize {
+			break
+		}
+		afterAddressId = balances[len(balances)-1].AddressId
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	err := or.balanceDb.InsertNativeBalanceAdjustments(ctx, or.chainName, lastProcessedHeight, adjustments)
+	cancel()
+	if err != nil {
+		l.Error().Caller().Stack().Err(err).Msg("Failed to store the native balance corrections")
+		return
+	}
+	l.Info().Msgf("Reconciled the native balances of %d address(es) at height %d, %d correction(s)",
+		checked, lastProcessedHeight, len(adjustments))
+	or.reconciledHeight = lastProcessedHeight
+}
+
+// compareBalances is a private method that compares the indexed balances with the node at the height
+// and appends the differences to the adjustments
+//
+// Parameters:
+//   - balances: the indexed balances of the addresses
+//   - height: the height to query the node at
+//   - adjustments: the differences found so far, the change index continues from them
+//
+// Returns:
+//   - []sqlDataTypes.NativeBalanceChange: the adjustments with the new differences
+func (or *Orchestrator) compareBalances(
+	balances []database.AddressBalance,
+	height uint64,
+	adjustments []sqlDataTypes.NativeBalanceChange,
+) []sqlDataTypes.NativeBalanceChange {
+	for _, balance := range balances {
+		nodeBalances, err := or.queryBankBalances(balance.Address, height)
+		if err != nil {
+			l.Warn().Err(err).Msgf("Failed to query the balance of %s at height %d", balance.Address, height)
+			continue
+		}
+		for _, diff := range balanceDiffs(balance.Balances, nodeBalances) {
+			l.Warn().Msgf("Native balance of %s differs from the node by %s%s at height %d",
+				balance.Address, diff.amount.String(), diff.denom, height)
+			adjustments = append(adjustments, sqlDataTypes.NativeBalanceChange{
+				TxHash:      []byte{},
+				ChainName:   or.chainName,
+				ChangeIndex: int16(len(adjustments)),
+				BlockHeight: height,
+				Address:     balance.AddressId,
+				Denom:       diff.denom,
+				Amount:      pgtype.Numeric{Int: diff.amount, Valid: true},
+				Source:      reconcileSource,
+			})
+		}
+	}
+	return adjustments
+}
+
+// queryBankBalances is a private method that queries the balances of an address from the node at a height
+func (or *Orchestrator) queryBankBalances(address string, height uint64) (map[string]*big.Int, error) {
+	result, err := or.abciQuerier.GetAbciQuery("bank/balances/"+address, "", &height, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseBankBalances(result)
+}
+
+// parseBankBalances reads the coins from the result of the bank/balances abci query
+//
+// The result holds the response base with the data encoded in base64, the data is the
+// amino JSON of the coins, a string like "1000ugnot,10foo".
+//
+// Returns:
+//   - map[string]*big.Int: the balance of every denom
+//   - error: if the result is not a valid bank/balances response
+func parseBankBalances(result any) (map[string]*big.Int, error) {
+	resultMap, _ := result.(map[string]any)
+	response, _ := resultMap["response"].(map[string]any)
+	responseBase, ok := response["ResponseBase"].(map[string]any)
+	if !ok {
+		return nil, errors.New("abci query returned no response")
+	}
+	if queryErr := responseBase["Error"]; queryErr != nil {
+		return nil, fmt.Errorf("abci query failed: %v", queryErr)
+	}
+	data, _ := responseBase["Data"].(string)
+	bz, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the abci query data: %w", err)
+	}
+
+	coinsStr := strings.TrimSpace(string(bz))
+	if strings.HasPrefix(coinsStr, `"`) {
+		if err := json.Unmarshal([]byte(coinsStr), &coinsStr); err != nil {
+			return nil, fmt.Errorf("failed to read the balances %s: %w", coinsStr, err)
+		}
+	}
+	coins, err := std.ParseCoins(coinsStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the balances %s: %w", coinsStr, err)
+	}
+	balances := make(map[string]*big.Int, len(coins))
+	for _, coin := range coins {
+		balances[coin.Denom] = big.NewInt(coin.Amount)
+	}
+	return balances, nil
+}
+
+// denomDiff is the amount the node balance of a denom is above the indexed one
+type denomDiff struct {
+	denom  string
+	amount *big.Int
+}
+
+// balanceDiffs compares the indexed balances with the node balances, a missing denom counts as zero
+//
+// Returns:
+//   - []denomDiff: the denoms that differ sorted by the denom
+func balanceDiffs(indexed map[string]*big.Int, node map[string]*big.Int) []denomDiff {
+	denoms := make([]string, 0, len(indexed)+len(node))
+	for denom := range indexed {
+		denoms = append(denoms, denom)
+	}
+	for denom := range node {
+		if _, ok := indexed[denom]; !ok {
+			denoms = append(denoms, denom)
+		}
+	}
+	slices.Sort(denoms)
+
+	diffs := make([]denomDiff, 0)
+	for _, denom := range denoms {
+		diff := new(big.Int)
+		if amount, ok := node[denom]; ok {
+			diff.Add(diff, amount)
+		}
+		if amount, ok := indexed[denom]; ok {
+			diff.Sub(diff, amount)
+		}
+		if diff.Sign() != 0 {
+			diffs = append(diffs, denomDiff{denom: denom, amount: diff})
+		}
+	}
+	return diffs
+}
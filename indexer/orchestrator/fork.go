@@ -0,0 +1,180 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+)
+
+// defaultMaxRewindDepth is the maximum amount of blocks the orchestrator will look back
+// to find the last block that is the same in the database and on the rpc node,
+// used when max_rewind_depth is not set in the config
+const defaultMaxRewindDepth uint64 = 100
+
+// ForkError is returned when the new blocks do not link to the block stored in the database
+type ForkError struct {
+	// Height of the stored block that is not the parent of the next block
+	Height uint64
+}
+
+// Error implements the error interface
+func (e *ForkError) Error() string {
+	return fmt.Sprintf("chain diverged, stored block at height %d is not the parent of the next block", e.Height)
+}
+
+// checkContinuity is a private method that checks if the blocks are linked by the last block id.
+// Every block in the chunk needs to link to the previous block of the same chunk, and the first
+// block of the chunk needs to link to the block stored in the database (if there is one).
+//
+// Parameters:
+//   - chunkStart: the first height of the chunk
+//   - blocks: the blocks of the chunk
+//
+// Returns:
+//   - *ForkError: if the first block doesn't link to the stored block
+//   - error: if the blocks within the chunk don't link or the stored block can't be checked
+func (or *Orchestrator) checkContinuity(chunkStart uint64, blocks []*rpcClient.BlockResponse) error {
+	byHeight := make(map[uint64]*rpcClient.BlockResponse, len(blocks))
+	for _, block := range blocks {
+		if block == nil {
+			continue
+		}
+		height, err := block.GetHeight()
+		if err != nil {
+			continue
+		}
+		byHeight[height] = block
+	}
+
+	// the chunk itself should be consistent, if it is not the node changed its chain while we were
+	// fetching the blocks so it is enough to fail the chunk and process it again
+	for height, block := range byHeight {
+		parent, ok := byHeight[height-1]
+		if !ok {
+			continue
+		}
+		if block.GetLastBlockHash() != parent.GetBlockHash() {
+			return fmt.Errorf("block %d doesn't link to block %d from the same chunk", height, height-1)
+		}
+	}
+
+	first, ok := byHeight[chunkStart]
+	if !ok || chunkStart <= 1 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stored, err := or.db.GetBlockHashes(ctx, or.chainName, chunkStart-1, chunkStart-1)
+	if err != nil {
+		return fmt.Errorf("failed to get stored block hash at height %d: %w", chunkStart-1, err)
+	}
+	storedHash, ok := stored[chunkStart-1]
+	if !ok {
+		// nothing stored yet, there is nothing to compare to
+		return nil
+	}
+
+	lastBlockHash, err := base64.StdEncoding.DecodeString(first.GetLastBlockHash())
+	if err != nil {
+		return fmt.Errorf("failed to decode last block hash of block %d: %w", chunkStart, err)
+	}
+	if !bytes.Equal(lastBlockHash, storedHash) {
+		return &ForkError{Height: chunkStart - 1}
+	}
+	return nil
+}
+
+// handleFork is a private method that recovers the database from a fork.
+// It searches for the highest block that is the same in the database and on the rpc node,
+// deletes everything above it and then indexes the blocks again up to the fork height.
+//
+// Parameters:
+//   - forkHeight: the height of the stored block that didn't match
+//   - compressEvents: if true, compress the events
+//
+// Returns:
+//   - error: if the common block can't be found or the rewind fails
+func (or *Orchestrator) handleFork(forkHeight uint64, compressEvents bool) error {
+	maxRewindDepth := or.config.MaxRewindDepth
+	if maxRewindDepth == 0 {
+		maxRewindDepth = defaultMaxRewindDepth
+	}
+	fromHeight := uint64(1)
+	if forkHeight > maxRewindDepth {
+		fromHeight = forkHeight - maxRewindDepth + 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	stored, err := or.db.GetBlockHashes(ctx, or.chainName, fromHeight, forkHeight)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to get stored block hashes: %w", err)
+	}
+
+	rpcHashes := make(map[uint64][]byte, forkHeight-fromHeight+1)
+	for _, block := range or.queryOperator.GetFromToBlocks(fromHeight, forkHeight) {
+		if block == nil {
+			continue
+		}
+		height, err := block.GetHeight()
+		if err != nil {
+			continue
+		}
+		hash, err := base64.StdEncoding.DecodeString(block.GetBlockHash())
+		if err != nil {
+			continue
+		}
+		rpcHashes[height] = hash
+	}
+
+	// find the highest common block, heights that are not stored are skipped
+	// but if the rpc node didn't return the block it is not safe to continue
+	var commonHeight uint64
+	found := false
+	for height := forkHeight; height >= fromHeight; height-- {
+		storedHash, ok := stored[height]
+		if ok {
+			rpcHash, ok := rpcHashes[height]
+			if !ok {
+				return fmt.Errorf("failed to get block %d from the rpc to compare it with the stored one", height)
+			}
+			if bytes.Equal(storedHash, rpcHash) {
+				commonHeight = height
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		if fromHeight > 1 {
+			return fmt.Errorf("no common block found within %d blocks below height %d", maxRewindDepth, forkHeight)
+		}
+		// the fork goes all the way to the start of the chain
+		commonHeight = 0
+	}
+
+	l.Warn().Msgf("Fork detected at height %d, rewinding the database to height %d", forkHeight, commonHeight)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if err := or.db.RewindToHeight(ctx, or.chainName, commonHeight); err != nil {
+		return fmt.Errorf("failed to rewind to height %d: %w", commonHeight, err)
+	}
+
+	// index the blocks again up to the fork height, the chunk that detected the fork continues after this
+	for startHeight := commonHeight + 1; startHeight <= forkHeight; {
+		chunkEnd := min(startHeight+or.config.MaxBlockChunkSize-1, forkHeight)
+		if err := or.processChunk(startHeight, chunkEnd, compressEvents); err != nil {
+			return fmt.Errorf("failed to re-index chunk %d-%d: %w", startHeight, chunkEnd, err)
+		}
+		startHeight = chunkEnd + 1
+	}
+
+	l.Info().Msgf("Recovered from fork, re-indexed blocks %d-%d", commonHeight+1, forkHeight)
+	return nil
+}
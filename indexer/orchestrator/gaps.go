@@ -0,0 +1,173 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+)
+
+// gapScanWindow is the amount of heights checked for gaps with a single query,
+// bigger ranges are split so the generate_series doesn't get too large
+const gapScanWindow uint64 = 100_000
+
+// SetGapCheckInterval sets how often the live process checks the recently indexed
+// heights for gaps and backfills them. Zero disables the check.
+//
+// Parameters:
+//   - interval: the interval between the gap checks
+func (or *Orchestrator) SetGapCheckInterval(interval time.Duration) {
+	or.gapCheckInterval = interval
+	or.nextGapCheck = time.Now().Add(interval)
+}
+
+// GapProcess finds the heights missing from the database and indexes them again.
+//
+// If toHeight is 0 the last stored height is used.
+//
+// Parameters:
+//   - fromHeight: the start height (inclusive)
+//   - toHeight: the end height (inclusive)
+//   - compressEvents: if true, compress the events
+//   - dryRun: if true, only report the gaps without indexing them
+func (or *Orchestrator) GapProcess(fromHeight uint64, toHeight uint64, compressEvents bool, dryRun bool) {
+	startTime := time.Now()
+
+	if toHeight == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		lastHeight, err := or.db.GetLastBlockHeight(ctx, or.chainName)
+		cancel()
+		if err != nil {
+			l.Error().Caller().Stack().Err(err).Msg("Failed to get last block height from database")
+			return
+		}
+		toHeight = lastHeight
+	}
+	if fromHeight == 0 {
+		fromHeight = 1
+	}
+	if fromHeight > toHeight {
+		l.Info().Msgf("Nothing to check, from height %d is above to height %d", fromHeight, toHeight)
+		return
+	}
+
+	or.isProcessing = true
+	defer func() {
+		or.isProcessing = false
+	}()
+
+	l.Info().Msgf("Checking for gaps from %d to %d", fromHeight, toHeight)
+	gaps, err := or.findGaps(fromHeight, toHeight)
+	if err != nil {
+		l.Error().Caller().Stack().Err(err).Msg("Failed to find gaps")
+		return
+	}
+	reportGaps(gaps)
+
+	if !dryRun {
+		or.fillGaps(gaps, compressEvents)
+	}
+	l.Info().Msgf("Gap process completed from %d to %d in %v", fromHeight, toHeight, time.Since(startTime))
+}
+
+// findGaps is a private method that finds the missing ranges, the range is scanned in windows
+//
+// Parameters:
+//   - fromHeight: the start height (inclusive)
+//   - toHeight: the end height (inclusive)
+//
+// Returns:
+//   - []database.HeightRange: the missing ranges
+//   - error: if the query fails
+func (or *Orchestrator) findGaps(fromHeight uint64, toHeight uint64) ([]database.HeightRange, error) {
+	gaps := make([]database.HeightRange, 0)
+	for start := fromHeight; start <= toHeight; {
+		end := min(start+gapScanWindow-1, toHeight)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		windowGaps, err := or.db.GetMissingHeightRanges(ctx, or.chainName, start, end)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, gap := range windowGaps {
+			// join the gaps that were split by the window boundary
+			if n := len(gaps); n > 0 && gaps[n-1].ToHeight+1 == gap.FromHeight {
+				gaps[n-1].ToHeight = gap.ToHeight
+				continue
+			}
+			gaps = append(gaps, gap)
+		}
+		start = end + 1
+	}
+	return gaps, nil
+}
+
+// fillGaps is a private method that indexes the missing ranges through the regular chunk pipeline
+//
+// A height is also a gap when the block is stored but its validator signings are not, so the
+// chunks are committed without touching the rows that already exist, whatever the insert mode is.
+//
+// Parameters:
+//   - gaps: the missing ranges
+//   - compressEvents: if true, compress the events
+func (or *Orchestrator) fillGaps(gaps []database.HeightRange, compressEvents bool) {
+	or.skipExisting = true
+	defer func() {
+		or.skipExisting = false
+	}()
+	for _, gap := range gaps {
+		for startHeight := gap.FromHeight; startHeight <= gap.ToHeight; {
+			chunkEnd := min(startHeight+or.config.MaxBlockChunkSize-1, gap.ToHeight)
+			or.currentProcessingHeight = startHeight
+			if err := or.processChunk(startHeight, chunkEnd, compressEvents); err != nil {
+				l.Error().
+					Caller().
+					Stack().
+					Err(err).
+					Msgf("Error backfilling chunk %d-%d", startHeight, chunkEnd)
+			}
+			startHeight = chunkEnd + 1
+		}
+	}
+}
+
+// checkGaps is a private method used by the live process to backfill the gaps within the
+// last scanned window below the last processed height once the gap check interval passes.
+//
+// Parameters:
+//   - lastProcessedHeight: the last height processed by the live process
+//   - compressEvents: if true, compress the events
+func (or *Orchestrator) checkGaps(lastProcessedHeight uint64, compressEvents bool) {
+	if or.gapCheckInterval <= 0 || time.Now().Before(or.nextGapCheck) || lastProcessedHeight == 0 {
+		return
+	}
+	or.nextGapCheck = time.Now().Add(or.gapCheckInterval)
+
+	fromHeight := uint64(1)
+	if lastProcessedHeight > gapScanWindow {
+		fromHeight = lastProcessedHeight - gapScanWindow + 1
+	}
+	gaps, err := or.findGaps(fromHeight, lastProcessedHeight)
+	if err != nil {
+		l.Error().Caller().Stack().Err(err).Msg("Failed to check for gaps")
+		return
+	}
+	if len(gaps) == 0 {
+		return
+	}
+	reportGaps(gaps)
+	or.fillGaps(gaps, compressEvents)
+	or.currentProcessingHeight = lastProcessedHeight
+}
+
+// reportGaps logs the missing ranges and the total amount of missing heights
+func reportGaps(gaps []database.HeightRange) {
+	var missing uint64
+	for _, gap := range gaps {
+		missing += gap.ToHeight - gap.FromHeight + 1
+		l.Warn().Msgf("Missing heights %d-%d", gap.FromHeight, gap.ToHeight)
+	}
+	l.Info().Msgf("Found %d gap(s) with %d missing height(s)", len(gaps), missing)
+}
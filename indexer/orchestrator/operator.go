@@ -5,9 +5,11 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,14 +17,23 @@ import (
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/config"
 	dataprocessor "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/data_processor"
 	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/logger"
 )
 
 const (
 	Live     = "live"
 	Historic = "historic"
+	Gaps     = "gaps"
 )
 
+// the directory the processing state is saved to when the processing stops
+const defaultStateDir = "state_dumps"
+
+// how long the live process keeps polling before it tries to subscribe again
+// after the websocket subscription failed or dropped
+const resubscribeInterval = 30 * time.Second
+
 var l = logger.Get()
 
 func NewOrchestrator(
@@ -34,8 +45,8 @@ func NewOrchestrator(
 	dataProcessor DataProcessor,
 	queryOperator QueryOperator,
 ) *Orchestrator {
-	if runningMode != Live && runningMode != Historic {
-		panic("invalid running mode, please choose between live and historic")
+	if runningMode != Live && runningMode != Historic && runningMode != Gaps {
+		panic("invalid running mode, please choose between live, historic and gaps")
 	}
 	return &Orchestrator{
 		runningMode:             runningMode,
@@ -45,15 +56,45 @@ func NewOrchestrator(
 		gnoRpcClient:            gnoRpcClient,
 		dataProcessor:           dataProcessor,
 		queryOperator:           queryOperator,
+		stateDir:                defaultStateDir,
 		isProcessing:            false,
 		currentProcessingHeight: 0,
 	}
 }
 
+// SetStateDir sets the directory the processing state is saved to when the processing stops
+//
+// Parameters:
+//   - dir: the directory, it is created if it doesn't exist
+func (or *Orchestrator) SetStateDir(dir string) {
+	or.stateDir = dir
+}
+
+// SetBlockSubscriber sets the block subscriber used by the live process.
+// When set the live process will be driven by the new block events instead of polling,
+// polling is still used as a fallback whenever the subscription is not available.
+//
+// Parameters:
+//   - subscriber: the block subscriber
+func (or *Orchestrator) SetBlockSubscriber(subscriber BlockSubscriber) {
+	or.blockSubscriber = subscriber
+}
+
+// HistoricProcess processes the blocks from the given height to the given height in chunks.
+//
+// With resume set the ranges already recorded as processed by a previous historic run are
+// skipped and only the ranges that never completed are fetched again.
+//
+// Parameters:
+//   - fromHeight: the start height (inclusive)
+//   - toHeight: the end height (inclusive)
+//   - compressEvents: if true, compress the events
+//   - resume: if true, skip the ranges that were already processed
 func (or *Orchestrator) HistoricProcess(
 	fromHeight uint64,
 	toHeight uint64,
-	compressEvents bool) {
+	compressEvents bool,
+	resume bool) {
 	l.Info().Msgf("Starting historic process from %d to %d", fromHeight, toHeight)
 	startTime := time.Now()
 
@@ -65,31 +106,44 @@ func (or *Orchestrator) HistoricProcess(
 		l.Info().Msgf("Historic processing completed at height %d", or.currentProcessingHeight)
 	}()
 
-	for startHeight := fromHeight; startHeight <= toHeight; {
-		chunkEndHeight := min(startHeight+or.config.MaxBlockChunkSize-1, toHeight)
+	ranges := []database.HeightRange{{FromHeight: fromHeight, ToHeight: toHeight}}
+	if resume {
+		pending, err := or.pendingRanges(fromHeight, toHeight)
+		if err != nil {
+			l.Error().Err(err).Msg("failed to get the indexer progress, processing the whole range")
+		} else {
+			ranges = pending
+			l.Info().Msgf("Resuming historic process, %d range(s) left to process", len(ranges))
+		}
+	}
+
+	for _, hr := range ranges {
+		for startHeight := hr.FromHeight; startHeight <= hr.ToHeight; {
+			chunkEndHeight := min(startHeight+or.config.MaxBlockChunkSize-1, hr.ToHeight)
 
-		l.Info().Msgf("Processing chunk from %d to %d", startHeight, chunkEndHeight)
+			l.Info().Msgf("Processing chunk from %d to %d", startHeight, chunkEndHeight)
 
-		// Update current processing height
-		or.currentProcessingHeight = startHeight
+			// Update current processing height
+			or.currentProcessingHeight = startHeight
 
-		// Process the chunk
-		err := or.processChunk(startHeight, chunkEndHeight, compressEvents)
-		if err != nil {
-			l.Error().
-				Caller().
-				Stack().
-				Err(err).
-				Msgf(
-					"Error processing chunk %d-%d", startHeight, chunkEndHeight,
-				)
+			// Process the chunk
+			err := or.processChunk(startHeight, chunkEndHeight, compressEvents)
+			if err != nil {
+				l.Error().
+					Caller().
+					Stack().
+					Err(err).
+					Msgf(
+						"Error processing chunk %d-%d", startHeight, chunkEndHeight,
+					)
 
-		}
+			}
 
-		// Always advance to next chunk, regardless of whether blocks were found
-		// Update processing height to end of chunk
-		or.currentProcessingHeight = chunkEndHeight
-		startHeight = chunkEndHeight + 1
+			// Always advance to next chunk, regardless of whether blocks were found
+			// Update processing height to end of chunk
+			or.currentProcessingHeight = chunkEndHeight
+			startHeight = chunkEndHeight + 1
+		}
 	}
 
 	totalDuration := time.Since(startTime)
@@ -138,6 +192,7 @@ func (or *Orchestrator) LiveProcess(ctx context.Context, skipInitialDbCheck bool
 
 	or.currentProcessingHeight = lastProcessedHeight
 	lastProgressTime := time.Now()
+	nextSubscribeAttempt := time.Now()
 
 	// Main processing loop
 	for {
@@ -149,6 +204,16 @@ func (or *Orchestrator) LiveProcess(ctx context.Context, skipInitialDbCheck bool
 		default:
 		}
 
+		or.checkGaps(lastProcessedHeight, compressEvents)
+		or.checkBalances(lastProcessedHeight)
+
+		// Event driven processing, returns once the subscription is gone and then polling takes over
+		if or.blockSubscriber != nil && !time.Now().Before(nextSubscribeAttempt) {
+			lastProcessedHeight = or.subscribedProcess(ctx, lastProcessedHeight, compressEvents, &lastProgressTime)
+			nextSubscribeAttempt = time.Now().Add(resubscribeInterval)
+			continue
+		}
+
 		// Get the latest block height from the chain
 		latestHeight, rpcErr := or.gnoRpcClient.GetLatestBlockHeight()
 		if rpcErr != nil {
@@ -203,6 +268,74 @@ func (or *Orchestrator) LiveProcess(ctx context.Context, skipInitialDbCheck bool
 	}
 }
 
+// subscribedProcess is a private method that processes the new blocks as they are announced
+// by the block subscriber. Every received height is processed in chunks starting right after
+// the last processed height so the missed heights are never skipped.
+//
+// Parameters:
+//   - ctx: the context of the live process
+//   - lastProcessedHeight: the last height that was processed
+//   - compressEvents: if true, compress the events
+//   - lastProgressTime: the time of the last progress log
+//
+// Returns:
+//   - uint64: the last processed height when the subscription ended
+func (or *Orchestrator) subscribedProcess(
+	ctx context.Context,
+	lastProcessedHeight uint64,
+	compressEvents bool,
+	lastProgressTime *time.Time,
+) uint64 {
+	heights, err := or.blockSubscriber.SubscribeNewBlocks(ctx)
+	if err != nil {
+		l.Warn().
+			Err(err).
+			Msgf("Failed to subscribe to new blocks, falling back to polling for %v", resubscribeInterval)
+		return lastProcessedHeight
+	}
+	l.Info().Msg("Subscribed to new blocks, waiting for new block events")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return lastProcessedHeight
+		case latestHeight, ok := <-heights:
+			if !ok {
+				if ctx.Err() == nil {
+					l.Warn().Msgf("New block subscription dropped, falling back to polling for %v", resubscribeInterval)
+				}
+				return lastProcessedHeight
+			}
+
+			for lastProcessedHeight < latestHeight {
+				if ctx.Err() != nil {
+					return lastProcessedHeight
+				}
+				blocksBehind := latestHeight - lastProcessedHeight
+				chunkStart := lastProcessedHeight + 1
+				chunkEnd := min(chunkStart+or.config.MaxBlockChunkSize-1, latestHeight)
+
+				or.currentProcessingHeight = chunkStart
+				if err := or.processChunk(chunkStart, chunkEnd, compressEvents); err != nil {
+					// keep the subscription, the next event will retry from the same height
+					l.Error().
+						Caller().
+						Stack().
+						Err(err).
+						Msgf("Error processing live chunk %d-%d", chunkStart, chunkEnd)
+					break
+				}
+
+				lastProcessedHeight = chunkEnd
+				or.currentProcessingHeight = chunkEnd
+				or.updateProgressMetrics(chunkStart, chunkEnd, blocksBehind, lastProgressTime)
+			}
+			or.checkGaps(lastProcessedHeight, compressEvents)
+			or.checkBalances(lastProcessedHeight)
+		}
+	}
+}
+
 // processChunk processes a single chunk of blocks for live processing
 func (or *Orchestrator) processChunk(chunkStart, chunkEnd uint64, compressEvents bool) error {
 	chunkStartTime := time.Now()
@@ -225,27 +358,87 @@ func (or *Orchestrator) processChunk(chunkStart, chunkEnd uint64, compressEvents
 
 	wg.Wait()
 
-	if len(blocks) == 0 && len(commits) == 0 {
-		l.Info().Msgf("No valid blocks in live chunk %d-%d", chunkStart, chunkEnd)
-		return nil
+	// the progress of the whole chunk is recorded, so a height that is not returned fails the chunk
+	if missing := missingHeights(chunkStart, chunkEnd, blocks, commits); len(missing) > 0 {
+		return fmt.Errorf("chunk %d-%d is missing the blocks or the commits of the heights %v", chunkStart, chunkEnd, missing)
+	}
+
+	// Make sure the blocks link to the stored chain, rewind and re-index if they don't
+	if err := or.checkContinuity(chunkStart, blocks); err != nil {
+		var forkErr *ForkError
+		if !errors.As(err, &forkErr) {
+			return fmt.Errorf("failed continuity check for chunk %d-%d: %w", chunkStart, chunkEnd, err)
+		}
+		if err := or.handleFork(forkErr.Height, compressEvents); err != nil {
+			return fmt.Errorf("failed to recover from fork at height %d: %w", forkErr.Height, err)
+		}
 	}
 
-	// Step 2: Collect all transactions from all blocks in this chunk
-	allTransactions := or.collectTransactionsFromBlocks(blocks)
+	// Step 2: Collect all transactions and block results from all blocks in this chunk
+	allTransactions, blockResults, err := or.collectBlockResults(blocks)
+	if err != nil {
+		return fmt.Errorf("failed to collect the transactions of chunk %d-%d: %w", chunkStart, chunkEnd, err)
+	}
 
 	l.Info().Msgf("Collected %d transactions from %d blocks in live chunk", len(allTransactions), len(blocks))
 
+	// the validator sets are only fetched at the heights where the set changed
+	validatorSets := or.collectValidatorSets(blocks)
+
 	// Step 3: Process all data concurrently
-	if err := or.processAll(blocks, commits, allTransactions, compressEvents, chunkStart, chunkEnd); err != nil {
+	if err := or.processAll(
+		blocks, commits, allTransactions, blockResults, validatorSets, compressEvents, chunkStart, chunkEnd,
+	); err != nil {
 		return fmt.Errorf("failed to process live chunk %d-%d: %w", chunkStart, chunkEnd, err)
 	}
 
+	or.recordProgress(chunkStart, chunkEnd)
+
 	chunkDuration := time.Since(chunkStartTime)
 	l.Info().Msgf("Chunk %d-%d completed in %v", chunkStart, chunkEnd, chunkDuration)
 
 	return nil
 }
 
+// missingHeights returns the heights of the chunk without a block or a commit
+//
+// Parameters:
+//   - chunkStart: the start height of the chunk
+//   - chunkEnd: the end height of the chunk
+//   - blocks: the blocks returned for the chunk
+//   - commits: the commits returned for the chunk
+//
+// Returns:
+//   - []uint64: the missing heights in ascending order
+func missingHeights(
+	chunkStart, chunkEnd uint64,
+	blocks []*rpcClient.BlockResponse,
+	commits []*rpcClient.CommitResponse,
+) []uint64 {
+	blockHeights := make(map[uint64]struct{}, len(blocks))
+	for _, block := range blocks {
+		if height, err := block.GetHeight(); err == nil {
+			blockHeights[height] = struct{}{}
+		}
+	}
+	commitHeights := make(map[uint64]struct{}, len(commits))
+	for _, commit := range commits {
+		if height, err := commit.GetHeight(); err == nil {
+			commitHeights[height] = struct{}{}
+		}
+	}
+
+	missing := make([]uint64, 0)
+	for height := chunkStart; height <= chunkEnd; height++ {
+		_, hasBlock := blockHeights[height]
+		_, hasCommit := commitHeights[height]
+		if !hasBlock || !hasCommit {
+			missing = append(missing, height)
+		}
+	}
+	return missing
+}
+
 // updateProgressMetrics updates and logs progress metrics for live processing
 func (or *Orchestrator) updateProgressMetrics(
 	chunkStart, chunkEnd, blocksBehind uint64,
@@ -264,100 +457,124 @@ func (or *Orchestrator) updateProgressMetrics(
 }
 
 /*
-	collectTransactionsFromBlocks extracts all transactions from blocks and queries them concurrently
+	collectBlockResults fetches the block results of the blocks and reads the transactions from the blocks
+
+The raw transactions come from the block body, the results of all of the transactions of a block
+come from a single block_results call. The same call holds the BeginBlock and EndBlock events and
+the validator updates, so it is made for every block of the chunk.
 
 Parameters:
   - blocks: a slice of blocks
 
 Returns:
   - a slice of transactions
-
-The method will not throw an error if the transactions are not found, it will just return an empty slice.
+  - a slice of block results
+  - error: if a block with transactions has no results or a different amount of them,
+    the chunk needs to be processed again since the block would be stored without its transactions
 */
-func (or *Orchestrator) collectTransactionsFromBlocks(blocks []*rpcClient.BlockResponse) []dataprocessor.TransactionsData {
-	// Collect all transaction hashes from all blocks
-	var allTxHashes []string
-	blockTxData := make([]struct {
-		txHash      string
-		blockHeight uint64
-		timestamp   time.Time
-	}, 0)
-
+func (or *Orchestrator) collectBlockResults(
+	blocks []*rpcClient.BlockResponse,
+) ([]dataprocessor.TransactionsData, []dataprocessor.BlockResultsData, error) {
+	validBlocks := make([]*rpcClient.BlockResponse, 0, len(blocks))
+	heights := make([]uint64, 0, len(blocks))
+	txCount := 0
 	for _, block := range blocks {
 		if block == nil {
 			continue
 		}
+		blockHeight, err := block.GetHeight()
+		if err != nil {
+			l.Error().
+				Caller().
+				Stack().
+				Err(err).
+				Msgf("Failed to get block height")
+			continue
+		}
+		validBlocks = append(validBlocks, block)
+		heights = append(heights, blockHeight)
+		txCount += len(block.GetTxHashes())
+	}
+
+	if len(heights) == 0 {
+		return make([]dataprocessor.TransactionsData, 0), make([]dataprocessor.BlockResultsData, 0), nil
+	}
 
-		// this should not be nil but just in case
-		// also we need to collect all of the tx hashes, decode the base64 to raw bytes then to sha256
-		// and then sha256 to base64
-		txHashes := block.GetTxHashes()
-		if txHashes == nil {
+	l.Info().Msgf("Fetching the block results of %d blocks with %d transactions", len(heights), txCount)
+
+	blockResults := or.queryOperator.GetBlockResults(heights)
+
+	txData := make([]dataprocessor.TransactionsData, 0, txCount)
+	resultsData := make([]dataprocessor.BlockResultsData, 0, len(heights))
+	for idx, block := range validBlocks {
+		// the block results are in the same order as the heights
+		var blockResult *rpcClient.BlockResultsResponse
+		if idx < len(blockResults) && blockResults[idx].IsValid() {
+			blockResult = blockResults[idx]
+			resultsData = append(resultsData, dataprocessor.BlockResultsData{
+				Response:    blockResult,
+				Timestamp:   block.GetTimestamp(),
+				BlockHeight: heights[idx],
+			})
+		} else {
+			l.Error().Msgf("Missing the block results at height %d", heights[idx])
+		}
+
+		txRaws := block.GetTxHashes()
+		if len(txRaws) == 0 {
 			continue
 		}
-		for _, txHash := range txHashes {
-			txHashBytes, err := base64.StdEncoding.DecodeString(txHash)
+		results := blockResult.GetDeliverTxs()
+		if len(results) != len(txRaws) {
+			return nil, nil, fmt.Errorf("block %d has %d transactions but %d results",
+				heights[idx], len(txRaws), len(results))
+		}
+		for txIdx, txRaw := range txRaws {
+			tx, err := txFromBlock(txRaw, heights[idx], txIdx, results[txIdx])
 			if err != nil {
+				l.Error().Err(err).Msg("Failed to read the transaction from the block")
 				continue
 			}
-			txHashSha256 := sha256.Sum256(txHashBytes)
-			txHashFinal := base64.StdEncoding.EncodeToString(txHashSha256[:])
-			blockHeight, err := block.GetHeight()
-			if err != nil {
-				l.Error().
-					Caller().
-					Stack().
-					Err(err).
-					Msgf("Failed to get block height")
-				continue
-			}
-			allTxHashes = append(allTxHashes, txHashFinal)
-			blockTxData = append(blockTxData, struct {
-				txHash      string
-				blockHeight uint64
-				timestamp   time.Time
-			}{
-				txHash:      txHashFinal,
-				blockHeight: blockHeight,
-				timestamp:   block.GetTimestamp(),
+			txData = append(txData, dataprocessor.TransactionsData{
+				Response:    tx,
+				Timestamp:   block.GetTimestamp(),
+				BlockHeight: heights[idx],
 			})
 		}
 	}
 
-	if len(allTxHashes) == 0 {
-		return make([]dataprocessor.TransactionsData, 0)
-	}
+	l.Info().Msgf("Successfully collected %d valid transactions", len(txData))
+	return txData, resultsData, nil
+}
 
-	l.Info().Msgf("Fetching %d transactions concurrently", len(allTxHashes))
-
-	// Query all transactions concurrently
-	transactions := or.queryOperator.GetTransactions(allTxHashes)
-
-	// Build map of transactions with their timestamps
-	txData := make([]dataprocessor.TransactionsData, 0)
-	for _, tx := range transactions {
-		if tx != nil {
-			for _, blockTx := range blockTxData {
-				if blockTx.txHash == tx.GetHash() {
-					txData = append(txData, dataprocessor.TransactionsData{
-						Response:    tx,
-						Timestamp:   blockTx.timestamp,
-						BlockHeight: blockTx.blockHeight,
-					})
-				}
-			}
-		}
+// txFromBlock builds the same response the tx method returns from the raw transaction of the block
+// and its result, the hash is the sha256 of the decoded transaction encoded to base64
+func txFromBlock(txRaw string, height uint64, index int, result rpcClient.TxResult) (*rpcClient.TxResponse, error) {
+	txBytes, err := base64.StdEncoding.DecodeString(txRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %d of block %d: %w", index, height, err)
 	}
-
-	l.Info().Msgf("Successfully collected %d valid transactions", len(txData))
-	return txData
+	txHash := sha256.Sum256(txBytes)
+	return &rpcClient.TxResponse{
+		Jsonrpc: "2.0",
+		Result: rpcClient.TxResultData{
+			Hash:     base64.StdEncoding.EncodeToString(txHash[:]),
+			Height:   strconv.FormatUint(height, 10),
+			Index:    index,
+			TxResult: result,
+			Tx:       txRaw,
+		},
+	}, nil
 }
 
 // This function processes all data using optimized concurrent execution
+// and commits the chunk once everything is processed
 //
 // Parameters:
 //   - blocks: a slice of blocks
 //   - transactions: a map of transactions and timestamps
+//   - blockResults: the block results with the block events and the validator updates
+//   - validatorSets: the validator sets fetched where the set changed
 //   - compressEvents: if true, compress the events
 //   - fromHeight: the start height
 //   - toHeight: the end height
@@ -370,10 +587,15 @@ func (or *Orchestrator) processAll(
 	blocks []*rpcClient.BlockResponse,
 	commits []*rpcClient.CommitResponse,
 	transactions []dataprocessor.TransactionsData,
+	blockResults []dataprocessor.BlockResultsData,
+	validatorSets []dataprocessor.ValidatorSetData,
 	compressEvents bool,
 	fromHeight uint64,
 	toHeight uint64) error {
 
+	// drop anything left from a chunk that failed before it was committed
+	or.dataProcessor.ResetChunk()
+
 	// Phase 1: Independent concurrent operations
 	var wg1 sync.WaitGroup
 	var errors []error
@@ -382,26 +604,23 @@ func (or *Orchestrator) processAll(
 	// Channel to signal when validator addresses are ready
 	validatorAddressesDone := make(chan struct{})
 
-	wg1.Add(3)
+	wg1.Add(2)
 
 	// 1. Process validator addresses (populates validator cache)
+	// the validator sets and the validator updates of the block results solve their addresses
+	// with the same cache so they run right after
 	go func() {
 		defer wg1.Done()
 		defer close(validatorAddressesDone) // Signal completion
 		l.Info().Msg("Phase 1: Starting ProcessValidatorAddresses")
 		or.dataProcessor.ProcessValidatorAddresses(blocks, fromHeight, toHeight)
+		or.dataProcessor.ProcessValidatorSets(validatorSets, fromHeight, toHeight)
+		or.dataProcessor.ProcessBlockResults(blockResults, compressEvents, fromHeight, toHeight)
 		l.Info().Msg("Phase 1: ProcessValidatorAddresses completed")
 	}()
 
-	// 2. Process transactions (no dependencies)
-	go func() {
-		defer wg1.Done()
-		l.Info().Msg("Phase 1: Starting ProcessTransactions")
-		or.dataProcessor.ProcessTransactions(transactions, compressEvents, fromHeight, toHeight)
-		l.Info().Msg("Phase 1: ProcessTransactions completed")
-	}()
-
-	// 3. Process messages (uses separate address cache)
+	// 2. Process messages and transactions (uses separate address cache)
+	// the transactions store the ids of their signers with the same cache so they run right after
 	go func() {
 		defer wg1.Done()
 		l.Info().Msg("Phase 1: Starting ProcessMessages")
@@ -411,6 +630,9 @@ func (or *Orchestrator) processAll(
 			errorsMutex.Unlock()
 		}
 		l.Info().Msg("Phase 1: ProcessMessages completed")
+		l.Info().Msg("Phase 1: Starting ProcessTransactions")
+		or.dataProcessor.ProcessTransactions(transactions, compressEvents, fromHeight, toHeight)
+		l.Info().Msg("Phase 1: ProcessTransactions completed")
 	}()
 
 	// Wait for Phase 1 to complete
@@ -437,7 +659,7 @@ func (or *Orchestrator) processAll(
 	go func() {
 		defer wg2.Done()
 		l.Info().Msg("Phase 2: Starting ProcessBlocks")
-		or.dataProcessor.ProcessBlocks(blocks, fromHeight, toHeight)
+		or.dataProcessor.ProcessBlocks(blocks, transactions, fromHeight, toHeight)
 		l.Info().Msg("Phase 2: ProcessBlocks completed")
 	}()
 
@@ -452,6 +674,11 @@ func (or *Orchestrator) processAll(
 	// Wait for Phase 2 to complete
 	wg2.Wait()
 
+	// Phase 3: write the whole chunk at once, either all of it is stored or nothing
+	if err := or.dataProcessor.CommitChunk(or.skipExisting); err != nil {
+		return fmt.Errorf("failed to commit chunk: %w", err)
+	}
+
 	l.Info().Msgf("All processing completed successfully from %d to %d", fromHeight, toHeight)
 	return nil
 }
@@ -480,7 +707,7 @@ func (or *Orchestrator) saveProcessingState(height uint64, reason string) {
 	}
 
 	// Create state directory if it doesn't exist
-	stateDir := "state_dumps"
+	stateDir := or.stateDir
 	if err := os.MkdirAll(stateDir, 0755); err != nil {
 		l.Error().
 			Caller().
@@ -2,6 +2,10 @@ package orchestrator_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -9,6 +13,8 @@ import (
 	dataprocessor "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/data_processor"
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/orchestrator"
 	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
 )
 
 // Mock implementations for testing orchestration logic
@@ -23,6 +29,14 @@ type MockDataProcessor struct {
 	ProcessMessagesCalled           bool
 	ProcessValidatorSigningsCalled  bool
 	ProcessMessagesError            error
+	CommitChunkError                error
+	ExistingRowsError               error
+	ResetChunkCalls                 int
+	CommitChunkCalls                int
+	SkipExistingCommits             int
+	ValidatorSets                   []dataprocessor.ValidatorSetData
+	BlockResults                    []dataprocessor.BlockResultsData
+	Transactions                    []dataprocessor.TransactionsData
 }
 
 // Mock method for ProcessValidatorAddresses
@@ -31,13 +45,19 @@ func (m *MockDataProcessor) ProcessValidatorAddresses(blocks []*rpcClient.BlockR
 }
 
 // Mock method for ProcessBlocks
-func (m *MockDataProcessor) ProcessBlocks(blocks []*rpcClient.BlockResponse, fromHeight uint64, toHeight uint64) {
+func (m *MockDataProcessor) ProcessBlocks(
+	blocks []*rpcClient.BlockResponse,
+	transactions []dataprocessor.TransactionsData,
+	fromHeight uint64,
+	toHeight uint64,
+) {
 	m.ProcessBlocksCalled = true
 }
 
 // Mock method for ProcessTransactions
 func (m *MockDataProcessor) ProcessTransactions(transactions []dataprocessor.TransactionsData, compressEvents bool, fromHeight uint64, toHeight uint64) {
 	m.ProcessTransactionsCalled = true
+	m.Transactions = append(m.Transactions, transactions...)
 }
 
 // Mock method for ProcessMessages
@@ -51,6 +71,39 @@ func (m *MockDataProcessor) ProcessValidatorSignings(commits []*rpcClient.Commit
 	m.ProcessValidatorSigningsCalled = true
 }
 
+// Mock method for ProcessValidatorSets
+func (m *MockDataProcessor) ProcessValidatorSets(validatorSets []dataprocessor.ValidatorSetData, fromHeight uint64, toHeight uint64) {
+	m.ValidatorSets = append(m.ValidatorSets, validatorSets...)
+}
+
+// Mock method for ProcessBlockResults
+func (m *MockDataProcessor) ProcessBlockResults(
+	blockResults []dataprocessor.BlockResultsData,
+	compressEvents bool,
+	fromHeight uint64,
+	toHeight uint64,
+) {
+	m.BlockResults = append(m.BlockResults, blockResults...)
+}
+
+// Mock method for ResetChunk
+func (m *MockDataProcessor) ResetChunk() {
+	m.ResetChunkCalls++
+}
+
+// Mock method for CommitChunk
+// ExistingRowsError is returned by the commits that don't skip the existing rows,
+// like the copy insert mode does when the chunk has a row that is already stored
+func (m *MockDataProcessor) CommitChunk(skipExisting bool) error {
+	m.CommitChunkCalls++
+	if skipExisting {
+		m.SkipExistingCommits++
+	} else if m.ExistingRowsError != nil {
+		return m.ExistingRowsError
+	}
+	return m.CommitChunkError
+}
+
 // MockQueryOperator - returns minimal data
 // The idea should be to count the number of calls to the method
 // and check if the method is called with the correct parameters
@@ -58,6 +111,8 @@ type MockQueryOperator struct {
 	ShouldReturnBlocks  bool
 	CallCount           int
 	ShouldReturnCommits bool
+	// the heights the validator sets were requested for
+	ValidatorSetHeights []uint64
 }
 
 // Mock method for GetFromToBlocks
@@ -67,8 +122,14 @@ func (m *MockQueryOperator) GetFromToBlocks(fromHeight uint64, toHeight uint64)
 		return []*rpcClient.BlockResponse{} // Return empty slice
 	}
 
-	// Return a single empty block
-	return []*rpcClient.BlockResponse{{}}
+	// Return an empty block for every height
+	blocks := make([]*rpcClient.BlockResponse, 0)
+	for height := fromHeight; height <= toHeight; height++ {
+		block := &rpcClient.BlockResponse{}
+		block.Result.Block.Header.Height = fmt.Sprintf("%d", height)
+		blocks = append(blocks, block)
+	}
+	return blocks
 }
 
 // Mock method for GetFromToCommits
@@ -78,13 +139,34 @@ func (m *MockQueryOperator) GetFromToCommits(fromHeight uint64, toHeight uint64)
 		return []*rpcClient.CommitResponse{} // Return empty slice
 	}
 
-	// Return a single empty commit
-	return []*rpcClient.CommitResponse{{}}
+	return heightCommits(fromHeight, toHeight)
 }
 
-// Mock method for GetTransactions
-func (m *MockQueryOperator) GetTransactions(txs []string) []*rpcClient.TxResponse {
-	return []*rpcClient.TxResponse{} // Empty transactions
+// heightCommits returns an empty commit for every height
+func heightCommits(fromHeight uint64, toHeight uint64) []*rpcClient.CommitResponse {
+	commits := make([]*rpcClient.CommitResponse, 0)
+	for height := fromHeight; height <= toHeight; height++ {
+		commit := &rpcClient.CommitResponse{}
+		commit.Result.SignedHeader.Header.Height = fmt.Sprintf("%d", height)
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// Mock method for GetBlockResults
+func (m *MockQueryOperator) GetBlockResults(heights []uint64) []*rpcClient.BlockResultsResponse {
+	return []*rpcClient.BlockResultsResponse{} // Empty block results
+}
+
+// Mock method for GetValidatorSets
+func (m *MockQueryOperator) GetValidatorSets(heights []uint64) []*rpcClient.ValidatorsResponse {
+	m.ValidatorSetHeights = append(m.ValidatorSetHeights, heights...)
+	validatorSets := make([]*rpcClient.ValidatorsResponse, len(heights))
+	for idx, height := range heights {
+		validatorSets[idx] = &rpcClient.ValidatorsResponse{}
+		validatorSets[idx].Result.BlockHeight = fmt.Sprintf("%d", height)
+	}
+	return validatorSets
 }
 
 // Mock method for GetLatestBlockHeight
@@ -97,6 +179,12 @@ func (m *MockQueryOperator) GetLatestBlockHeight() (uint64, error) {
 type MockDatabaseHeight struct {
 	HeightToReturn uint64
 	ShouldError    bool
+	StoredHashes   map[uint64][]byte
+	RewindCalled   bool
+	RewindHeight   uint64
+	Progress       []sqlDataTypes.IndexerProgress
+	Gaps           []database.HeightRange
+	ValidatorsHash []byte
 }
 
 // Mock method for GetLastBlockHeight
@@ -107,6 +195,74 @@ func (m *MockDatabaseHeight) GetLastBlockHeight(ctx context.Context, chainName s
 	return m.HeightToReturn, nil
 }
 
+// Mock method for GetBlockHashes
+func (m *MockDatabaseHeight) GetBlockHashes(ctx context.Context, chainName string, fromHeight uint64, toHeight uint64) (map[uint64][]byte, error) {
+	hashes := make(map[uint64][]byte)
+	for height, hash := range m.StoredHashes {
+		if height >= fromHeight && height <= toHeight {
+			hashes[height] = hash
+		}
+	}
+	return hashes, nil
+}
+
+// Mock method for GetValidatorsHash
+func (m *MockDatabaseHeight) GetValidatorsHash(ctx context.Context, chainName string, height uint64) ([]byte, error) {
+	return m.ValidatorsHash, nil
+}
+
+// Mock method for RewindToHeight
+func (m *MockDatabaseHeight) RewindToHeight(ctx context.Context, chainName string, height uint64) error {
+	m.RewindCalled = true
+	m.RewindHeight = height
+	for stored := range m.StoredHashes {
+		if stored > height {
+			delete(m.StoredHashes, stored)
+		}
+	}
+	return nil
+}
+
+// Mock method for InsertIndexerProgress
+func (m *MockDatabaseHeight) InsertIndexerProgress(
+	ctx context.Context, chainName string, runningMode string, fromHeight uint64, toHeight uint64,
+) error {
+	m.Progress = append(m.Progress, sqlDataTypes.IndexerProgress{
+		ChainName: chainName, RunningMode: runningMode, FromHeight: fromHeight, ToHeight: toHeight,
+	})
+	return nil
+}
+
+// Mock method for GetIndexerProgress
+func (m *MockDatabaseHeight) GetIndexerProgress(
+	ctx context.Context, chainName string, runningMode string, fromHeight uint64, toHeight uint64,
+) ([]sqlDataTypes.IndexerProgress, error) {
+	if m.ShouldError {
+		return nil, &DatabaseError{"database error"}
+	}
+	return m.Progress, nil
+}
+
+// Mock method for GetMissingHeightRanges
+func (m *MockDatabaseHeight) GetMissingHeightRanges(
+	ctx context.Context, chainName string, fromHeight uint64, toHeight uint64,
+) ([]database.HeightRange, error) {
+	if m.ShouldError {
+		return nil, &DatabaseError{"database error"}
+	}
+	gaps := make([]database.HeightRange, 0)
+	for _, gap := range m.Gaps {
+		if gap.ToHeight < fromHeight || gap.FromHeight > toHeight {
+			continue
+		}
+		gaps = append(gaps, database.HeightRange{
+			FromHeight: max(gap.FromHeight, fromHeight),
+			ToHeight:   min(gap.ToHeight, toHeight),
+		})
+	}
+	return gaps, nil
+}
+
 // MockGnolandRpcClient
 type MockGnolandRpcClient struct {
 	HeightToReturn uint64
@@ -162,7 +318,7 @@ func TestOrchestrator_HistoricProcess_CallsAllProcessors(t *testing.T) {
 	)
 
 	// Test historic processing
-	orch.HistoricProcess(1, 5, false)
+	orch.HistoricProcess(1, 5, false, false)
 
 	// Verify orchestration: all processors should be called
 	if !mockDataProcessor.ProcessValidatorAddressesCalled {
@@ -210,7 +366,7 @@ func TestOrchestrator_HistoricProcess_SkipsProcessingWhenNoBlocks(t *testing.T)
 	)
 
 	// Test historic processing
-	orch.HistoricProcess(1, 5, false)
+	orch.HistoricProcess(1, 5, false, false)
 
 	// Verify query was attempted
 	if mockQueryOperator.CallCount == 0 {
@@ -252,6 +408,8 @@ func TestOrchestrator_LiveProcess_RespectsContext(t *testing.T) {
 	// Start time
 	start := time.Now()
 
+	// the state is saved when the live process stops
+	orch.SetStateDir(t.TempDir())
 	// Test live processing - should return quickly due to context cancellation
 	orch.LiveProcess(ctx, false, false)
 
@@ -290,6 +448,7 @@ func TestOrchestrator_LiveProcess_SkipDbCheck(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
 	defer cancel()
 
+	orch.SetStateDir(t.TempDir())
 	// Test live processing with skip DB check - should not fail even though DB errors
 	orch.LiveProcess(ctx, true, false) // skipInitialDbCheck = true
 
@@ -322,3 +481,858 @@ func TestOrchestrator_Constructor_ValidatesMode(t *testing.T) {
 		mockQueryOperator,
 	)
 }
+
+// MockBlockSubscriber - pushes the given heights and closes the channel
+type MockBlockSubscriber struct {
+	Heights     []uint64
+	ShouldError bool
+	CallCount   int
+}
+
+// Mock method for SubscribeNewBlocks
+func (m *MockBlockSubscriber) SubscribeNewBlocks(ctx context.Context) (<-chan uint64, error) {
+	m.CallCount++
+	if m.ShouldError {
+		return nil, &DatabaseError{"subscription error"}
+	}
+	heights := make(chan uint64, len(m.Heights))
+	for _, height := range m.Heights {
+		heights <- height
+	}
+	close(heights)
+	return heights, nil
+}
+
+// Test orchestrator live mode driven by the block subscriber
+func TestOrchestrator_LiveProcess_WithSubscriber(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockQueryOperator{
+		ShouldReturnBlocks:  true,
+		ShouldReturnCommits: true,
+	}
+	mockDB := &MockDatabaseHeight{HeightToReturn: 0}
+	// polling fallback should have nothing to do
+	mockRPC := &MockGnolandRpcClient{HeightToReturn: 0}
+	mockSubscriber := &MockBlockSubscriber{Heights: []uint64{3}}
+
+	orch := orchestrator.NewOrchestrator(
+		"live",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+	orch.SetBlockSubscriber(mockSubscriber)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	orch.SetStateDir(t.TempDir())
+	orch.LiveProcess(ctx, false, false)
+
+	if mockSubscriber.CallCount != 1 {
+		t.Errorf("expected one subscription attempt, got %d", mockSubscriber.CallCount)
+	}
+	if !mockDataProcessor.ProcessBlocksCalled {
+		t.Error("expected blocks announced by the subscriber to be processed")
+	}
+}
+
+// Test orchestrator live mode falls back to polling when the subscription fails
+func TestOrchestrator_LiveProcess_SubscriberFallback(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockQueryOperator{
+		ShouldReturnBlocks:  true,
+		ShouldReturnCommits: true,
+	}
+	mockDB := &MockDatabaseHeight{HeightToReturn: 0}
+	mockRPC := &MockGnolandRpcClient{HeightToReturn: 3}
+	mockSubscriber := &MockBlockSubscriber{ShouldError: true}
+
+	orch := orchestrator.NewOrchestrator(
+		"live",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+	orch.SetBlockSubscriber(mockSubscriber)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	orch.SetStateDir(t.TempDir())
+	orch.LiveProcess(ctx, false, false)
+
+	if mockSubscriber.CallCount != 1 {
+		t.Errorf("expected one subscription attempt, got %d", mockSubscriber.CallCount)
+	}
+	if !mockDataProcessor.ProcessBlocksCalled {
+		t.Error("expected polling fallback to process the blocks")
+	}
+}
+
+// chainHash returns the base64 hash of the block at the given height on the given branch
+func chainHash(branch string, height uint64) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s-%d", branch, height)))
+}
+
+// MockChainQueryOperator - returns linked blocks, the blocks above ForkAfter are on another branch
+type MockChainQueryOperator struct {
+	MockQueryOperator
+	ForkAfter uint64
+	// if true every block in the chunk points to a random parent
+	Unlinked bool
+	// the requested block ranges
+	Requested [][2]uint64
+}
+
+// Mock method for GetFromToBlocks
+func (m *MockChainQueryOperator) GetFromToBlocks(fromHeight uint64, toHeight uint64) []*rpcClient.BlockResponse {
+	m.CallCount++
+	m.Requested = append(m.Requested, [2]uint64{fromHeight, toHeight})
+	branch := func(height uint64) string {
+		if m.ForkAfter != 0 && height > m.ForkAfter {
+			return "b"
+		}
+		return "a"
+	}
+	blocks := make([]*rpcClient.BlockResponse, 0, toHeight-fromHeight+1)
+	for height := fromHeight; height <= toHeight; height++ {
+		block := &rpcClient.BlockResponse{}
+		block.Result.Block.Header.Height = fmt.Sprintf("%d", height)
+		block.Result.BlockMeta.BlockID.Hash = chainHash(branch(height), height)
+		block.Result.Block.Header.LastBlockID.Hash = chainHash(branch(height-1), height-1)
+		if m.Unlinked {
+			block.Result.Block.Header.LastBlockID.Hash = chainHash("x", height-1)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// Mock method for GetFromToCommits
+func (m *MockChainQueryOperator) GetFromToCommits(fromHeight uint64, toHeight uint64) []*rpcClient.CommitResponse {
+	m.CallCount++
+	return heightCommits(fromHeight, toHeight)
+}
+
+// storedChain returns the stored hashes of the "a" branch up to the given height
+func storedChain(toHeight uint64) map[uint64][]byte {
+	hashes := make(map[uint64][]byte)
+	for height := uint64(1); height <= toHeight; height++ {
+		hashes[height] = []byte(fmt.Sprintf("a-%d", height))
+	}
+	return hashes
+}
+
+// Test orchestrator rewinds the database to the last common block when the chain forks
+func TestOrchestrator_HistoricProcess_RewindsOnFork(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	// the rpc node agrees with the database up to height 3
+	mockQueryOperator := &MockChainQueryOperator{ForkAfter: 3}
+	mockDB := &MockDatabaseHeight{HeightToReturn: 5, StoredHashes: storedChain(5)}
+	mockRPC := &MockGnolandRpcClient{}
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.HistoricProcess(6, 8, false, false)
+
+	if !mockDB.RewindCalled {
+		t.Fatal("expected the database to be rewound")
+	}
+	if mockDB.RewindHeight != 3 {
+		t.Errorf("expected rewind to height 3, got %d", mockDB.RewindHeight)
+	}
+	if !mockDataProcessor.ProcessBlocksCalled {
+		t.Error("expected blocks to be processed after the rewind")
+	}
+}
+
+// Test orchestrator doesn't rewind past the configured max rewind depth
+func TestOrchestrator_HistoricProcess_RespectsMaxRewindDepth(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	// the rpc node agrees with the database up to height 3, 2 blocks below the stored tip
+	mockQueryOperator := &MockChainQueryOperator{ForkAfter: 3}
+	mockDB := &MockDatabaseHeight{HeightToReturn: 5, StoredHashes: storedChain(5)}
+	mockRPC := &MockGnolandRpcClient{}
+	conf := createSimpleTestConfig()
+	conf.MaxRewindDepth = 2
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		conf,
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.HistoricProcess(6, 8, false, false)
+
+	if mockDB.RewindCalled {
+		t.Errorf("expected no rewind beyond 2 blocks, got a rewind to height %d", mockDB.RewindHeight)
+	}
+	if mockDataProcessor.ProcessBlocksCalled {
+		t.Error("expected the chunk to fail without a common block")
+	}
+}
+
+// Test orchestrator doesn't rewind when the blocks link to the stored chain
+func TestOrchestrator_HistoricProcess_NoRewindWhenLinked(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockChainQueryOperator{}
+	mockDB := &MockDatabaseHeight{HeightToReturn: 5, StoredHashes: storedChain(5)}
+	mockRPC := &MockGnolandRpcClient{}
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.HistoricProcess(6, 8, false, false)
+
+	if mockDB.RewindCalled {
+		t.Error("expected no rewind for a continuous chain")
+	}
+	if !mockDataProcessor.ProcessBlocksCalled {
+		t.Error("expected blocks to be processed")
+	}
+}
+
+// Test orchestrator skips the chunk when the blocks within it don't link
+func TestOrchestrator_HistoricProcess_SkipsUnlinkedChunk(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockChainQueryOperator{Unlinked: true}
+	mockDB := &MockDatabaseHeight{}
+	mockRPC := &MockGnolandRpcClient{}
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.HistoricProcess(1, 3, false, false)
+
+	if mockDB.RewindCalled {
+		t.Error("expected no rewind for an inconsistent chunk")
+	}
+	if mockDataProcessor.ProcessBlocksCalled {
+		t.Error("expected the inconsistent chunk to be skipped")
+	}
+}
+
+// Test orchestrator records every processed chunk
+func TestOrchestrator_HistoricProcess_RecordsProgress(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockChainQueryOperator{}
+	mockDB := &MockDatabaseHeight{}
+	mockRPC := &MockGnolandRpcClient{}
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.HistoricProcess(1, 8, false, false)
+
+	expected := [][2]uint64{{1, 5}, {6, 8}}
+	if len(mockDB.Progress) != len(expected) {
+		t.Fatalf("expected %d recorded chunks, got %d", len(expected), len(mockDB.Progress))
+	}
+	for i, p := range mockDB.Progress {
+		if p.FromHeight != expected[i][0] || p.ToHeight != expected[i][1] {
+			t.Errorf("expected chunk %v, got %d-%d", expected[i], p.FromHeight, p.ToHeight)
+		}
+		if p.RunningMode != "historic" {
+			t.Errorf("expected historic running mode, got %s", p.RunningMode)
+		}
+	}
+}
+
+// MockMissingBlockQueryOperator - returns linked blocks, the block at MissingBlockAt is not returned
+type MockMissingBlockQueryOperator struct {
+	MockChainQueryOperator
+	MissingBlockAt uint64
+}
+
+// Mock method for GetFromToBlocks
+func (m *MockMissingBlockQueryOperator) GetFromToBlocks(fromHeight uint64, toHeight uint64) []*rpcClient.BlockResponse {
+	blocks := m.MockChainQueryOperator.GetFromToBlocks(fromHeight, toHeight)
+	for idx, block := range blocks {
+		if height, _ := block.GetHeight(); height == m.MissingBlockAt {
+			blocks[idx] = nil
+		}
+	}
+	return blocks
+}
+
+// Test orchestrator doesn't store or record the chunk with a missing block, so resume processes it again
+func TestOrchestrator_HistoricProcess_SkipsChunkWithMissingBlock(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockMissingBlockQueryOperator{MissingBlockAt: 7}
+	mockDB := &MockDatabaseHeight{}
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		&MockGnolandRpcClient{},
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.HistoricProcess(1, 8, false, false)
+
+	if len(mockDB.Progress) != 1 || mockDB.Progress[0].FromHeight != 1 || mockDB.Progress[0].ToHeight != 5 {
+		t.Fatalf("expected only the chunk 1-5 to be recorded, got %+v", mockDB.Progress)
+	}
+	if mockDataProcessor.CommitChunkCalls != 1 {
+		t.Errorf("expected only the chunk 1-5 to be committed, got %d commits", mockDataProcessor.CommitChunkCalls)
+	}
+}
+
+// Test orchestrator resume only processes the ranges that were not recorded
+func TestOrchestrator_HistoricProcess_Resume(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockChainQueryOperator{}
+	mockDB := &MockDatabaseHeight{
+		Progress: []sqlDataTypes.IndexerProgress{
+			{ChainName: "test-chain", RunningMode: "historic", FromHeight: 1, ToHeight: 5},
+			{ChainName: "test-chain", RunningMode: "historic", FromHeight: 9, ToHeight: 10},
+		},
+	}
+	mockRPC := &MockGnolandRpcClient{}
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.HistoricProcess(1, 12, false, true)
+
+	expected := [][2]uint64{{6, 8}, {11, 12}}
+	if len(mockQueryOperator.Requested) != len(expected) {
+		t.Fatalf("expected %d requested ranges, got %v", len(expected), mockQueryOperator.Requested)
+	}
+	for i, r := range mockQueryOperator.Requested {
+		if r != expected[i] {
+			t.Errorf("expected range %v, got %v", expected[i], r)
+		}
+	}
+}
+
+// Test orchestrator gap process indexes only the missing ranges
+func TestOrchestrator_GapProcess_FillsGaps(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockChainQueryOperator{}
+	mockDB := &MockDatabaseHeight{
+		HeightToReturn: 20,
+		Gaps: []database.HeightRange{
+			{FromHeight: 3, ToHeight: 4},
+			{FromHeight: 10, ToHeight: 16},
+		},
+	}
+	mockRPC := &MockGnolandRpcClient{}
+
+	orch := orchestrator.NewOrchestrator(
+		"gaps",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	// to height 0 should use the last stored height
+	orch.GapProcess(1, 0, false, false)
+
+	expected := [][2]uint64{{3, 4}, {10, 14}, {15, 16}}
+	if len(mockQueryOperator.Requested) != len(expected) {
+		t.Fatalf("expected %d requested ranges, got %v", len(expected), mockQueryOperator.Requested)
+	}
+	for i, r := range mockQueryOperator.Requested {
+		if r != expected[i] {
+			t.Errorf("expected range %v, got %v", expected[i], r)
+		}
+	}
+}
+
+// Test orchestrator backfills a height where the block is stored but the validator signings are not
+func TestOrchestrator_GapProcess_KeepsStoredRows(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{ExistingRowsError: &DatabaseError{"duplicate key"}}
+	mockQueryOperator := &MockChainQueryOperator{}
+	// the block at height 5 is stored, only its signings are missing
+	mockDB := &MockDatabaseHeight{
+		HeightToReturn: 10,
+		StoredHashes:   storedChain(10),
+		Gaps:           []database.HeightRange{{FromHeight: 5, ToHeight: 5}},
+	}
+	mockRPC := &MockGnolandRpcClient{}
+
+	orch := orchestrator.NewOrchestrator(
+		"gaps",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.GapProcess(1, 10, false, false)
+
+	if mockDataProcessor.SkipExistingCommits != 1 {
+		t.Fatalf("expected the gap to be committed once keeping the stored rows, got %d", mockDataProcessor.SkipExistingCommits)
+	}
+	if len(mockDB.Progress) != 1 || mockDB.Progress[0].FromHeight != 5 || mockDB.Progress[0].ToHeight != 5 {
+		t.Errorf("expected the backfilled height to be recorded, got %v", mockDB.Progress)
+	}
+	if mockDB.RewindCalled {
+		t.Error("expected no rewind for a stored block")
+	}
+
+	// the other modes commit with the configured insert mode
+	orch.HistoricProcess(11, 12, false, false)
+	if mockDataProcessor.SkipExistingCommits != 1 {
+		t.Errorf("expected the historic chunk to use the insert mode, got %d skip commits", mockDataProcessor.SkipExistingCommits)
+	}
+}
+
+// Test orchestrator gap process only reports the gaps in dry run
+func TestOrchestrator_GapProcess_DryRun(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockChainQueryOperator{}
+	mockDB := &MockDatabaseHeight{
+		Gaps: []database.HeightRange{{FromHeight: 3, ToHeight: 4}},
+	}
+	mockRPC := &MockGnolandRpcClient{}
+
+	orch := orchestrator.NewOrchestrator(
+		"gaps",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.GapProcess(1, 10, false, true)
+
+	if len(mockQueryOperator.Requested) != 0 {
+		t.Errorf("expected no blocks to be requested in dry run, got %v", mockQueryOperator.Requested)
+	}
+}
+
+// Test orchestrator commits every chunk once and doesn't record a chunk that failed to commit
+func TestOrchestrator_HistoricProcess_CommitsChunks(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockChainQueryOperator{}
+	mockDB := &MockDatabaseHeight{}
+	mockRPC := &MockGnolandRpcClient{}
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+
+	orch.HistoricProcess(1, 8, false, false)
+
+	if mockDataProcessor.CommitChunkCalls != 2 {
+		t.Errorf("expected 2 committed chunks, got %d", mockDataProcessor.CommitChunkCalls)
+	}
+	if mockDataProcessor.ResetChunkCalls != 2 {
+		t.Errorf("expected 2 reset chunks, got %d", mockDataProcessor.ResetChunkCalls)
+	}
+
+	failingProcessor := &MockDataProcessor{CommitChunkError: &DatabaseError{"commit error"}}
+	failingDB := &MockDatabaseHeight{}
+	orch = orchestrator.NewOrchestrator(
+		"historic",
+		createSimpleTestConfig(),
+		"test-chain",
+		failingDB,
+		mockRPC,
+		failingProcessor,
+		&MockChainQueryOperator{},
+	)
+
+	orch.HistoricProcess(1, 8, false, false)
+
+	if len(failingDB.Progress) != 0 {
+		t.Errorf("expected no progress for the chunks that failed to commit, got %v", failingDB.Progress)
+	}
+}
+
+// MockBalanceDatabase returns the indexed balances and records the corrections
+type MockBalanceDatabase struct {
+	Balances     []database.AddressBalance
+	FromHeight   uint64
+	ToHeight     uint64
+	Height       uint64
+	Adjustments  []sqlDataTypes.NativeBalanceChange
+	BalanceCalls int
+}
+
+// Mock method for GetChangedNativeBalances, the balances are paged by the address id
+func (m *MockBalanceDatabase) GetChangedNativeBalances(
+	ctx context.Context, chainName string, fromHeight uint64, toHeight uint64, afterAddressId int32, limit uint64,
+) ([]database.AddressBalance, error) {
+	m.BalanceCalls++
+	m.FromHeight = fromHeight
+	m.ToHeight = toHeight
+	page := make([]database.AddressBalance, 0)
+	for _, balance := range m.Balances {
+		if balance.AddressId > afterAddressId && uint64(len(page)) < limit {
+			page = append(page, balance)
+		}
+	}
+	return page, nil
+}
+
+func (m *MockBalanceDatabase) InsertNativeBalanceAdjustments(
+	ctx context.Context, chainName string, height uint64, adjustments []sqlDataTypes.NativeBalanceChange,
+) error {
+	m.Height = height
+	m.Adjustments = append(m.Adjustments, adjustments...)
+	return nil
+}
+
+// MockAbciQuerier answers the bank/balances query with the given coins
+type MockAbciQuerier struct {
+	Coins string
+	Paths []string
+}
+
+func (m *MockAbciQuerier) GetAbciQuery(path string, data string, height *uint64, prove *bool) (any, error) {
+	m.Paths = append(m.Paths, path)
+	return map[string]any{
+		"response": map[string]any{
+			"ResponseBase": map[string]any{
+				"Error": nil,
+				"Data":  base64.StdEncoding.EncodeToString([]byte(`"` + m.Coins + `"`)),
+			},
+		},
+	}, nil
+}
+
+// Test the live process stores the differences between the indexed and the node balances
+func TestOrchestrator_LiveProcess_ReconcilesBalances(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockQueryOperator{
+		ShouldReturnBlocks:  true,
+		ShouldReturnCommits: true,
+	}
+	mockDB := &MockDatabaseHeight{HeightToReturn: 50}
+	mockRPC := &MockGnolandRpcClient{HeightToReturn: 55}
+	balanceDb := &MockBalanceDatabase{
+		Balances: []database.AddressBalance{{
+			AddressId: 3,
+			Address:   "g1test",
+			Balances:  map[string]*big.Int{"ugnot": big.NewInt(100), "bar": big.NewInt(7)},
+		}},
+	}
+	querier := &MockAbciQuerier{Coins: "150ugnot,5foo"}
+
+	orch := orchestrator.NewOrchestrator(
+		"live",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+	orch.SetBalanceReconciler(balanceDb, querier, time.Nanosecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	orch.SetStateDir(t.TempDir())
+	orch.LiveProcess(ctx, false, false)
+
+	// the heights stored before the live process started are not checked
+	if balanceDb.BalanceCalls != 1 || balanceDb.FromHeight != 50 || balanceDb.ToHeight != 55 {
+		t.Fatalf("expected one check of the heights 50-55, got %d calls from %d to %d",
+			balanceDb.BalanceCalls, balanceDb.FromHeight, balanceDb.ToHeight)
+	}
+	if len(querier.Paths) != 1 || querier.Paths[0] != "bank/balances/g1test" {
+		t.Errorf("unexpected abci queries %v", querier.Paths)
+	}
+	expected := []struct {
+		denom  string
+		amount int64
+	}{{"bar", -7}, {"foo", 5}, {"ugnot", 50}}
+	if len(balanceDb.Adjustments) != len(expected) || balanceDb.Height != 55 {
+		t.Fatalf("expected %d corrections at height 55, got %d at %d", len(expected), len(balanceDb.Adjustments), balanceDb.Height)
+	}
+	for i, want := range expected {
+		adjustment := balanceDb.Adjustments[i]
+		if adjustment.Denom != want.denom || adjustment.Amount.Int.Int64() != want.amount ||
+			adjustment.Address != 3 || adjustment.Source != "reconcile" || adjustment.ChangeIndex != int16(i) {
+			t.Errorf("unexpected correction %d: %+v", i, adjustment)
+		}
+	}
+}
+
+// Test the balance reconciliation compares every changed address, not only the first page
+func TestOrchestrator_LiveProcess_ReconcilesAllChangedBalances(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockQueryOperator{
+		ShouldReturnBlocks:  true,
+		ShouldReturnCommits: true,
+	}
+	mockDB := &MockDatabaseHeight{HeightToReturn: 50}
+	mockRPC := &MockGnolandRpcClient{HeightToReturn: 55}
+	balanceDb := &MockBalanceDatabase{}
+	for id := int32(1); id <= 250; id++ {
+		balanceDb.Balances = append(balanceDb.Balances, database.AddressBalance{
+			AddressId: id,
+			Address:   fmt.Sprintf("g1test%d", id),
+			Balances:  map[string]*big.Int{"ugnot": big.NewInt(100)},
+		})
+	}
+	querier := &MockAbciQuerier{Coins: "101ugnot"}
+
+	orch := orchestrator.NewOrchestrator(
+		"live",
+		createSimpleTestConfig(),
+		"test-chain",
+		mockDB,
+		mockRPC,
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+	orch.SetBalanceReconciler(balanceDb, querier, time.Nanosecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	orch.SetStateDir(t.TempDir())
+	orch.LiveProcess(ctx, false, false)
+
+	// 3 pages of at most 100 addresses
+	if balanceDb.BalanceCalls != 3 {
+		t.Errorf("expected the changed addresses to be read in 3 pages, got %d", balanceDb.BalanceCalls)
+	}
+	if len(querier.Paths) != 250 || len(balanceDb.Adjustments) != 250 {
+		t.Fatalf("expected 250 addresses compared and corrected, got %d and %d",
+			len(querier.Paths), len(balanceDb.Adjustments))
+	}
+	for i, adjustment := range balanceDb.Adjustments {
+		if adjustment.ChangeIndex != int16(i) {
+			t.Fatalf("expected the change index %d, got %d", i, adjustment.ChangeIndex)
+		}
+	}
+}
+
+// MockValidatorsQueryOperator - returns linked blocks signed by set "a" until ChangeAt and by set "b" from it
+type MockValidatorsQueryOperator struct {
+	MockChainQueryOperator
+	ChangeAt uint64
+}
+
+// Mock method for GetFromToBlocks
+func (m *MockValidatorsQueryOperator) GetFromToBlocks(fromHeight uint64, toHeight uint64) []*rpcClient.BlockResponse {
+	blocks := m.MockChainQueryOperator.GetFromToBlocks(fromHeight, toHeight)
+	for _, block := range blocks {
+		height, _ := block.GetHeight()
+		block.Result.Block.Header.ValidatorsHash = base64.StdEncoding.EncodeToString([]byte("set-a"))
+		if height >= m.ChangeAt {
+			block.Result.Block.Header.ValidatorsHash = base64.StdEncoding.EncodeToString([]byte("set-b"))
+		}
+	}
+	return blocks
+}
+
+// Test the validator set is only fetched where the validators hash changed
+func TestOrchestrator_HistoricProcess_FetchesChangedValidatorSets(t *testing.T) {
+	tests := []struct {
+		name           string
+		storedHash     []byte
+		expectedHeight []uint64
+	}{
+		// the first chunk links to the stored set, only the change is fetched
+		{"stored set", []byte("set-a"), []uint64{8}},
+		// nothing stored yet, the set of the first block of every chunk is fetched as well
+		{"no stored set", nil, []uint64{1, 6, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDataProcessor := &MockDataProcessor{}
+			mockQueryOperator := &MockValidatorsQueryOperator{ChangeAt: 8}
+			mockDB := &MockDatabaseHeight{ValidatorsHash: tt.storedHash}
+
+			orch := orchestrator.NewOrchestrator(
+				"historic",
+				createSimpleTestConfig(),
+				"test-chain",
+				mockDB,
+				&MockGnolandRpcClient{},
+				mockDataProcessor,
+				mockQueryOperator,
+			)
+			orch.HistoricProcess(1, 10, false, false)
+
+			if fmt.Sprint(mockQueryOperator.ValidatorSetHeights) != fmt.Sprint(tt.expectedHeight) {
+				t.Fatalf("expected the sets at %v, got %v", tt.expectedHeight, mockQueryOperator.ValidatorSetHeights)
+			}
+			if len(mockDataProcessor.ValidatorSets) != len(tt.expectedHeight) {
+				t.Fatalf("expected %d processed sets, got %d", len(tt.expectedHeight), len(mockDataProcessor.ValidatorSets))
+			}
+			last := mockDataProcessor.ValidatorSets[len(mockDataProcessor.ValidatorSets)-1]
+			if last.BlockHeight != 8 || last.ValidatorsHash != base64.StdEncoding.EncodeToString([]byte("set-b")) {
+				t.Errorf("unexpected validator set %+v", last)
+			}
+		})
+	}
+}
+
+// MockTxsQueryOperator - returns linked blocks with two transactions in every block,
+// the block results of the MissingResultsAt height have only one result
+type MockTxsQueryOperator struct {
+	MockChainQueryOperator
+	MissingResultsAt uint64
+	// the heights the block results were requested for
+	ResultHeights []uint64
+}
+
+// Mock method for GetFromToBlocks
+func (m *MockTxsQueryOperator) GetFromToBlocks(fromHeight uint64, toHeight uint64) []*rpcClient.BlockResponse {
+	blocks := m.MockChainQueryOperator.GetFromToBlocks(fromHeight, toHeight)
+	for _, block := range blocks {
+		height, _ := block.GetHeight()
+		txs := []string{
+			base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("tx-%d-0", height))),
+			base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("tx-%d-1", height))),
+		}
+		block.Result.Block.Data.Txs = &txs
+	}
+	return blocks
+}
+
+// Mock method for GetBlockResults
+func (m *MockTxsQueryOperator) GetBlockResults(heights []uint64) []*rpcClient.BlockResultsResponse {
+	m.ResultHeights = append(m.ResultHeights, heights...)
+	blockResults := make([]*rpcClient.BlockResultsResponse, len(heights))
+	for idx, height := range heights {
+		blockResults[idx] = &rpcClient.BlockResultsResponse{}
+		blockResults[idx].Result.Height = fmt.Sprintf("%d", height)
+		blockResults[idx].Result.Results.DeliverTxs = []rpcClient.TxResult{{GasUsed: "10"}, {GasUsed: "20"}}
+		if height == m.MissingResultsAt {
+			blockResults[idx].Result.Results.DeliverTxs = blockResults[idx].Result.Results.DeliverTxs[:1]
+		}
+	}
+	return blockResults
+}
+
+// Test the transactions are read from the blocks and matched with their block results by the index
+func TestOrchestrator_HistoricProcess_CollectsTransactionsFromBlockResults(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockTxsQueryOperator{}
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		createSimpleTestConfig(),
+		"test-chain",
+		&MockDatabaseHeight{},
+		&MockGnolandRpcClient{},
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+	orch.HistoricProcess(1, 4, false, false)
+
+	if fmt.Sprint(mockQueryOperator.ResultHeights) != fmt.Sprint([]uint64{1, 2, 3, 4}) {
+		t.Fatalf("expected the block results of 1-4, got %v", mockQueryOperator.ResultHeights)
+	}
+	// the block results are passed on for the block events and the validator updates
+	if len(mockDataProcessor.BlockResults) != 4 {
+		t.Fatalf("expected 4 block results, got %d", len(mockDataProcessor.BlockResults))
+	}
+	if len(mockDataProcessor.Transactions) != 8 {
+		t.Fatalf("expected 8 transactions, got %d", len(mockDataProcessor.Transactions))
+	}
+
+	tx := mockDataProcessor.Transactions[1]
+	txHash := sha256.Sum256([]byte("tx-1-1"))
+	if tx.BlockHeight != 1 || tx.Response.GetHash() != base64.StdEncoding.EncodeToString(txHash[:]) {
+		t.Errorf("unexpected transaction hash %s at height %d", tx.Response.GetHash(), tx.BlockHeight)
+	}
+	if tx.Response.Result.Index != 1 || tx.Response.Result.Height != "1" || tx.Response.Result.TxResult.GasUsed != "20" {
+		t.Errorf("unexpected transaction result %+v", tx.Response.Result)
+	}
+}
+
+// Test the chunk with a block that misses the results of its transactions is not stored,
+// so the block is not written without its transactions and the chunk stays pending
+func TestOrchestrator_HistoricProcess_FailsChunkWithMissingResults(t *testing.T) {
+	mockDataProcessor := &MockDataProcessor{}
+	mockQueryOperator := &MockTxsQueryOperator{MissingResultsAt: 3}
+	mockDB := &MockDatabaseHeight{}
+	conf := createSimpleTestConfig()
+	conf.MaxBlockChunkSize = 2
+
+	orch := orchestrator.NewOrchestrator(
+		"historic",
+		conf,
+		"test-chain",
+		mockDB,
+		&MockGnolandRpcClient{},
+		mockDataProcessor,
+		mockQueryOperator,
+	)
+	orch.HistoricProcess(1, 4, false, false)
+
+	// only the chunk 1-2 is processed and committed
+	if len(mockDataProcessor.Transactions) != 4 || mockDataProcessor.CommitChunkCalls != 1 {
+		t.Fatalf("expected only the transactions of the first chunk, got %d transactions and %d commits",
+			len(mockDataProcessor.Transactions), mockDataProcessor.CommitChunkCalls)
+	}
+	for _, tx := range mockDataProcessor.Transactions {
+		if tx.BlockHeight > 2 {
+			t.Fatalf("expected the chunk 3-4 to be skipped, got a transaction at height %d", tx.BlockHeight)
+		}
+	}
+	if len(mockDB.Progress) != 1 || mockDB.Progress[0].FromHeight != 1 || mockDB.Progress[0].ToHeight != 2 {
+		t.Errorf("expected only the chunk 1-2 to be recorded, got %+v", mockDB.Progress)
+	}
+}
@@ -0,0 +1,67 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+)
+
+// recordProgress is a private method that stores the processed chunk in the database.
+// Failing to store the progress doesn't fail the chunk, the worst case is that the chunk
+// will be fetched again on the next resume.
+//
+// Parameters:
+//   - chunkStart: the first height of the chunk
+//   - chunkEnd: the last height of the chunk
+func (or *Orchestrator) recordProgress(chunkStart, chunkEnd uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := or.db.InsertIndexerProgress(ctx, or.chainName, or.runningMode, chunkStart, chunkEnd); err != nil {
+		l.Error().Err(err).Msgf("failed to record progress for chunk %d-%d", chunkStart, chunkEnd)
+	}
+}
+
+// pendingRanges is a private method that returns the ranges within fromHeight and toHeight
+// that are not yet recorded as processed for the current chain and running mode.
+//
+// Parameters:
+//   - fromHeight: the start height (inclusive)
+//   - toHeight: the end height (inclusive)
+//
+// Returns:
+//   - []database.HeightRange: the ranges that still need to be processed
+//   - error: if the progress can't be read from the database
+func (or *Orchestrator) pendingRanges(fromHeight, toHeight uint64) ([]database.HeightRange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	progress, err := or.db.GetIndexerProgress(ctx, or.chainName, or.runningMode, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	return subtractRanges(fromHeight, toHeight, progress), nil
+}
+
+// subtractRanges returns the parts of the fromHeight-toHeight range that are not covered
+// by the completed ranges. The completed ranges need to be ordered by the start height.
+func subtractRanges(fromHeight, toHeight uint64, completed []sqlDataTypes.IndexerProgress) []database.HeightRange {
+	pending := make([]database.HeightRange, 0)
+	next := fromHeight
+	for _, done := range completed {
+		if next > toHeight {
+			break
+		}
+		if done.ToHeight < next {
+			continue
+		}
+		if done.FromHeight > next {
+			pending = append(pending, database.HeightRange{FromHeight: next, ToHeight: min(done.FromHeight-1, toHeight)})
+		}
+		next = done.ToHeight + 1
+	}
+	if next <= toHeight {
+		pending = append(pending, database.HeightRange{FromHeight: next, ToHeight: toHeight})
+	}
+	return pending
+}
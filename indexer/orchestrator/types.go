@@ -7,28 +7,44 @@ import (
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/config"
 	dataprocessor "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/data_processor"
 	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
 )
 
 // Define interfaces where we USE them (consumer-side interfaces)
 type DataProcessor interface {
 	ProcessValidatorAddresses(blocks []*rpcClient.BlockResponse, fromHeight uint64, toHeight uint64)
-	ProcessBlocks(blocks []*rpcClient.BlockResponse, fromHeight uint64, toHeight uint64)
+	ProcessBlocks(blocks []*rpcClient.BlockResponse, transactions []dataprocessor.TransactionsData, fromHeight uint64, toHeight uint64)
 	ProcessTransactions(transactions []dataprocessor.TransactionsData, compressEvents bool, fromHeight uint64, toHeight uint64)
 	ProcessMessages(transactions []dataprocessor.TransactionsData, fromHeight uint64, toHeight uint64) error
 	ProcessValidatorSignings(commits []*rpcClient.CommitResponse, fromHeight uint64, toHeight uint64)
+	ProcessValidatorSets(validatorSets []dataprocessor.ValidatorSetData, fromHeight uint64, toHeight uint64)
+	ProcessBlockResults(blockResults []dataprocessor.BlockResultsData, compressEvents bool, fromHeight uint64, toHeight uint64)
+	ResetChunk()
+	CommitChunk(skipExisting bool) error
 }
 
 type QueryOperator interface {
 	GetFromToBlocks(fromHeight uint64, toHeight uint64) []*rpcClient.BlockResponse
-	GetTransactions(txs []string) []*rpcClient.TxResponse
+	GetBlockResults(heights []uint64) []*rpcClient.BlockResultsResponse
 	GetLatestBlockHeight() (uint64, error)
 	GetFromToCommits(fromHeight uint64, toHeight uint64) []*rpcClient.CommitResponse
+	GetValidatorSets(heights []uint64) []*rpcClient.ValidatorsResponse
 }
 
-// Only needed for one opetaion
 // Part of the timescaledb interface
+// Used to get the last height, to keep the stored chain continuous, to track the progress
+// and to know the last stored validator set
 type DatabaseHeight interface {
 	GetLastBlockHeight(ctx context.Context, chainName string) (uint64, error)
+	GetBlockHashes(ctx context.Context, chainName string, fromHeight uint64, toHeight uint64) (map[uint64][]byte, error)
+	GetValidatorsHash(ctx context.Context, chainName string, height uint64) ([]byte, error)
+	RewindToHeight(ctx context.Context, chainName string, height uint64) error
+	InsertIndexerProgress(ctx context.Context, chainName string, runningMode string, fromHeight uint64, toHeight uint64) error
+	GetIndexerProgress(
+		ctx context.Context, chainName string, runningMode string, fromHeight uint64, toHeight uint64,
+	) ([]sqlDataTypes.IndexerProgress, error)
+	GetMissingHeightRanges(ctx context.Context, chainName string, fromHeight uint64, toHeight uint64) ([]database.HeightRange, error)
 }
 
 // Only needed for one opetaion
@@ -37,6 +53,29 @@ type GnolandRpcClient interface {
 	GetLatestBlockHeight() (uint64, *rpcClient.RpcHeightError)
 }
 
+// Optional, only needed for the live mode with websocket subscription
+// Part of the rpc client interface
+type BlockSubscriber interface {
+	SubscribeNewBlocks(ctx context.Context) (<-chan uint64, error)
+}
+
+// Optional, only needed for the live mode with the balance reconciliation
+// Part of the timescaledb interface
+type BalanceDatabase interface {
+	GetChangedNativeBalances(
+		ctx context.Context, chainName string, fromHeight uint64, toHeight uint64, afterAddressId int32, limit uint64,
+	) ([]database.AddressBalance, error)
+	InsertNativeBalanceAdjustments(
+		ctx context.Context, chainName string, height uint64, adjustments []sqlDataTypes.NativeBalanceChange,
+	) error
+}
+
+// Optional, only needed for the live mode with the balance reconciliation
+// Part of the rpc client interface
+type AbciQuerier interface {
+	GetAbciQuery(path string, data string, height *uint64, prove *bool) (any, error)
+}
+
 // Orchestrator struct to hold the orchestrator
 // holds:
 // - the database height interface
@@ -46,6 +85,10 @@ type GnolandRpcClient interface {
 // - the query operator interface
 // - the running mode
 // - the config
+// - the block subscriber (optional, used in live mode)
+// - the gap check interval (optional, used in live mode)
+// - the balance reconciliation (optional, used in live mode)
+// - if the rows already stored are kept (set while the gaps are backfilled)
 // - processing state tracking
 type Orchestrator struct {
 	db                      DatabaseHeight
@@ -55,6 +98,16 @@ type Orchestrator struct {
 	queryOperator           QueryOperator
 	runningMode             string
 	config                  *config.Config
+	blockSubscriber         BlockSubscriber
+	gapCheckInterval        time.Duration
+	nextGapCheck            time.Time
+	balanceDb               BalanceDatabase
+	abciQuerier             AbciQuerier
+	reconcileInterval       time.Duration
+	nextReconcile           time.Time
+	reconciledHeight        uint64
+	stateDir                string
+	skipExisting            bool
 	isProcessing            bool
 	currentProcessingHeight uint64
 }
@@ -0,0 +1,107 @@
+package orchestrator
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"slices"
+	"time"
+
+	dataprocessor "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/data_processor"
+	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+)
+
+// collectValidatorSets is a private method that fetches the validator sets of the chunk
+//
+// The set is fetched at every height where the validators hash of the block header is different
+// from the previous block. The first block of the chunk is compared with the latest set stored
+// at a lower height, if there is none or it can't be read the set of the first block is fetched.
+//
+// Parameters:
+//   - blocks: the blocks of the chunk
+//
+// Returns:
+//   - []dataprocessor.ValidatorSetData: the fetched validator sets, the sets that failed to be fetched are left out
+func (or *Orchestrator) collectValidatorSets(blocks []*rpcClient.BlockResponse) []dataprocessor.ValidatorSetData {
+	sorted := sortedBlocks(blocks)
+	if len(sorted) == 0 {
+		return nil
+	}
+	firstHeight, _ := sorted[0].GetHeight()
+
+	var previousHash string
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	storedHash, err := or.db.GetValidatorsHash(ctx, or.chainName, firstHeight-1)
+	cancel()
+	if err != nil {
+		l.Warn().Err(err).Msgf("Failed to get the validator set stored below height %d", firstHeight)
+	} else if storedHash != nil {
+		previousHash = base64.StdEncoding.EncodeToString(storedHash)
+	}
+
+	changed := validatorSetChanges(sorted, previousHash)
+	if len(changed) == 0 {
+		return nil
+	}
+	heights := make([]uint64, len(changed))
+	for idx, block := range changed {
+		heights[idx], _ = block.GetHeight()
+	}
+
+	l.Info().Msgf("Validator set changed at %d height(s), fetching the sets", len(heights))
+	responses := or.queryOperator.GetValidatorSets(heights)
+
+	validatorSets := make([]dataprocessor.ValidatorSetData, 0, len(heights))
+	for idx, response := range responses {
+		if !response.IsValid() {
+			l.Error().Msgf("Missing the validator set at height %d", heights[idx])
+			continue
+		}
+		validatorSets = append(validatorSets, dataprocessor.ValidatorSetData{
+			Response:       response,
+			Timestamp:      changed[idx].GetTimestamp(),
+			BlockHeight:    heights[idx],
+			ValidatorsHash: changed[idx].GetValidatorsHash(),
+		})
+	}
+	return validatorSets
+}
+
+// sortedBlocks returns the valid blocks ordered by their height
+func sortedBlocks(blocks []*rpcClient.BlockResponse) []*rpcClient.BlockResponse {
+	sorted := make([]*rpcClient.BlockResponse, 0, len(blocks))
+	for _, block := range blocks {
+		if _, err := block.GetHeight(); err == nil {
+			sorted = append(sorted, block)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b *rpcClient.BlockResponse) int {
+		aHeight, _ := a.GetHeight()
+		bHeight, _ := b.GetHeight()
+		return cmp.Compare(aHeight, bHeight)
+	})
+	return sorted
+}
+
+// validatorSetChanges returns the blocks whose validators hash differs from the block before them
+//
+// Parameters:
+//   - blocks: the blocks ordered by their height
+//   - previousHash: the base64 validators hash before the first block, empty if it is not known
+//
+// Returns:
+//   - []*rpcClient.BlockResponse: the blocks that changed the validator set
+func validatorSetChanges(blocks []*rpcClient.BlockResponse, previousHash string) []*rpcClient.BlockResponse {
+	changed := make([]*rpcClient.BlockResponse, 0)
+	for _, block := range blocks {
+		hash := block.GetValidatorsHash()
+		if hash == "" {
+			continue
+		}
+		if hash != previousHash {
+			changed = append(changed, block)
+		}
+		previousHash = hash
+	}
+	return changed
+}
@@ -0,0 +1,70 @@
+package query
+
+import "sync"
+
+// fetchInBatches splits the keys in batches and requests all of the batches at the same time
+//
+// Parameters:
+//   - keys: the heights or the tx hashes to request
+//   - batchSize: the max amount of keys in one batch
+//   - kind: the kind of the items, used for the logs
+//   - fetch: the batch request of the rpc client
+//
+// Returns:
+//   - []*T: the items in the same order as the keys, nil for every item that is missing
+func fetchInBatches[K any, T any](
+	keys []K,
+	batchSize uint64,
+	kind string,
+	fetch func([]K) ([]*T, error),
+) []*T {
+	results := make([]*T, len(keys))
+	wg := sync.WaitGroup{}
+
+	for start := 0; start < len(keys); start += int(batchSize) {
+		end := min(start+int(batchSize), len(keys))
+		wg.Add(1)
+		go func(start int, end int) {
+			defer wg.Done()
+			items, err := fetch(keys[start:end])
+			if err != nil {
+				l.Warn().Err(err).Msgf("batch request for %d %s failed, requesting them one by one", end-start, kind)
+				return
+			}
+			// every batch writes to its own part of the slice so there is no need for a mutex
+			copy(results[start:end], items)
+		}(start, end)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// missingIndexes returns the indexes of the nil items
+func missingIndexes[T any](items []*T) []int {
+	missing := make([]int, 0)
+	for i, item := range items {
+		if item == nil {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// selectIndexes returns the keys at the given indexes
+func selectIndexes[K any](keys []K, indexes []int) []K {
+	selected := make([]K, len(indexes))
+	for i, idx := range indexes {
+		selected[i] = keys[idx]
+	}
+	return selected
+}
+
+// heightRange returns count heights starting from the given height
+func heightRange(fromHeight uint64, count uint64) []uint64 {
+	heights := make([]uint64, count)
+	for i := range count {
+		heights[i] = fromHeight + i
+	}
+	return heights
+}
@@ -47,11 +47,24 @@ func NewQueryOperator(
 	}
 }
 
+// SetBatchSize enables the JSON-RPC batch requests
+//
+// If the rpc client supports the batch requests the blocks, commits and transactions
+// are requested in batches of the given size instead of one request per item.
+// The items missing from the batches are requested one by one with the retries.
+//
+// Parameters:
+//   - batchSize: the max amount of items in one batch request, 0 disables the batch requests
+func (q *QueryOperator) SetBatchSize(batchSize uint64) {
+	q.batchSize = batchSize
+}
+
 // A swarm method to get blocks from a to b chain height inclusive
 // This is a fan out method that launches async workers for each block and wait to get the results
 // The order of the blocks is not guaranteed but it shouldn't matter because at the end of the process
 // the indexer should store them all together as one huge slice of blocks, so the order is not important
 // the speed is what matters here.
+// If the batch size is set and the rpc client supports it, the blocks are requested in batches instead.
 //
 // Parameters:
 //   - fromHeight: the start height
@@ -86,17 +99,35 @@ func (q *QueryOperator) GetFromToBlocks(fromHeight uint64, toHeight uint64) []*r
 	if diff < 1 {
 		return nil
 	}
+	heights := heightRange(fromHeight, diff)
+
+	batcher, ok := q.rpcClient.(BatchRpcClient)
+	if !ok || q.batchSize == 0 {
+		return q.getBlocks(heights)
+	}
+	// the blocks missing from the batches are requested one by one with the retries
+	blocks := fetchInBatches(heights, q.batchSize, "blocks", batcher.GetBlocks)
+	missing := missingIndexes(blocks)
+	for i, block := range q.getBlocks(selectIndexes(heights, missing)) {
+		blocks[missing[i]] = block
+	}
+	return blocks
+}
+
+// getBlocks launches async workers for each block and waits to get the results
+func (q *QueryOperator) getBlocks(heights []uint64) []*rc.BlockResponse {
+	if len(heights) == 0 {
+		return nil
+	}
 
 	// Preallocate with exact size
-	blocks := make([]*rc.BlockResponse, diff)
+	blocks := make([]*rc.BlockResponse, len(heights))
 	var mu sync.Mutex
 	wg := sync.WaitGroup{}
-	wg.Add(int(diff))
+	wg.Add(len(heights))
 
 	// Launch goroutines to get the blocks
-	for i := range diff {
-		height := fromHeight + i
-		idx := i // Capture index
+	for idx, height := range heights {
 		go func(height uint64, idx int) {
 			block, err := q.rpcClient.GetBlock(height)
 			if err != nil {
@@ -139,7 +170,7 @@ func (q *QueryOperator) GetFromToBlocks(fromHeight uint64, toHeight uint64) []*r
 			blocks[idx] = block
 			mu.Unlock()
 			wg.Done()
-		}(height, int(idx))
+		}(height, idx)
 	}
 
 	wg.Wait()
@@ -151,16 +182,34 @@ func (q *QueryOperator) GetFromToCommits(fromHeight uint64, toHeight uint64) []*
 	if diff < 1 {
 		return nil
 	}
+	heights := heightRange(fromHeight, diff)
+
+	batcher, ok := q.rpcClient.(BatchRpcClient)
+	if !ok || q.batchSize == 0 {
+		return q.getCommits(heights)
+	}
+	// the commits missing from the batches are requested one by one with the retries
+	commits := fetchInBatches(heights, q.batchSize, "commits", batcher.GetCommits)
+	missing := missingIndexes(commits)
+	for i, commit := range q.getCommits(selectIndexes(heights, missing)) {
+		commits[missing[i]] = commit
+	}
+	return commits
+}
 
-	commits := make([]*rc.CommitResponse, diff)
+// getCommits launches async workers for each commit and waits to get the results
+func (q *QueryOperator) getCommits(heights []uint64) []*rc.CommitResponse {
+	if len(heights) == 0 {
+		return nil
+	}
+
+	commits := make([]*rc.CommitResponse, len(heights))
 	var mu sync.Mutex
 	wg := sync.WaitGroup{}
-	wg.Add(int(diff))
+	wg.Add(len(heights))
 
 	// Launch goroutines to get the commits
-	for i := range diff {
-		height := fromHeight + i
-		idx := i // Capture index
+	for idx, height := range heights {
 		go func(height uint64, idx int) {
 			commit, err := q.rpcClient.GetCommit(height)
 			if err != nil {
@@ -203,50 +252,53 @@ func (q *QueryOperator) GetFromToCommits(fromHeight uint64, toHeight uint64) []*
 			commits[idx] = commit
 			mu.Unlock()
 			wg.Done()
-		}(height, int(idx))
+		}(height, idx)
 	}
 
 	wg.Wait()
 	return commits
 }
 
-// A swarm method to get transactions from a slice of tx hashes
-// This is a fan out method that lauches async workers for each tx and wait to get the resaults
-// the indexer should store them all together as one huge slice of transactions,
+// A swarm method to get the results of the blocks at the given heights
+// The results hold every transaction result of the block in the same order as the block txs,
+// together with the block events and the validator updates, so they are requested for every block.
+// If the batch size is set and the rpc client supports it, the results are requested in batches instead.
 //
 // Parameters:
-//   - txs: a slice of tx hashes
+//   - heights: the heights of the blocks
 //
 // Returns:
-//   - []*rpcClient.TxResponse: returns the slice of transaction responses
-//
-// The method will not throw an error if the transaction is missing, not found or there is some query error,
-// it will just return nil for the transaction.
+//   - []*rpcClient.BlockResultsResponse: the block results at the same index as the heights
 //
-// Example:
-//
-//	var transactions []*rpcClient.TxResponse
-//	transactions = q.GetTransactions([]string{"tx_hash_1", "tx_hash_2", "tx_hash_3"})
-//	for _, transaction := range transactions {
-//		fmt.Println(transaction.Hash)
-//	}
-func (q *QueryOperator) GetTransactions(txs []string) []*rc.TxResponse {
-	nTxs := len(txs)
+// The method will not throw an error if the block results can't be fetched, it will just return nil for them.
+func (q *QueryOperator) GetBlockResults(heights []uint64) []*rc.BlockResultsResponse {
+	batcher, ok := q.rpcClient.(BatchRpcClient)
+	if !ok || q.batchSize == 0 || len(heights) == 0 {
+		return q.getBlockResults(heights)
+	}
+	// the results missing from the batches are requested one by one with the retries
+	blockResults := fetchInBatches(heights, q.batchSize, "block results", batcher.GetBlocksResults)
+	missing := missingIndexes(blockResults)
+	for i, result := range q.getBlockResults(selectIndexes(heights, missing)) {
+		blockResults[missing[i]] = result
+	}
+	return blockResults
+}
 
-	if nTxs < 1 {
+// getBlockResults launches async workers for each block results and waits to get the results
+func (q *QueryOperator) getBlockResults(heights []uint64) []*rc.BlockResultsResponse {
+	if len(heights) == 0 {
 		return nil
 	}
 
-	// Preallocate with exact size
-	transactions := make([]*rc.TxResponse, nTxs)
+	blockResults := make([]*rc.BlockResultsResponse, len(heights))
 	var mu sync.Mutex
 	wg := sync.WaitGroup{}
-	wg.Add(nTxs)
+	wg.Add(len(heights))
 
-	// Launch goroutines to get the transactions
-	for idx, tx := range txs {
-		go func(idx int, tx string) {
-			txResponse, err := q.rpcClient.GetTx(tx)
+	for idx, height := range heights {
+		go func(height uint64, idx int) {
+			result, err := q.rpcClient.GetBlockResults(height)
 			if err != nil {
 				// Use retry mechanism with callback pattern
 				retry.RetryWithContext(
@@ -254,17 +306,17 @@ func (q *QueryOperator) GetTransactions(txs []string) []*rc.TxResponse {
 					q.pause,
 					q.pauseTime,
 					q.exponentialBackoff,
-					func(args ...any) (*rc.TxResponse, error) {
-						txHash := args[0].(string)
-						result, rpcErr := q.rpcClient.GetTx(txHash)
+					func(args ...any) (*rc.BlockResultsResponse, error) {
+						h := args[0].(uint64)
+						result, rpcErr := q.rpcClient.GetBlockResults(h)
 						if rpcErr != nil {
 							return nil, rpcErr
 						}
 						return result, nil
 					},
-					func(result *rc.TxResponse) {
+					func(result *rc.BlockResultsResponse) {
 						mu.Lock()
-						transactions[idx] = result
+						blockResults[idx] = result
 						mu.Unlock()
 						wg.Done()
 					},
@@ -273,25 +325,89 @@ func (q *QueryOperator) GetTransactions(txs []string) []*rc.TxResponse {
 							Caller().
 							Stack().
 							Err(retryErr).
-							Msgf("failed to get tx %s after retries", tx)
+							Msgf("failed to get block results %d after retries", height)
+						wg.Done()
+					},
+					height,
+				)
+				return
+			}
+			mu.Lock()
+			blockResults[idx] = result
+			mu.Unlock()
+			wg.Done()
+		}(height, idx)
+	}
+
+	wg.Wait()
+	return blockResults
+}
+
+// A swarm method to get the validator sets at the given heights
+// It launches async workers for each height like the blocks, the sets are only requested for
+// the heights where the validators hash changed so there are usually none or a few of them.
+//
+// Parameters:
+//   - heights: the heights to get the validator sets for
+//
+// Returns:
+//   - []*rpcClient.ValidatorsResponse: the validator sets at the same index as the heights
+//
+// The method will not throw an error if the validator set can't be fetched, it will just return nil for it.
+func (q *QueryOperator) GetValidatorSets(heights []uint64) []*rc.ValidatorsResponse {
+	if len(heights) == 0 {
+		return nil
+	}
+
+	validatorSets := make([]*rc.ValidatorsResponse, len(heights))
+	var mu sync.Mutex
+	wg := sync.WaitGroup{}
+	wg.Add(len(heights))
+
+	for idx, height := range heights {
+		go func(height uint64, idx int) {
+			validators, err := q.rpcClient.GetValidators(height)
+			if err != nil {
+				retry.RetryWithContext(
+					q.retryAmount,
+					q.pause,
+					q.pauseTime,
+					q.exponentialBackoff,
+					func(args ...any) (*rc.ValidatorsResponse, error) {
+						h := args[0].(uint64)
+						result, rpcErr := q.rpcClient.GetValidators(h)
+						if rpcErr != nil {
+							return nil, rpcErr
+						}
+						return result, nil
+					},
+					func(result *rc.ValidatorsResponse) {
 						mu.Lock()
-						transactions[idx] = nil
+						validatorSets[idx] = result
 						mu.Unlock()
 						wg.Done()
 					},
-					tx,
+					func(retryErr error) {
+						l.Error().
+							Caller().
+							Stack().
+							Err(retryErr).
+							Msgf("failed to get validator set %d after retries", height)
+						wg.Done()
+					},
+					height,
 				)
 				return
 			}
 			mu.Lock()
-			transactions[idx] = txResponse
+			validatorSets[idx] = validators
 			mu.Unlock()
 			wg.Done()
-		}(idx, tx)
+		}(height, idx)
 	}
 
 	wg.Wait()
-	return transactions
+	return validatorSets
 }
 
 func (q *QueryOperator) GetLatestBlockHeight() (uint64, error) {
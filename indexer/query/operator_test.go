@@ -1,6 +1,7 @@
 package query_test
 
 import (
+	"strconv"
 	"sync"
 	"testing"
 
@@ -14,11 +15,11 @@ type MockRpcClient struct {
 	mu                         sync.Mutex
 	GetBlockCalled             bool
 	GetLatestBlockHeightCalled bool
-	GetTxCalled                bool
 	GetBlockCallCount          int
-	GetTxCallCount             int
 	GetCommitCalled            bool
 	GetCommitCallCount         int
+	GetValidatorsCallCount     int
+	GetBlockResultsCallCount   int
 }
 
 // Mock method for GetBlock
@@ -38,15 +39,6 @@ func (m *MockRpcClient) GetLatestBlockHeight() (uint64, *rpcClient.RpcHeightErro
 	return 1, nil
 }
 
-// Mock method for GetTx
-func (m *MockRpcClient) GetTx(txHash string) (*rpcClient.TxResponse, *rpcClient.RpcStringError) {
-	m.mu.Lock()
-	m.GetTxCalled = true
-	m.GetTxCallCount++
-	m.mu.Unlock()
-	return &rpcClient.TxResponse{}, nil
-}
-
 // Mock method for GetCommit
 func (m *MockRpcClient) GetCommit(height uint64) (*rpcClient.CommitResponse, *rpcClient.RpcCommitError) {
 	m.mu.Lock()
@@ -56,6 +48,26 @@ func (m *MockRpcClient) GetCommit(height uint64) (*rpcClient.CommitResponse, *rp
 	return &rpcClient.CommitResponse{}, nil
 }
 
+// Mock method for GetValidators
+func (m *MockRpcClient) GetValidators(height uint64) (*rpcClient.ValidatorsResponse, *rpcClient.RpcHeightError) {
+	m.mu.Lock()
+	m.GetValidatorsCallCount++
+	m.mu.Unlock()
+	response := &rpcClient.ValidatorsResponse{}
+	response.Result.BlockHeight = strconv.FormatUint(height, 10)
+	return response, nil
+}
+
+// Mock method for GetBlockResults
+func (m *MockRpcClient) GetBlockResults(height uint64) (*rpcClient.BlockResultsResponse, *rpcClient.RpcHeightError) {
+	m.mu.Lock()
+	m.GetBlockResultsCallCount++
+	m.mu.Unlock()
+	response := &rpcClient.BlockResultsResponse{}
+	response.Result.Height = strconv.FormatUint(height, 10)
+	return response, nil
+}
+
 // TestQueryOperator - tests the query operator
 func TestQueryOperator(t *testing.T) {
 	mockRpcClient := &MockRpcClient{}
@@ -74,15 +86,114 @@ func TestQueryOperator(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, mockRpcClient.GetLatestBlockHeightCalled)
 
-	// Test GetTransactions - should call GetTx for each transaction hash
-	txHashes := []string{"txHash1", "txHash2", "txHash3"}
-	queryOperator.GetTransactions(txHashes)
-	assert.True(t, mockRpcClient.GetTxCalled)
-	assert.Equal(t, len(txHashes), mockRpcClient.GetTxCallCount)
-
 	// Test GetFromToCommits - should call GetCommit multiple times (1 to 10 = 10 calls)
 	queryOperator.GetFromToCommits(1, 10)
 	assert.True(t, mockRpcClient.GetCommitCalled)
 
 	assert.Equal(t, 10, mockRpcClient.GetCommitCallCount)
+
+	// Test GetValidatorSets - should call GetValidators once per height and keep the order
+	validatorSets := queryOperator.GetValidatorSets([]uint64{5, 9})
+	assert.Equal(t, 2, mockRpcClient.GetValidatorsCallCount)
+	assert.Len(t, validatorSets, 2)
+	assert.Equal(t, "5", validatorSets[0].GetBlockHeight())
+	assert.Equal(t, "9", validatorSets[1].GetBlockHeight())
+
+	// Test GetBlockResults - should call GetBlockResults once per height and keep the order
+	blockResults := queryOperator.GetBlockResults([]uint64{3, 4, 8})
+	assert.Equal(t, 3, mockRpcClient.GetBlockResultsCallCount)
+	assert.Len(t, blockResults, 3)
+	height, err := blockResults[2].GetHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), height)
+}
+
+// MockBatchRpcClient - supports the batch requests but never returns the given heights and tx hashes
+type MockBatchRpcClient struct {
+	MockRpcClient
+	MissingHeight   uint64
+	BatchSizes      []int
+	GetBlocksCount  int
+	GetCommitsCount int
+	GetResultsCount int
+	batchMu         sync.Mutex
+}
+
+func (m *MockBatchRpcClient) recordBatch(size int, counter *int) {
+	m.batchMu.Lock()
+	m.BatchSizes = append(m.BatchSizes, size)
+	*counter++
+	m.batchMu.Unlock()
+}
+
+// Mock method for GetBlocks
+func (m *MockBatchRpcClient) GetBlocks(heights []uint64) ([]*rpcClient.BlockResponse, error) {
+	m.recordBatch(len(heights), &m.GetBlocksCount)
+	blocks := make([]*rpcClient.BlockResponse, len(heights))
+	for i, height := range heights {
+		if height != m.MissingHeight {
+			blocks[i] = rpcClient.NewTestBlockResponse(height, "test-chain")
+		}
+	}
+	return blocks, nil
+}
+
+// Mock method for GetCommits
+func (m *MockBatchRpcClient) GetCommits(heights []uint64) ([]*rpcClient.CommitResponse, error) {
+	m.recordBatch(len(heights), &m.GetCommitsCount)
+	commits := make([]*rpcClient.CommitResponse, len(heights))
+	for i := range heights {
+		commits[i] = &rpcClient.CommitResponse{}
+	}
+	return commits, nil
+}
+
+// Mock method for GetBlocksResults
+func (m *MockBatchRpcClient) GetBlocksResults(heights []uint64) ([]*rpcClient.BlockResultsResponse, error) {
+	m.recordBatch(len(heights), &m.GetResultsCount)
+	blockResults := make([]*rpcClient.BlockResultsResponse, len(heights))
+	for i, height := range heights {
+		if height != m.MissingHeight {
+			blockResults[i] = &rpcClient.BlockResultsResponse{}
+			blockResults[i].Result.Height = strconv.FormatUint(height, 10)
+		}
+	}
+	return blockResults, nil
+}
+
+// TestQueryOperator_Batches - tests that the items are requested in batches and the missing ones one by one
+func TestQueryOperator_Batches(t *testing.T) {
+	mockRpcClient := &MockBatchRpcClient{MissingHeight: 7}
+	queryOperator := query.NewQueryOperator(mockRpcClient, nil, nil, nil, nil)
+	queryOperator.SetBatchSize(4)
+
+	// 10 blocks in batches of 4, 4 and 2, the missing block is requested on its own
+	blocks := queryOperator.GetFromToBlocks(1, 10)
+	assert.Len(t, blocks, 10)
+	assert.Equal(t, 3, mockRpcClient.GetBlocksCount)
+	assert.Equal(t, 1, mockRpcClient.GetBlockCallCount)
+	for i, block := range blocks {
+		assert.NotNil(t, block)
+		if i != 6 {
+			height, err := block.GetHeight()
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(i+1), height)
+		}
+	}
+
+	commits := queryOperator.GetFromToCommits(1, 10)
+	assert.Len(t, commits, 10)
+	assert.Equal(t, 3, mockRpcClient.GetCommitsCount)
+	assert.False(t, mockRpcClient.GetCommitCalled)
+
+	// the missing block results are requested one by one as well
+	blockResults := queryOperator.GetBlockResults([]uint64{5, 6, 7})
+	assert.Len(t, blockResults, 3)
+	assert.Equal(t, 1, mockRpcClient.GetResultsCount)
+	assert.Equal(t, 1, mockRpcClient.GetBlockResultsCallCount)
+	for _, result := range blockResults {
+		assert.NotNil(t, result)
+	}
+
+	assert.ElementsMatch(t, []int{4, 4, 2, 4, 4, 2, 3}, mockRpcClient.BatchSizes)
 }
@@ -0,0 +1,506 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	rc "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+)
+
+var (
+	defaultHealthCheckInterval = 30 * time.Second
+	defaultMaxLag              = uint64(10)
+	defaultMaxFailures         = 3
+)
+
+// ParseSelectionStrategy converts the string from the config to the selection strategy
+//
+// Parameters:
+//   - strategy: the selection strategy as a string, empty string is round robin
+//
+// Returns:
+//   - SelectionStrategy: the selection strategy
+//   - error: if the strategy is not valid
+func ParseSelectionStrategy(strategy string) (SelectionStrategy, error) {
+	switch SelectionStrategy(strategy) {
+	case "", RoundRobin:
+		return RoundRobin, nil
+	case LatencyWeighted:
+		return LatencyWeighted, nil
+	default:
+		return "", fmt.Errorf("rpc selection must be %s or %s, got %s", RoundRobin, LatencyWeighted, strategy)
+	}
+}
+
+// NewRpcPool creates a new pool of rpc endpoints
+//
+// The pool implements the RpcClient interface so it can be passed to the query operator
+// like a single rpc client. Every request is sent to one of the healthy endpoints, chosen by the strategy.
+// An endpoint is evicted after maxFailures errors in a row or when it lags more than maxLag blocks
+// behind the highest known height. The evicted endpoints are checked again by the health check
+// and they are returned to the pool once they are healthy and synced.
+//
+// Parameters:
+//   - endpoints: the rpc endpoints, at least one is required
+//   - strategy: the selection strategy, round robin if empty
+//   - maxLag: the max amount of blocks an endpoint can lag behind the highest known height(optional)
+//   - maxFailures: the amount of errors in a row before the endpoint is evicted(optional)
+//
+// Returns:
+//   - *RpcPool: the rpc pool
+//   - error: if there are no endpoints or the strategy is unknown
+func NewRpcPool(
+	endpoints []PoolEndpoint,
+	strategy SelectionStrategy,
+	maxLag uint64,
+	maxFailures int,
+) (*RpcPool, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("at least one rpc endpoint is required")
+	}
+	strategy, err := ParseSelectionStrategy(string(strategy))
+	if err != nil {
+		return nil, err
+	}
+	if maxLag == 0 {
+		maxLag = defaultMaxLag
+	}
+	if maxFailures == 0 {
+		maxFailures = defaultMaxFailures
+	}
+
+	states := make([]*endpointState, len(endpoints))
+	for i, endpoint := range endpoints {
+		states[i] = &endpointState{
+			url:     endpoint.Url,
+			client:  endpoint.Client,
+			healthy: true,
+		}
+	}
+
+	return &RpcPool{
+		endpoints:   states,
+		strategy:    strategy,
+		maxLag:      maxLag,
+		maxFailures: maxFailures,
+		stop:        make(chan struct{}),
+	}, nil
+}
+
+// StartHealthCheck runs the health check of every endpoint in the background
+// The first check is done before the method returns so the pool starts with a known state.
+//
+// Parameters:
+//   - interval: how often to check the endpoints(optional, default is 30 seconds)
+func (p *RpcPool) StartHealthCheck(interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	p.CheckHealth()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.CheckHealth()
+			}
+		}
+	}()
+}
+
+// CheckHealth calls Health and GetLatestBlockHeight on every endpoint
+// The endpoints that fail or lag behind the highest known height are evicted,
+// the rest of them are returned to the pool.
+func (p *RpcPool) CheckHealth() {
+	var wg sync.WaitGroup
+	for _, endpoint := range p.endpoints {
+		wg.Add(1)
+		go func(endpoint *endpointState) {
+			defer wg.Done()
+			start := time.Now()
+			if err := endpoint.client.Health(); err != nil {
+				p.markUnhealthy(endpoint, fmt.Errorf("health check failed: %w", err))
+				return
+			}
+			height, rpcErr := endpoint.client.GetLatestBlockHeight()
+			if rpcErr != nil {
+				p.markUnhealthy(endpoint, fmt.Errorf("failed to get latest block height: %w", rpcErr))
+				return
+			}
+			p.mu.Lock()
+			endpoint.recordLatency(time.Since(start))
+			endpoint.height = height
+			endpoint.failures = 0
+			if height > p.highestHeight {
+				p.highestHeight = height
+			}
+			p.mu.Unlock()
+		}(endpoint)
+	}
+	wg.Wait()
+
+	// the lag can only be checked once every endpoint reported its height
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, endpoint := range p.endpoints {
+		if endpoint.failures > 0 || endpoint.height == 0 {
+			continue
+		}
+		if p.highestHeight-endpoint.height > p.maxLag {
+			if endpoint.healthy {
+				l.Warn().Str("rpc", endpoint.url).
+					Uint64("height", endpoint.height).
+					Uint64("highest_height", p.highestHeight).
+					Msg("rpc endpoint is lagging behind, evicting it from the pool")
+			}
+			endpoint.healthy = false
+			continue
+		}
+		if !endpoint.healthy {
+			l.Info().Str("rpc", endpoint.url).Msg("rpc endpoint is healthy again, returning it to the pool")
+		}
+		endpoint.healthy = true
+	}
+}
+
+// Close stops the health check and closes every endpoint client that can be closed
+func (p *RpcPool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+		for _, endpoint := range p.endpoints {
+			if closer, ok := endpoint.client.(interface{ Close() }); ok {
+				closer.Close()
+			}
+		}
+	})
+}
+
+// pick selects the endpoint for the next request
+// if every endpoint is evicted it picks from all of them, so the retries still have a chance
+func (p *RpcPool) pick() *endpointState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	candidates := make([]*endpointState, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		if endpoint.healthy {
+			candidates = append(candidates, endpoint)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = p.endpoints
+	}
+
+	if p.strategy == LatencyWeighted {
+		return pickByLatency(candidates)
+	}
+	endpoint := candidates[p.next%uint64(len(candidates))]
+	p.next++
+	return endpoint
+}
+
+// pickByLatency selects an endpoint at random with the weight of the inverse latency
+// the endpoints without any measured latency get the weight of the fastest endpoint
+func pickByLatency(candidates []*endpointState) *endpointState {
+	fastest := time.Duration(0)
+	for _, endpoint := range candidates {
+		if endpoint.latency > 0 && (fastest == 0 || endpoint.latency < fastest) {
+			fastest = endpoint.latency
+		}
+	}
+	if fastest == 0 {
+		return candidates[rand.IntN(len(candidates))]
+	}
+
+	weights := make([]float64, len(candidates))
+	total := 0.0
+	for i, endpoint := range candidates {
+		latency := endpoint.latency
+		if latency == 0 {
+			latency = fastest
+		}
+		weights[i] = 1 / latency.Seconds()
+		total += weights[i]
+	}
+	target := rand.Float64() * total
+	for i, weight := range weights {
+		target -= weight
+		if target < 0 {
+			return candidates[i]
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// observe records the outcome of a request sent to the endpoint
+func (p *RpcPool) observe(endpoint *endpointState, start time.Time, err error) {
+	if err != nil {
+		p.evict(endpoint, err)
+		return
+	}
+	p.mu.Lock()
+	endpoint.recordLatency(time.Since(start))
+	endpoint.failures = 0
+	p.mu.Unlock()
+}
+
+// evict counts the error and removes the endpoint from the pool after too many errors in a row
+func (p *RpcPool) evict(endpoint *endpointState, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	endpoint.failures++
+	if endpoint.healthy && endpoint.failures >= p.maxFailures {
+		endpoint.healthy = false
+		l.Warn().Err(err).Str("rpc", endpoint.url).
+			Int("failures", endpoint.failures).
+			Msg("rpc endpoint failed too many times, evicting it from the pool")
+	}
+}
+
+// markUnhealthy evicts the endpoint right away, used when the health check fails
+func (p *RpcPool) markUnhealthy(endpoint *endpointState, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	endpoint.failures = max(endpoint.failures+1, p.maxFailures)
+	if endpoint.healthy {
+		endpoint.healthy = false
+		l.Warn().Err(err).Str("rpc", endpoint.url).Msg("rpc endpoint is not healthy, evicting it from the pool")
+	}
+}
+
+// GetBlock method to get a block from one of the endpoints
+func (p *RpcPool) GetBlock(height uint64) (*rc.BlockResponse, *rc.RpcHeightError) {
+	endpoint := p.pick()
+	start := time.Now()
+	block, err := endpoint.client.GetBlock(height)
+	if err != nil {
+		p.observe(endpoint, start, err)
+		return nil, err
+	}
+	p.observe(endpoint, start, nil)
+	return block, nil
+}
+
+// GetBlockResults method to get the results of a block from one of the endpoints
+func (p *RpcPool) GetBlockResults(height uint64) (*rc.BlockResultsResponse, *rc.RpcHeightError) {
+	endpoint := p.pick()
+	start := time.Now()
+	blockResults, err := endpoint.client.GetBlockResults(height)
+	if err != nil {
+		p.observe(endpoint, start, err)
+		return nil, err
+	}
+	p.observe(endpoint, start, nil)
+	return blockResults, nil
+}
+
+// GetCommit method to get a commit from one of the endpoints
+func (p *RpcPool) GetCommit(height uint64) (*rc.CommitResponse, *rc.RpcCommitError) {
+	endpoint := p.pick()
+	start := time.Now()
+	commit, err := endpoint.client.GetCommit(height)
+	if err != nil {
+		p.observe(endpoint, start, err)
+		return nil, err
+	}
+	p.observe(endpoint, start, nil)
+	return commit, nil
+}
+
+// GetValidators method to get the validator set at a height from one of the endpoints
+func (p *RpcPool) GetValidators(height uint64) (*rc.ValidatorsResponse, *rc.RpcHeightError) {
+	endpoint := p.pick()
+	start := time.Now()
+	validators, err := endpoint.client.GetValidators(height)
+	if err != nil {
+		p.observe(endpoint, start, err)
+		return nil, err
+	}
+	p.observe(endpoint, start, nil)
+	return validators, nil
+}
+
+// GetBlocks method to get multiple blocks with one batch request to one of the endpoints
+func (p *RpcPool) GetBlocks(heights []uint64) ([]*rc.BlockResponse, error) {
+	endpoint := p.pick()
+	batcher, ok := endpoint.client.(BatchRpcClient)
+	if !ok {
+		return nil, fmt.Errorf("rpc endpoint %s doesn't support batch requests", endpoint.url)
+	}
+	start := time.Now()
+	blocks, err := batcher.GetBlocks(heights)
+	p.observe(endpoint, start, err)
+	return blocks, err
+}
+
+// GetBlocksResults method to get the results of multiple blocks with one batch request to one of the endpoints
+func (p *RpcPool) GetBlocksResults(heights []uint64) ([]*rc.BlockResultsResponse, error) {
+	endpoint := p.pick()
+	batcher, ok := endpoint.client.(BatchRpcClient)
+	if !ok {
+		return nil, fmt.Errorf("rpc endpoint %s doesn't support batch requests", endpoint.url)
+	}
+	start := time.Now()
+	blockResults, err := batcher.GetBlocksResults(heights)
+	p.observe(endpoint, start, err)
+	return blockResults, err
+}
+
+// GetCommits method to get multiple commits with one batch request to one of the endpoints
+func (p *RpcPool) GetCommits(heights []uint64) ([]*rc.CommitResponse, error) {
+	endpoint := p.pick()
+	batcher, ok := endpoint.client.(BatchRpcClient)
+	if !ok {
+		return nil, fmt.Errorf("rpc endpoint %s doesn't support batch requests", endpoint.url)
+	}
+	start := time.Now()
+	commits, err := batcher.GetCommits(heights)
+	p.observe(endpoint, start, err)
+	return commits, err
+}
+
+// GetAbciQuery method to query the application state from one of the endpoints
+func (p *RpcPool) GetAbciQuery(path string, data string, height *uint64, prove *bool) (any, error) {
+	endpoint := p.pick()
+	querier, ok := endpoint.client.(AbciRpcClient)
+	if !ok {
+		return nil, fmt.Errorf("rpc endpoint %s doesn't support abci queries", endpoint.url)
+	}
+	start := time.Now()
+	result, err := querier.GetAbciQuery(path, data, height, prove)
+	p.observe(endpoint, start, err)
+	return result, err
+}
+
+// GetLatestBlockHeight method to get the latest block height from one of the endpoints
+//
+// The height is compared to the highest known height, if the endpoint lags behind
+// it is evicted and the next endpoint is asked instead.
+func (p *RpcPool) GetLatestBlockHeight() (uint64, *rc.RpcHeightError) {
+	var lastErr *rc.RpcHeightError
+	for range p.endpoints {
+		endpoint := p.pick()
+		start := time.Now()
+		height, err := endpoint.client.GetLatestBlockHeight()
+		if err != nil {
+			p.observe(endpoint, start, err)
+			lastErr = err
+			continue
+		}
+		p.observe(endpoint, start, nil)
+
+		p.mu.Lock()
+		endpoint.height = height
+		if height > p.highestHeight {
+			p.highestHeight = height
+		}
+		lagging := p.highestHeight-height > p.maxLag
+		if lagging && endpoint.healthy {
+			endpoint.healthy = false
+			l.Warn().Str("rpc", endpoint.url).
+				Uint64("height", height).
+				Uint64("highest_height", p.highestHeight).
+				Msg("rpc endpoint is lagging behind, evicting it from the pool")
+		}
+		p.mu.Unlock()
+
+		if !lagging {
+			return height, nil
+		}
+		lastErr = &rc.RpcHeightError{
+			Height:    height,
+			HasHeight: true,
+			Err:       fmt.Errorf("rpc endpoint %s is lagging behind", endpoint.url),
+		}
+	}
+	return 0, lastErr
+}
+
+// SubscribeNewBlocks subscribes to the new blocks on the first healthy endpoint that supports it
+func (p *RpcPool) SubscribeNewBlocks(ctx context.Context) (<-chan uint64, error) {
+	var lastErr error = errors.New("none of the rpc endpoints support websocket subscriptions")
+	for _, endpoint := range p.healthyFirst() {
+		subscriber, ok := endpoint.client.(interface {
+			SubscribeNewBlocks(ctx context.Context) (<-chan uint64, error)
+		})
+		if !ok {
+			continue
+		}
+		blocks, err := subscriber.SubscribeNewBlocks(ctx)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return blocks, nil
+	}
+	return nil, lastErr
+}
+
+// healthyFirst returns every endpoint, the healthy ones first
+func (p *RpcPool) healthyFirst() []*endpointState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ordered := make([]*endpointState, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		if endpoint.healthy {
+			ordered = append(ordered, endpoint)
+		}
+	}
+	for _, endpoint := range p.endpoints {
+		if !endpoint.healthy {
+			ordered = append(ordered, endpoint)
+		}
+	}
+	return ordered
+}
+
+// GetState returns the state of every endpoint in the pool, used for the state dumps
+func (p *RpcPool) GetState() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	endpoints := make([]map[string]interface{}, len(p.endpoints))
+	for i, endpoint := range p.endpoints {
+		endpoints[i] = map[string]interface{}{
+			"url":      endpoint.url,
+			"healthy":  endpoint.healthy,
+			"height":   endpoint.height,
+			"latency":  endpoint.latency.String(),
+			"failures": endpoint.failures,
+		}
+	}
+	return map[string]interface{}{
+		"strategy":       string(p.strategy),
+		"highest_height": p.highestHeight,
+		"endpoints":      endpoints,
+	}
+}
+
+// Healthy returns the urls of the endpoints that are currently in the pool
+func (p *RpcPool) Healthy() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	urls := make([]string, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		if endpoint.healthy {
+			urls = append(urls, endpoint.url)
+		}
+	}
+	return urls
+}
+
+// recordLatency keeps the moving average of the endpoint latency
+func (e *endpointState) recordLatency(latency time.Duration) {
+	if e.latency == 0 {
+		e.latency = latency
+		return
+	}
+	e.latency = (e.latency*4 + latency) / 5
+}
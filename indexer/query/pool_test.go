@@ -0,0 +1,216 @@
+package query_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/query"
+	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPoolClient - one endpoint of the pool with a configurable height, latency and failures
+type MockPoolClient struct {
+	mu          sync.Mutex
+	Height      uint64
+	Latency     time.Duration
+	Failing     bool
+	Unhealthy   bool
+	Closed      bool
+	CallCount   int
+	HealthCalls int
+}
+
+func (m *MockPoolClient) call() bool {
+	m.mu.Lock()
+	m.CallCount++
+	failing := m.Failing
+	m.mu.Unlock()
+	time.Sleep(m.Latency)
+	return failing
+}
+
+func (m *MockPoolClient) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.CallCount
+}
+
+func (m *MockPoolClient) Health() error {
+	m.mu.Lock()
+	m.HealthCalls++
+	unhealthy := m.Unhealthy
+	m.mu.Unlock()
+	time.Sleep(m.Latency)
+	if unhealthy {
+		return errors.New("node is not healthy")
+	}
+	return nil
+}
+
+func (m *MockPoolClient) GetBlock(height uint64) (*rpcClient.BlockResponse, *rpcClient.RpcHeightError) {
+	if m.call() {
+		return nil, &rpcClient.RpcHeightError{Height: height, HasHeight: true, Err: errors.New("block failed")}
+	}
+	return &rpcClient.BlockResponse{}, nil
+}
+
+func (m *MockPoolClient) GetLatestBlockHeight() (uint64, *rpcClient.RpcHeightError) {
+	if m.call() {
+		return 0, &rpcClient.RpcHeightError{HasHeight: true, Err: errors.New("height failed")}
+	}
+	return m.Height, nil
+}
+
+func (m *MockPoolClient) GetCommit(height uint64) (*rpcClient.CommitResponse, *rpcClient.RpcCommitError) {
+	if m.call() {
+		return nil, &rpcClient.RpcCommitError{Height: height, HasHeight: true, Err: errors.New("commit failed")}
+	}
+	return &rpcClient.CommitResponse{}, nil
+}
+
+func (m *MockPoolClient) GetValidators(height uint64) (*rpcClient.ValidatorsResponse, *rpcClient.RpcHeightError) {
+	if m.call() {
+		return nil, &rpcClient.RpcHeightError{Height: height, HasHeight: true, Err: errors.New("validators failed")}
+	}
+	return &rpcClient.ValidatorsResponse{}, nil
+}
+
+func (m *MockPoolClient) GetBlockResults(height uint64) (*rpcClient.BlockResultsResponse, *rpcClient.RpcHeightError) {
+	if m.call() {
+		return nil, &rpcClient.RpcHeightError{Height: height, HasHeight: true, Err: errors.New("block results failed")}
+	}
+	return &rpcClient.BlockResultsResponse{}, nil
+}
+
+func (m *MockPoolClient) Close() {
+	m.mu.Lock()
+	m.Closed = true
+	m.mu.Unlock()
+}
+
+func newTestPool(t *testing.T, strategy query.SelectionStrategy, clients ...*MockPoolClient) *query.RpcPool {
+	endpoints := make([]query.PoolEndpoint, len(clients))
+	for i, client := range clients {
+		endpoints[i] = query.PoolEndpoint{Url: "http://node" + string(rune('a'+i)), Client: client}
+	}
+	pool, err := query.NewRpcPool(endpoints, strategy, 5, 2)
+	require.NoError(t, err)
+	return pool
+}
+
+// TestRpcPool_RoundRobin - the requests are spread evenly between the healthy endpoints
+func TestRpcPool_RoundRobin(t *testing.T) {
+	a := &MockPoolClient{Height: 100}
+	b := &MockPoolClient{Height: 100}
+	c := &MockPoolClient{Height: 100}
+	pool := newTestPool(t, query.RoundRobin, a, b, c)
+
+	for i := range 30 {
+		_, err := pool.GetBlock(uint64(i + 1))
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, 10, a.Calls())
+	assert.Equal(t, 10, b.Calls())
+	assert.Equal(t, 10, c.Calls())
+}
+
+// TestRpcPool_EvictsFailingEndpoint - after max failures in a row the endpoint doesn't get any requests
+func TestRpcPool_EvictsFailingEndpoint(t *testing.T) {
+	healthy := &MockPoolClient{Height: 100}
+	failing := &MockPoolClient{Height: 100, Failing: true}
+	pool := newTestPool(t, query.RoundRobin, healthy, failing)
+
+	// the failing endpoint gets two requests before it is evicted
+	for i := range 4 {
+		pool.GetBlock(uint64(i + 1))
+	}
+	assert.Equal(t, 2, failing.Calls())
+	assert.Equal(t, []string{"http://nodea"}, pool.Healthy())
+
+	for i := range 10 {
+		_, err := pool.GetCommit(uint64(i + 1))
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 2, failing.Calls())
+
+	// once it recovers the health check returns it to the pool
+	failing.mu.Lock()
+	failing.Failing = false
+	failing.mu.Unlock()
+	pool.CheckHealth()
+	assert.Equal(t, []string{"http://nodea", "http://nodeb"}, pool.Healthy())
+}
+
+// TestRpcPool_EvictsLaggingEndpoint - the endpoints behind the highest known height are evicted
+func TestRpcPool_EvictsLaggingEndpoint(t *testing.T) {
+	synced := &MockPoolClient{Height: 1000}
+	lagging := &MockPoolClient{Height: 900}
+	unhealthy := &MockPoolClient{Height: 1000, Unhealthy: true}
+	pool := newTestPool(t, query.RoundRobin, synced, lagging, unhealthy)
+
+	pool.CheckHealth()
+	assert.Equal(t, []string{"http://nodea"}, pool.Healthy())
+
+	height, err := pool.GetLatestBlockHeight()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1000), height)
+
+	// the lagging endpoint caught up
+	lagging.mu.Lock()
+	lagging.Height = 998
+	lagging.mu.Unlock()
+	pool.CheckHealth()
+	assert.Equal(t, []string{"http://nodea", "http://nodeb"}, pool.Healthy())
+}
+
+// TestRpcPool_LatencyWeighted - the faster endpoint gets most of the requests
+func TestRpcPool_LatencyWeighted(t *testing.T) {
+	fast := &MockPoolClient{Height: 100}
+	slow := &MockPoolClient{Height: 100, Latency: 20 * time.Millisecond}
+	pool := newTestPool(t, query.LatencyWeighted, fast, slow)
+	pool.CheckHealth()
+
+	// the slow endpoint stays slow, so the picks can't change the ratio much
+	slowBefore := slow.Calls()
+	for i := range 200 {
+		_, err := pool.GetCommit(uint64(i + 1))
+		assert.Nil(t, err)
+	}
+	assert.Greater(t, fast.Calls(), (slow.Calls()-slowBefore)*5)
+}
+
+// TestRpcPool_QueryOperator - the pool can be used as the rpc client of the query operator
+func TestRpcPool_QueryOperator(t *testing.T) {
+	a := &MockPoolClient{Height: 100}
+	b := &MockPoolClient{Height: 100}
+	pool := newTestPool(t, query.RoundRobin, a, b)
+	queryOperator := query.NewQueryOperator(pool, nil, nil, nil, nil)
+
+	blocks := queryOperator.GetFromToBlocks(1, 10)
+	assert.Len(t, blocks, 10)
+	for _, block := range blocks {
+		assert.NotNil(t, block)
+	}
+	assert.Equal(t, 5, a.Calls())
+	assert.Equal(t, 5, b.Calls())
+
+	pool.Close()
+	assert.True(t, a.Closed)
+	assert.True(t, b.Closed)
+}
+
+// TestNewRpcPool_Errors - the pool needs at least one endpoint and a known strategy
+func TestNewRpcPool_Errors(t *testing.T) {
+	_, err := query.NewRpcPool(nil, query.RoundRobin, 0, 0)
+	assert.Error(t, err)
+
+	_, err = query.NewRpcPool(
+		[]query.PoolEndpoint{{Url: "http://node", Client: &MockPoolClient{}}}, "random", 0, 0,
+	)
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"sync"
 	"time"
 
 	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
@@ -19,6 +20,7 @@ type QueryOperator struct {
 	pause              int
 	pauseTime          time.Duration
 	exponentialBackoff time.Duration
+	batchSize          uint64
 }
 
 // Rate limiter Gnoland RPC client interface
@@ -28,10 +30,91 @@ type QueryOperator struct {
 // Methods:
 // - GetBlock: to get a block from the rpc client
 // - GetLatestBlockHeight: to get the latest block height from the rpc client
-// - GetTx: to get a tx from the rpc client
+// - GetValidators: to get the validator set at a height from the rpc client
+// - GetBlockResults: to get the results of the transactions of a block from the rpc client
 type RpcClient interface {
 	GetBlock(height uint64) (*rpcClient.BlockResponse, *rpcClient.RpcHeightError)
+	GetBlockResults(height uint64) (*rpcClient.BlockResultsResponse, *rpcClient.RpcHeightError)
 	GetLatestBlockHeight() (uint64, *rpcClient.RpcHeightError)
-	GetTx(txHash string) (*rpcClient.TxResponse, *rpcClient.RpcStringError)
 	GetCommit(height uint64) (*rpcClient.CommitResponse, *rpcClient.RpcCommitError)
+	GetValidators(height uint64) (*rpcClient.ValidatorsResponse, *rpcClient.RpcHeightError)
+}
+
+// Optional rpc client interface for the JSON-RPC batch requests
+// if the rpc client implements it the query operator requests the items in batches
+//
+// Methods:
+// - GetBlocks: to get multiple blocks with one request
+// - GetCommits: to get multiple commits with one request
+// - GetBlocksResults: to get the results of multiple blocks with one request
+type BatchRpcClient interface {
+	GetBlocks(heights []uint64) ([]*rpcClient.BlockResponse, error)
+	GetBlocksResults(heights []uint64) ([]*rpcClient.BlockResultsResponse, error)
+	GetCommits(heights []uint64) ([]*rpcClient.CommitResponse, error)
+}
+
+// Optional rpc client interface for the abci queries
+// if the endpoint implements it the pool can forward the abci queries to it
+//
+// Methods:
+// - GetAbciQuery: to query the application state at a height
+type AbciRpcClient interface {
+	GetAbciQuery(path string, data string, height *uint64, prove *bool) (any, error)
+}
+
+// Rpc client interface for the endpoints of the rpc pool
+//
+// Methods:
+// - every method of the RpcClient interface
+// - Health: to check the health of the endpoint
+type PoolClient interface {
+	RpcClient
+	Health() error
+}
+
+// PoolEndpoint is one rpc endpoint of the pool
+// holds:
+// - the url of the endpoint, used for the logs and the state dumps
+// - the rpc client of the endpoint
+type PoolEndpoint struct {
+	Url    string
+	Client PoolClient
+}
+
+// SelectionStrategy decides which endpoint of the pool gets the next request
+type SelectionStrategy string
+
+const (
+	// RoundRobin sends the requests to every healthy endpoint in turns
+	RoundRobin SelectionStrategy = "round-robin"
+	// LatencyWeighted sends more requests to the endpoints with lower latency
+	LatencyWeighted SelectionStrategy = "latency"
+)
+
+// RpcPool struct to hold the pool of rpc endpoints
+// holds:
+// - the endpoints and their state
+// - the selection strategy
+// - the highest block height reported by any endpoint
+// - the max lag and the max amount of errors in a row before an endpoint is evicted
+type RpcPool struct {
+	mu            sync.Mutex
+	endpoints     []*endpointState
+	strategy      SelectionStrategy
+	next          uint64
+	highestHeight uint64
+	maxLag        uint64
+	maxFailures   int
+	stop          chan struct{}
+	closeOnce     sync.Once
+}
+
+// endpointState holds the health of one endpoint of the pool
+type endpointState struct {
+	url      string
+	client   PoolClient
+	healthy  bool
+	height   uint64
+	latency  time.Duration
+	failures int
 }
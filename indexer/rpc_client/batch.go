@@ -0,0 +1,181 @@
+package rpcclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// batchItem is used to read the id of every response in the batch
+type batchItem struct {
+	ID int `json:"id"`
+}
+
+// performBatchRequest sends all of the requests as one JSON-RPC batch array
+//
+// The id of every request is its index, the node can return the responses in any order
+// so they are matched back by the id. The responses the node didn't return are left untouched.
+//
+// Parameters:
+//   - method: the rpc method for every request in the batch
+//   - params: the params of every request
+//   - results: the value to decode each response to, must have the same length as params
+//
+// Returns:
+//   - error: if the batch request fails as a whole
+func (r *RpcGnoland) performBatchRequest(method string, params []map[string]any, results []any) error {
+	requests := make([]map[string]any, len(params))
+	for i, param := range params {
+		requests[i] = map[string]any{
+			"jsonrpc": "2.0",
+			"id":      i,
+			"method":  method,
+			"params":  param,
+		}
+	}
+	requestBody, err := json.Marshal(requests)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch request: %w", err)
+	}
+
+	resp, err := r.client.Post(r.rpcURL, "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return fmt.Errorf("failed to perform batch request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close response body: %v", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error %s: %s", resp.Status, string(body))
+	}
+
+	// a node without batch support answers with a single object instead of an array
+	var responses []json.RawMessage
+	if err := json.Unmarshal(body, &responses); err != nil {
+		preview := string(body)
+		if len(preview) > 200 {
+			preview = preview[:200] + "..."
+		}
+		return fmt.Errorf("failed to decode batch response: %w; body preview: %q", err, preview)
+	}
+
+	for _, response := range responses {
+		var item batchItem
+		if err := json.Unmarshal(response, &item); err != nil {
+			return fmt.Errorf("failed to decode batch response id: %w", err)
+		}
+		if item.ID < 0 || item.ID >= len(results) {
+			return fmt.Errorf("batch response has unknown id %d", item.ID)
+		}
+		if err := json.Unmarshal(response, results[item.ID]); err != nil {
+			return fmt.Errorf("failed to decode batch response %d: %w", item.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetBlocks method to get multiple blocks with one batch request.
+//
+// Parameters:
+//   - heights: the heights of the blocks to get
+//
+// Returns:
+//   - []*BlockResponse: the blocks in the same order as the heights, nil if the node
+//     returned an error or didn't return the block at all
+//   - error: if the batch request fails
+func (r *RpcGnoland) GetBlocks(heights []uint64) ([]*BlockResponse, error) {
+	params := make([]map[string]any, len(heights))
+	responses := make([]*BlockResponse, len(heights))
+	results := make([]any, len(heights))
+	for i, height := range heights {
+		params[i] = map[string]any{"height": strconv.FormatUint(height, 10)}
+		responses[i] = &BlockResponse{}
+		results[i] = responses[i]
+	}
+	if err := r.performBatchRequest(Block, params, results); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*BlockResponse, len(heights))
+	for i, response := range responses {
+		if response.Error == nil && response.Jsonrpc != "" {
+			blocks[i] = response
+		}
+	}
+	return blocks, nil
+}
+
+// GetBlocksResults method to get the results of multiple blocks with one batch request.
+//
+// Parameters:
+//   - heights: the heights of the blocks to get the results for
+//
+// Returns:
+//   - []*BlockResultsResponse: the block results in the same order as the heights, nil if the node
+//     returned an error or didn't return the results at all
+//   - error: if the batch request fails
+func (r *RpcGnoland) GetBlocksResults(heights []uint64) ([]*BlockResultsResponse, error) {
+	params := make([]map[string]any, len(heights))
+	responses := make([]*BlockResultsResponse, len(heights))
+	results := make([]any, len(heights))
+	for i, height := range heights {
+		params[i] = map[string]any{"height": strconv.FormatUint(height, 10)}
+		responses[i] = &BlockResultsResponse{}
+		results[i] = responses[i]
+	}
+	if err := r.performBatchRequest(BlockResults, params, results); err != nil {
+		return nil, err
+	}
+
+	blockResults := make([]*BlockResultsResponse, len(heights))
+	for i, response := range responses {
+		if response.Error == nil && response.Jsonrpc != "" {
+			blockResults[i] = response
+		}
+	}
+	return blockResults, nil
+}
+
+// GetCommits method to get multiple commits with one batch request.
+//
+// Parameters:
+//   - heights: the heights of the commits to get
+//
+// Returns:
+//   - []*CommitResponse: the commits in the same order as the heights, nil if the node
+//     returned an error or didn't return the commit at all
+//   - error: if the batch request fails
+func (r *RpcGnoland) GetCommits(heights []uint64) ([]*CommitResponse, error) {
+	params := make([]map[string]any, len(heights))
+	responses := make([]*CommitResponse, len(heights))
+	results := make([]any, len(heights))
+	for i, height := range heights {
+		params[i] = map[string]any{"height": strconv.FormatUint(height, 10)}
+		responses[i] = &CommitResponse{}
+		results[i] = responses[i]
+	}
+	if err := r.performBatchRequest(RequestCommit, params, results); err != nil {
+		return nil, err
+	}
+
+	commits := make([]*CommitResponse, len(heights))
+	for i, response := range responses {
+		if response.Error == nil && response.Jsonrpc != "" {
+			commits[i] = response
+		}
+	}
+	return commits, nil
+}
@@ -0,0 +1,99 @@
+package rpcclient
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// BlockResultsResponse is the response from the rpc client for the block_results method
+// it holds the results of every transaction of the block in the same order as the block txs
+type BlockResultsResponse struct {
+	Jsonrpc string             `json:"jsonrpc"`
+	ID      int                `json:"id"`
+	Error   *JsonRpcError      `json:"error,omitempty"`
+	Result  BlockResultsResult `json:"result"`
+}
+
+// BlockResultsResult is the result from the block_results method
+type BlockResultsResult struct {
+	Height  string      `json:"height"`
+	Results ABCIResults `json:"results"`
+}
+
+// ABCIResults is part of the struct for the block results
+// the results of the transactions have the same fields as the tx_result of the tx method
+type ABCIResults struct {
+	DeliverTxs []TxResult         `json:"deliver_tx"` // nil if the block has no transactions
+	EndBlock   ResponseEndBlock   `json:"end_block"`
+	BeginBlock ResponseBeginBlock `json:"begin_block"`
+}
+
+// ResponseBeginBlock is the result of the BeginBlock of the block
+type ResponseBeginBlock struct {
+	ResponseBase ResponseBase `json:"ResponseBase"`
+}
+
+// ResponseEndBlock is the result of the EndBlock of the block
+// the consensus params are left out since the indexer doesn't store them
+type ResponseEndBlock struct {
+	ResponseBase     ResponseBase      `json:"ResponseBase"`
+	ValidatorUpdates []ValidatorUpdate `json:"ValidatorUpdates"`
+	Events           []Event           `json:"Events"`
+}
+
+// ValidatorUpdate is a change of the validator set made in the EndBlock,
+// zero power removes the validator from the set
+type ValidatorUpdate struct {
+	Address string    `json:"Address"`
+	PubKey  ValPubKey `json:"PubKey"`
+	Power   string    `json:"Power"`
+}
+
+// GetHeight returns the height of the block results
+func (br *BlockResultsResponse) GetHeight() (uint64, error) {
+	if br == nil {
+		return 0, fmt.Errorf("BlockResultsResponse is nil")
+	}
+	return strconv.ParseUint(br.Result.Height, 10, 64)
+}
+
+// GetDeliverTxs returns the results of the transactions of the block
+func (br *BlockResultsResponse) GetDeliverTxs() []TxResult {
+	if br == nil {
+		return nil
+	}
+	return br.Result.Results.DeliverTxs
+}
+
+// IsValid returns true if the response holds the block results
+func (br *BlockResultsResponse) IsValid() bool {
+	return br != nil && br.Error == nil
+}
+
+// GetBeginBlockEvents returns the events emitted in the BeginBlock of the block
+func (br *BlockResultsResponse) GetBeginBlockEvents() []Event {
+	if br == nil {
+		return nil
+	}
+	return br.Result.Results.BeginBlock.ResponseBase.Events
+}
+
+// GetEndBlockEvents returns the events emitted in the EndBlock of the block,
+// the EndBlock can hold them in the response base and in its own events field
+func (br *BlockResultsResponse) GetEndBlockEvents() []Event {
+	if br == nil {
+		return nil
+	}
+	endBlock := br.Result.Results.EndBlock
+	events := make([]Event, 0, len(endBlock.ResponseBase.Events)+len(endBlock.Events))
+	events = append(events, endBlock.ResponseBase.Events...)
+	return append(events, endBlock.Events...)
+}
+
+// GetValidatorUpdates returns the validator set changes made in the EndBlock of the block
+func (br *BlockResultsResponse) GetValidatorUpdates() []ValidatorUpdate {
+	if br == nil {
+		return nil
+	}
+	return br.Result.Results.EndBlock.ValidatorUpdates
+}
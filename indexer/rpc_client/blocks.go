@@ -126,6 +126,22 @@ func (br *BlockResponse) GetBlockHash() string {
 	return br.Result.BlockMeta.BlockID.Hash
 }
 
+// GetLastBlockHash returns the hash of the previous block this block links to
+func (br *BlockResponse) GetLastBlockHash() string {
+	if br == nil {
+		return ""
+	}
+	return br.Result.Block.Header.LastBlockID.Hash
+}
+
+// GetValidatorsHash returns the hash of the validator set that signs this block
+func (br *BlockResponse) GetValidatorsHash() string {
+	if br == nil {
+		return ""
+	}
+	return br.Result.Block.Header.ValidatorsHash
+}
+
 func (br *BlockResponse) GetTxHashes() []string {
 	if br == nil || br.Result.Block.Data.Txs == nil {
 		return nil
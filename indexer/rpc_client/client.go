@@ -72,6 +72,7 @@ const (
 	Health        = "health"
 	Tx            = "tx"
 	RequestCommit = "commit"
+	BlockResults  = "block_results"
 )
 
 func (r *RpcGnoland) performRequest(method string, params map[string]any, result interface{}) error {
@@ -193,6 +194,37 @@ func (r *RpcGnoland) GetBlock(height uint64) (*BlockResponse, *RpcHeightError) {
 	return response, nil
 }
 
+// GetBlockResults method to get the results of the transactions of a block from the rpc client.
+//
+// Parameters:
+//   - height: the height of the block to get the results for
+//
+// Returns:
+//   - *BlockResultsResponse: the response from the rpc client
+//   - error: if the call fails
+func (r *RpcGnoland) GetBlockResults(height uint64) (*BlockResultsResponse, *RpcHeightError) {
+	response := &BlockResultsResponse{}
+	// convert the height to a string because the rpc client expects a string
+	params := map[string]any{
+		"height": strconv.FormatUint(height, 10),
+	}
+	if err := r.performRequest(BlockResults, params, response); err != nil {
+		return nil, &RpcHeightError{
+			Height:    height,
+			HasHeight: true,
+			Err:       err,
+		}
+	}
+	if response.Error != nil {
+		return nil, &RpcHeightError{
+			Height:    height,
+			HasHeight: true,
+			Err:       fmt.Errorf("rpc error: %v, %s", response.Error.Code, response.Error.Message),
+		}
+	}
+	return response, nil
+}
+
 // This is method similar to GetBlock but it doesn't require a height
 // Whole purpose of this method is to get the latest block height from the rpc client
 // without having to query the block itself
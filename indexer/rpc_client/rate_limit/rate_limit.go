@@ -29,6 +29,7 @@ type ChannelRateLimiter struct {
 //
 //	limiter.Allow() // returns true if the request is allowed
 //	limiter.Wait() // blocks until the request is allowed
+//	limiter.WaitN(10) // blocks until 10 requests are allowed
 //	limiter.Close() // closes the rate limiter
 //
 //	limiter.GetStatus() // returns the status of the rate limiter
@@ -84,6 +85,13 @@ func (r *ChannelRateLimiter) Wait() {
 	<-r.tokens
 }
 
+// WaitN blocks until n requests are allowed, every item of a batch request counts as one request
+func (r *ChannelRateLimiter) WaitN(n int) {
+	for range n {
+		<-r.tokens
+	}
+}
+
 // Close closes the rate limiter
 func (r *ChannelRateLimiter) Close() {
 	close(r.done)
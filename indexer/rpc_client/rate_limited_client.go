@@ -1,6 +1,8 @@
 package rpcclient
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client/rate_limit"
@@ -61,6 +63,12 @@ func (r *RateLimitedRpcClient) GetBlock(height uint64) (*BlockResponse, *RpcHeig
 	return r.client.GetBlock(height)
 }
 
+// GetBlockResults method with rate limiting
+func (r *RateLimitedRpcClient) GetBlockResults(height uint64) (*BlockResultsResponse, *RpcHeightError) {
+	r.rateLimiter.Wait()
+	return r.client.GetBlockResults(height)
+}
+
 // GetLatestBlockHeight method with rate limiting
 func (r *RateLimitedRpcClient) GetLatestBlockHeight() (uint64, *RpcHeightError) {
 	r.rateLimiter.Wait()
@@ -73,6 +81,17 @@ func (r *RateLimitedRpcClient) GetTx(txHash string) (*TxResponse, *RpcStringErro
 	return r.client.GetTx(txHash)
 }
 
+// SubscribeNewBlocks method with rate limiting
+// only the subscription request is rate limited, the events pushed by the node are not
+func (r *RateLimitedRpcClient) SubscribeNewBlocks(ctx context.Context) (<-chan uint64, error) {
+	subscriber, ok := r.client.(Subscriber)
+	if !ok {
+		return nil, errors.New("rpc client doesn't support websocket subscriptions")
+	}
+	r.rateLimiter.Wait()
+	return subscriber.SubscribeNewBlocks(ctx)
+}
+
 // GetAbciQuery method with rate limiting
 func (r *RateLimitedRpcClient) GetAbciQuery(path string, data string, height *uint64, prove *bool) (any, error) {
 	r.rateLimiter.Wait()
@@ -85,6 +104,39 @@ func (r *RateLimitedRpcClient) GetCommit(height uint64) (*CommitResponse, *RpcCo
 	return r.client.GetCommit(height)
 }
 
+// GetBlocks method with rate limiting
+// every item of the batch counts as one request, the same way the public nodes count them
+func (r *RateLimitedRpcClient) GetBlocks(heights []uint64) ([]*BlockResponse, error) {
+	batcher, ok := r.client.(BatchClient)
+	if !ok {
+		return nil, errors.New("rpc client doesn't support batch requests")
+	}
+	r.rateLimiter.WaitN(len(heights))
+	return batcher.GetBlocks(heights)
+}
+
+// GetBlocksResults method with rate limiting
+// every item of the batch counts as one request, the same way the public nodes count them
+func (r *RateLimitedRpcClient) GetBlocksResults(heights []uint64) ([]*BlockResultsResponse, error) {
+	batcher, ok := r.client.(BatchClient)
+	if !ok {
+		return nil, errors.New("rpc client doesn't support batch requests")
+	}
+	r.rateLimiter.WaitN(len(heights))
+	return batcher.GetBlocksResults(heights)
+}
+
+// GetCommits method with rate limiting
+// every item of the batch counts as one request, the same way the public nodes count them
+func (r *RateLimitedRpcClient) GetCommits(heights []uint64) ([]*CommitResponse, error) {
+	batcher, ok := r.client.(BatchClient)
+	if !ok {
+		return nil, errors.New("rpc client doesn't support batch requests")
+	}
+	r.rateLimiter.WaitN(len(heights))
+	return batcher.GetCommits(heights)
+}
+
 // TryHealth - non-blocking version that returns false if rate limited
 func (r *RateLimitedRpcClient) TryHealth() (error, bool) {
 	if !r.rateLimiter.Allow() {
@@ -111,6 +163,15 @@ func (r *RateLimitedRpcClient) TryGetBlock(height uint64) (*BlockResponse, *RpcH
 	return response, err, true
 }
 
+// TryGetBlockResults - non-blocking version that returns false if rate limited
+func (r *RateLimitedRpcClient) TryGetBlockResults(height uint64) (*BlockResultsResponse, *RpcHeightError, bool) {
+	if !r.rateLimiter.Allow() {
+		return nil, nil, false // rate limited
+	}
+	response, err := r.client.GetBlockResults(height)
+	return response, err, true
+}
+
 // TryGetLatestBlockHeight - non-blocking version that returns false if rate limited
 func (r *RateLimitedRpcClient) TryGetLatestBlockHeight() (uint64, *RpcHeightError, bool) {
 	if !r.rateLimiter.Allow() {
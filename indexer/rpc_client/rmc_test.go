@@ -1,10 +1,15 @@
 package rpcclient_test
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
+	"github.com/gorilla/websocket"
 )
 
 // MockRpcClient - focuses on tracking what was called
@@ -101,3 +106,242 @@ func TestRateLimitedRpcClient_Constructor_ValidParameters(t *testing.T) {
 
 	t.Logf("Constructor result with valid params: err=%v", err)
 }
+
+// TestTxResponse_GetError - tests the conversion of the tx execution error to a string
+func TestTxResponse_GetError(t *testing.T) {
+	okTx := rpcClient.NewTestTxResponse("hash", 1)
+	if okTx.HasError() || okTx.GetError() != "" {
+		t.Errorf("expected no error for a successful tx, got %q", okTx.GetError())
+	}
+
+	failedTx := rpcClient.NewTestTxResponse("hash", 1).WithError(map[string]any{
+		"@type": "/std.OutOfGasError",
+		"value": map[string]any{},
+	})
+	if !failedTx.HasError() {
+		t.Fatal("expected tx to have an error")
+	}
+	if got := failedTx.GetError(); got != "/std.OutOfGasError" {
+		t.Errorf("expected /std.OutOfGasError, got %q", got)
+	}
+
+	unknownTx := rpcClient.NewTestTxResponse("hash", 1).WithError(map[string]any{"msg": "boom"})
+	if got := unknownTx.GetError(); got != `{"msg":"boom"}` {
+		t.Errorf("expected json encoded error, got %q", got)
+	}
+}
+
+// TestRpcGnoland_SubscribeNewBlocks - tests the websocket subscription against a fake rpc node
+func TestRpcGnoland_SubscribeNewBlocks(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != rpcClient.WebsocketPath {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var request map[string]any
+		if err := conn.ReadJSON(&request); err != nil {
+			return
+		}
+		if request["method"] != rpcClient.Subscribe {
+			return
+		}
+		_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "id": 1, "result": map[string]any{}})
+		_ = conn.WriteJSON(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1#event",
+			"result": map[string]any{
+				"query": rpcClient.NewBlockQuery,
+				"data": map[string]any{
+					"block": map[string]any{"header": map[string]any{"height": "42"}},
+				},
+			},
+		})
+		// give the client some time to read before the socket is closed
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client, err := rpcClient.NewRpcClient(server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create rpc client: %v", err)
+	}
+
+	heights, err := client.SubscribeNewBlocks(context.Background())
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	select {
+	case height := <-heights:
+		if height != 42 {
+			t.Errorf("expected height 42, got %d", height)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for new block height")
+	}
+
+	// channel should be closed once the server drops the socket
+	select {
+	case _, ok := <-heights:
+		if ok {
+			t.Error("expected channel to be closed after the socket dropped")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the channel to close")
+	}
+}
+
+// TestRpcGnoland_SubscribeNewBlocks_RpcError - tests that the rejected subscription returns an error
+func TestRpcGnoland_SubscribeNewBlocks_RpcError(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var request map[string]any
+		if err := conn.ReadJSON(&request); err != nil {
+			return
+		}
+		_ = conn.WriteJSON(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"error":   map[string]any{"code": -32601, "message": "Method not found"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := rpcClient.NewRpcClient(server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create rpc client: %v", err)
+	}
+
+	if _, err := client.SubscribeNewBlocks(context.Background()); err == nil {
+		t.Error("expected subscription to fail when the node rejects it")
+	}
+}
+
+// TestRpcGnoland_GetBlocks - tests the batch request against a fake rpc node
+func TestRpcGnoland_GetBlocks(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		var requests []map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// answer in the reverse order, the node doesn't have to keep the order of the batch
+		responses := make([]any, 0, len(requests))
+		for i := len(requests) - 1; i >= 0; i-- {
+			params := requests[i]["params"].(map[string]any)
+			if params["height"] == "3" {
+				responses = append(responses, map[string]any{
+					"jsonrpc": "2.0",
+					"id":      requests[i]["id"],
+					"error":   map[string]any{"code": -32603, "message": "height 3 must be less than or equal to the current blockchain height"},
+				})
+				continue
+			}
+			responses = append(responses, map[string]any{
+				"jsonrpc": "2.0",
+				"id":      requests[i]["id"],
+				"result": map[string]any{
+					"block": map[string]any{"header": map[string]any{"height": params["height"]}},
+				},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(responses)
+	}))
+	defer server.Close()
+
+	client, err := rpcClient.NewRpcClient(server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create rpc client: %v", err)
+	}
+
+	blocks, err := client.GetBlocks([]uint64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("failed to get blocks: %v", err)
+	}
+	if requestCount != 1 {
+		t.Errorf("expected 1 http request, got %d", requestCount)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(blocks))
+	}
+	for i, expected := range []uint64{1, 2} {
+		height, err := blocks[i].GetHeight()
+		if err != nil || height != expected {
+			t.Errorf("expected block %d at index %d, got %d (%v)", expected, i, height, err)
+		}
+	}
+	if blocks[2] != nil {
+		t.Error("expected the block with the rpc error to be nil")
+	}
+}
+
+// TestRpcGnoland_GetBlocks_NoBatchSupport - tests that a node without batch support returns an error
+func TestRpcGnoland_GetBlocks_NoBatchSupport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      -1,
+			"error":   map[string]any{"code": -32700, "message": "Parse error"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := rpcClient.NewRpcClient(server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create rpc client: %v", err)
+	}
+
+	if _, err := client.GetCommits([]uint64{1, 2}); err == nil {
+		t.Error("expected the batch request to fail when the node doesn't support batches")
+	}
+}
+
+// TestRateLimitedRpcClient_GetBlocks_TokenPerItem - tests that every item of a batch takes one rate limit token
+func TestRateLimitedRpcClient_GetBlocks_TokenPerItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		responses := make([]any, 0, len(requests))
+		for _, request := range requests {
+			params := request["params"].(map[string]any)
+			responses = append(responses, map[string]any{
+				"jsonrpc": "2.0",
+				"id":      request["id"],
+				"result": map[string]any{
+					"block": map[string]any{"header": map[string]any{"height": params["height"]}},
+				},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(responses)
+	}))
+	defer server.Close()
+
+	client, err := rpcClient.NewRateLimitedRpcClient(server.URL, nil, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create rate limited rpc client: %v", err)
+	}
+
+	if _, err := client.GetBlocks([]uint64{1, 2, 3}); err != nil {
+		t.Fatalf("failed to get blocks: %v", err)
+	}
+	if status := client.GetRateLimiterStatus(); status.TokensAvailable != 7 {
+		t.Errorf("expected 7 tokens left after a batch of 3 blocks, got %d", status.TokensAvailable)
+	}
+}
@@ -1,8 +1,10 @@
 package rpcclient
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // Named structs for TxResponse
@@ -16,6 +18,31 @@ type Event struct {
 	Type    string           `json:"type"`
 	Attrs   []EventAttribute `json:"attrs"`
 	PkgPath string           `json:"pkg_path"`
+	// the storage deposit events of the vm keep the coins in their own fields instead of the attrs
+	FeeDelta       *EventCoin `json:"fee_delta,omitempty"`
+	FeeRefund      *EventCoin `json:"fee_refund,omitempty"`
+	RefundWithheld bool       `json:"refund_withheld,omitempty"`
+}
+
+// EventCoin is a coin of an event, the amount is kept as a string since amino
+// encodes the int64 values as strings
+type EventCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// UnmarshalJSON accepts the amount as a string or as a number
+func (c *EventCoin) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Denom  string          `json:"denom"`
+		Amount json.RawMessage `json:"amount"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	c.Denom = raw.Denom
+	c.Amount = strings.Trim(string(raw.Amount), `"`)
+	return nil
 }
 
 type ResponseBase struct {
@@ -104,3 +131,43 @@ func (tr *TxResponse) IsValid() bool {
 func (tr *TxResponse) HasError() bool {
 	return tr != nil && tr.Result.TxResult.ResponseBase.Error != nil
 }
+
+// GetError returns the transaction execution error in a string form.
+// The amino encoded error usually looks like {"@type": "/std.InternalError", "value": ...}
+// so the type url is returned when present, otherwise the whole error is returned as json.
+//
+// Returns:
+//   - string: the error, empty if the transaction was executed successfully
+func (tr *TxResponse) GetError() string {
+	if !tr.HasError() {
+		return ""
+	}
+	txErr := tr.Result.TxResult.ResponseBase.Error
+	switch e := txErr.(type) {
+	case string:
+		return e
+	case map[string]any:
+		if atType, ok := e["@type"].(string); ok && atType != "" {
+			return atType
+		}
+	}
+	raw, err := json.Marshal(txErr)
+	if err != nil {
+		return fmt.Sprintf("%v", txErr)
+	}
+	return string(raw)
+}
+
+func (tr *TxResponse) GetLog() string {
+	if tr == nil {
+		return ""
+	}
+	return tr.Result.TxResult.ResponseBase.Log
+}
+
+func (tr *TxResponse) GetInfo() string {
+	if tr == nil {
+		return ""
+	}
+	return tr.Result.TxResult.ResponseBase.Info
+}
@@ -1,6 +1,7 @@
 package rpcclient
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client/rate_limit"
@@ -31,15 +32,29 @@ type Client interface {
 	Health() error
 	GetValidators(height uint64) (*ValidatorsResponse, *RpcHeightError)
 	GetBlock(height uint64) (*BlockResponse, *RpcHeightError)
+	GetBlockResults(height uint64) (*BlockResultsResponse, *RpcHeightError)
 	GetLatestBlockHeight() (uint64, *RpcHeightError)
 	GetTx(txHash string) (*TxResponse, *RpcStringError)
 	GetAbciQuery(path string, data string, height *uint64, prove *bool) (any, error)
 	GetCommit(height uint64) (*CommitResponse, *RpcCommitError)
 }
 
+// Subscriber is the interface for the rpc client that supports websocket subscriptions
+type Subscriber interface {
+	SubscribeNewBlocks(ctx context.Context) (<-chan uint64, error)
+}
+
+// BatchClient is the interface for the rpc client that supports JSON-RPC batch requests
+type BatchClient interface {
+	GetBlocks(heights []uint64) ([]*BlockResponse, error)
+	GetBlocksResults(heights []uint64) ([]*BlockResultsResponse, error)
+	GetCommits(heights []uint64) ([]*CommitResponse, error)
+}
+
 type RateLimiter interface {
 	Allow() bool
 	Wait()
+	WaitN(n int)
 	Close()
 	GetStatus() rate_limit.ChannelRateLimiterStatus
 }
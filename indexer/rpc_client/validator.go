@@ -28,6 +28,9 @@ type ValPubKey struct {
 }
 
 func (vr *ValidatorsResponse) GetValidators() []ValidatorsSlice {
+	if vr == nil {
+		return nil
+	}
 	return vr.Result.Validators
 }
 
@@ -0,0 +1,198 @@
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	Subscribe = "subscribe"
+	// query used to subscribe to the new block events
+	NewBlockQuery = "tm.event='NewBlock'"
+	// path of the websocket endpoint on the rpc node
+	WebsocketPath = "/websocket"
+)
+
+// how long the socket can stay silent before it is considered dead
+// blocks on gnoland are produced every few seconds so this should be more than enough
+const wsReadTimeout = 60 * time.Second
+
+// eventBlock holds only the part of the block needed from the new block event
+type eventBlock struct {
+	Header struct {
+		Height string `json:"height"`
+	} `json:"header"`
+}
+
+// NewBlockEvent is the message pushed by the rpc node for every new block
+//
+// Depending on the node version the block can be found either directly under the data
+// or wrapped inside of the value field so both are supported.
+type NewBlockEvent struct {
+	Jsonrpc string        `json:"jsonrpc"`
+	ID      any           `json:"id"`
+	Error   *JsonRpcError `json:"error,omitempty"`
+	Result  struct {
+		Query string `json:"query"`
+		Data  struct {
+			Block *eventBlock `json:"block"`
+			Value *struct {
+				Block *eventBlock `json:"block"`
+			} `json:"value"`
+		} `json:"data"`
+	} `json:"result"`
+}
+
+// GetHeight returns the height of the block from the new block event
+//
+// Returns:
+//   - uint64: the height of the block
+//   - error: if the event doesn't contain a block or the height is not valid
+func (e *NewBlockEvent) GetHeight() (uint64, error) {
+	if e == nil {
+		return 0, fmt.Errorf("NewBlockEvent is nil")
+	}
+	block := e.Result.Data.Block
+	if block == nil && e.Result.Data.Value != nil {
+		block = e.Result.Data.Value.Block
+	}
+	if block == nil {
+		return 0, fmt.Errorf("event doesn't contain a block")
+	}
+	return strconv.ParseUint(block.Header.Height, 10, 64)
+}
+
+// websocketURL converts the rpc url to the websocket url
+func websocketURL(rpcURL string) string {
+	switch {
+	case strings.HasPrefix(rpcURL, "https://"):
+		rpcURL = "wss://" + strings.TrimPrefix(rpcURL, "https://")
+	case strings.HasPrefix(rpcURL, "http://"):
+		rpcURL = "ws://" + strings.TrimPrefix(rpcURL, "http://")
+	}
+	return rpcURL + WebsocketPath
+}
+
+// SubscribeNewBlocks method to subscribe to the new block events over the rpc websocket.
+//
+// The method will dial the websocket, send the subscribe request and wait for the node to
+// acknowledge it. After that the heights of the new blocks are pushed to the returned channel.
+// The channel only holds the latest height, if the consumer is slower than the chain the older
+// heights are dropped since the consumer is expected to process everything up to the received height.
+//
+// The channel is closed when the socket drops or the context is cancelled.
+//
+// Parameters:
+//   - ctx: the context that controls the lifetime of the subscription
+//
+// Returns:
+//   - <-chan uint64: the channel with the new block heights
+//   - error: if the subscription fails
+func (r *RpcGnoland) SubscribeNewBlocks(ctx context.Context) (<-chan uint64, error) {
+	wsURL := websocketURL(r.rpcURL)
+	dialer := websocket.Dialer{HandshakeTimeout: r.client.Timeout}
+	conn, _, err := dialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial websocket %s: %w", wsURL, err)
+	}
+
+	request := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  Subscribe,
+		"params": map[string]any{
+			"query": NewBlockQuery,
+		},
+	}
+	if err := conn.SetWriteDeadline(time.Now().Add(r.client.Timeout)); err != nil {
+		closeConn(conn)
+		return nil, fmt.Errorf("failed to set write deadline: %w", err)
+	}
+	if err := conn.WriteJSON(request); err != nil {
+		closeConn(conn)
+		return nil, fmt.Errorf("failed to send subscribe request: %w", err)
+	}
+
+	// the first message should be the acknowledgement of the subscription
+	if err := conn.SetReadDeadline(time.Now().Add(r.client.Timeout)); err != nil {
+		closeConn(conn)
+		return nil, fmt.Errorf("failed to set read deadline: %w", err)
+	}
+	var ack HealthResponse
+	if err := conn.ReadJSON(&ack); err != nil {
+		closeConn(conn)
+		return nil, fmt.Errorf("failed to read subscribe response: %w", err)
+	}
+	if ack.Error != nil {
+		closeConn(conn)
+		return nil, fmt.Errorf("rpc error: %v, %s", ack.Error.Code, ack.Error.Message)
+	}
+
+	heights := make(chan uint64, 1)
+	go readNewBlocks(ctx, conn, heights)
+	return heights, nil
+}
+
+// readNewBlocks reads the new block events from the socket until it drops or the context is done
+func readNewBlocks(ctx context.Context, conn *websocket.Conn, heights chan uint64) {
+	done := make(chan struct{})
+	defer close(heights)
+	defer close(done)
+	defer closeConn(conn)
+
+	// close the connection on context cancellation to unblock the reader
+	go func() {
+		select {
+		case <-ctx.Done():
+			closeConn(conn)
+		case <-done:
+		}
+	}()
+
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(wsReadTimeout)); err != nil {
+			log.Printf("failed to set websocket read deadline: %v", err)
+			return
+		}
+		var event NewBlockEvent
+		if err := conn.ReadJSON(&event); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("websocket closed: %v", err)
+			}
+			return
+		}
+		if event.Error != nil {
+			log.Printf("websocket rpc error: %v, %s", event.Error.Code, event.Error.Message)
+			continue
+		}
+		height, err := event.GetHeight()
+		if err != nil {
+			continue
+		}
+
+		// keep only the latest height in the channel
+		select {
+		case heights <- height:
+		default:
+			select {
+			case <-heights:
+			default:
+			}
+			heights <- height
+		}
+	}
+}
+
+func closeConn(conn *websocket.Conn) {
+	if err := conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		log.Printf("failed to close websocket: %v", err)
+	}
+}
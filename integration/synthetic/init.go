@@ -11,6 +11,7 @@ import (
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/orchestrator"
 	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/database"
+	sqlDataTypes "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
 )
 
 // SyntheticIntegrationTestConfig holds configuration for synthetic integration tests
@@ -82,7 +83,7 @@ func RunSyntheticIntegrationTest(testConfig *SyntheticIntegrationTestConfig) err
 
 	// Run the historic process - this will use synthetic data but process it through
 	// the real data processor and store it in the real database
-	orch.HistoricProcess(testConfig.FromHeight, testConfig.ToHeight, false)
+	orch.HistoricProcess(testConfig.FromHeight, testConfig.ToHeight, false, false)
 
 	log.Printf("Synthetic integration test completed successfully!")
 	return nil
@@ -99,6 +100,38 @@ func (m *MockDatabaseHeight) GetLastBlockHeight(ctx context.Context, chainName s
 	return m.lastHeight, nil
 }
 
+func (m *MockDatabaseHeight) GetBlockHashes(ctx context.Context, chainName string, fromHeight uint64, toHeight uint64) (map[uint64][]byte, error) {
+	// the synthetic chain never forks, nothing to compare
+	return map[uint64][]byte{}, nil
+}
+
+func (m *MockDatabaseHeight) GetValidatorsHash(ctx context.Context, chainName string, height uint64) ([]byte, error) {
+	// the synthetic chain has no validators hash, there is never a set to compare
+	return nil, nil
+}
+
+func (m *MockDatabaseHeight) RewindToHeight(ctx context.Context, chainName string, height uint64) error {
+	return nil
+}
+
+func (m *MockDatabaseHeight) InsertIndexerProgress(
+	ctx context.Context, chainName string, runningMode string, fromHeight uint64, toHeight uint64,
+) error {
+	return nil
+}
+
+func (m *MockDatabaseHeight) GetIndexerProgress(
+	ctx context.Context, chainName string, runningMode string, fromHeight uint64, toHeight uint64,
+) ([]sqlDataTypes.IndexerProgress, error) {
+	return nil, nil
+}
+
+func (m *MockDatabaseHeight) GetMissingHeightRanges(
+	ctx context.Context, chainName string, fromHeight uint64, toHeight uint64,
+) ([]database.HeightRange, error) {
+	return nil, nil
+}
+
 // MockGnolandRpcClient implements the GnolandRpcClient interface
 type MockGnolandRpcClient struct {
 	latestHeight uint64
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"log"
 	"math/rand/v2"
+	"strconv"
 	"time"
 
 	rpcClient "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/rpc_client"
@@ -81,20 +82,25 @@ func (sq *SyntheticQueryOperator) GetFromToBlocks(fromHeight uint64, toHeight ui
 	return blocks
 }
 
-// GetTransactions implements the QueryOperator interface by returning synthetic transactions
-func (sq *SyntheticQueryOperator) GetTransactions(txHashes []string) []*rpcClient.TxResponse {
-	if len(txHashes) < 1 {
-		return nil
-	}
-
-	transactions := make([]*rpcClient.TxResponse, 0, len(txHashes))
-	for _, hash := range txHashes {
-		tx := sq.getTransaction(hash)
-		if tx != nil {
-			transactions = append(transactions, tx)
+// GetBlockResults implements the QueryOperator interface by returning the results of the
+// synthetic transactions in the same order as the transactions of the block
+func (sq *SyntheticQueryOperator) GetBlockResults(heights []uint64) []*rpcClient.BlockResultsResponse {
+	blockResults := make([]*rpcClient.BlockResultsResponse, 0, len(heights))
+	for _, height := range heights {
+		result := &rpcClient.BlockResultsResponse{Jsonrpc: "2.0", ID: 1}
+		result.Result.Height = strconv.FormatUint(height, 10)
+		for _, txRaw := range sq.getBlock(height).GetTxHashes() {
+			txRawBytes, err := base64.StdEncoding.DecodeString(txRaw)
+			if err != nil {
+				log.Fatal(err)
+			}
+			txHash := sha256.Sum256(txRawBytes)
+			tx := sq.getTransaction(base64.StdEncoding.EncodeToString(txHash[:]))
+			result.Result.Results.DeliverTxs = append(result.Result.Results.DeliverTxs, tx.Result.TxResult)
 		}
+		blockResults = append(blockResults, result)
 	}
-	return transactions
+	return blockResults
 }
 
 // GetFromToCommits implements the QueryOperator interface by returning synthetic commits
@@ -112,6 +118,24 @@ func (sq *SyntheticQueryOperator) GetFromToCommits(fromHeight uint64, toHeight u
 	return commits
 }
 
+// GetValidatorSets implements the QueryOperator interface by returning the synthetic validators
+// with the same voting power at every height
+func (sq *SyntheticQueryOperator) GetValidatorSets(heights []uint64) []*rpcClient.ValidatorsResponse {
+	validatorSets := make([]*rpcClient.ValidatorsResponse, 0, len(heights))
+	for _, height := range heights {
+		validatorSet := &rpcClient.ValidatorsResponse{Jsonrpc: "2.0", ID: 1}
+		validatorSet.Result.BlockHeight = strconv.FormatUint(height, 10)
+		for _, validator := range sq.signedValidators {
+			validatorSet.Result.Validators = append(validatorSet.Result.Validators, rpcClient.ValidatorsSlice{
+				Address:     validator,
+				VotingPower: "1",
+			})
+		}
+		validatorSets = append(validatorSets, validatorSet)
+	}
+	return validatorSets
+}
+
 // GetLatestBlockHeight implements the QueryOperator interface
 func (sq *SyntheticQueryOperator) GetLatestBlockHeight() (uint64, error) {
 	return sq.currentHeight, nil
@@ -193,12 +217,19 @@ func (sq *SyntheticQueryOperator) createSynthBlock(height uint64) (*rpcClient.Bl
 
 	blockTimestamp := baseTimestamp.Add(time.Duration(height-1) * blockProductionRate)
 
+	// link the block to the previous one so the orchestrator sees a continuous chain
+	lastBlockHash := ""
+	if parent, ok := sq.blocks[height-1]; ok {
+		lastBlockHash = parent.GetBlockHash()
+	}
+
 	// Create the block using existing synthetic response maker
 	blockInput := GenBlockInput{
-		Height:    height,
-		ChainID:   sq.chainID,
-		Timestamp: blockTimestamp,
-		TxsRaw:    txRaws,
+		Height:        height,
+		ChainID:       sq.chainID,
+		Timestamp:     blockTimestamp,
+		TxsRaw:        txRaws,
+		LastBlockHash: lastBlockHash,
 	}
 
 	block := sq.responseMaker.GenerateBlockResponse(blockInput)
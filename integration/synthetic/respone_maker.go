@@ -23,13 +23,18 @@ type GenBlockInput struct {
 	ChainID   string
 	Timestamp time.Time
 	TxsRaw    []string
+	// hash of the previous block, if empty a random one is generated
+	LastBlockHash string
 }
 
 func (rm *ResponseMaker) GenerateBlockResponse(input GenBlockInput) *rpcClient.BlockResponse {
 	// just generate the hash for block and last block
 	// for others just use empty strings since this is all just to mock the similar expereience
 	blockHash := rm.generator.GenerateBlockHash()
-	lastBlockHash := rm.generator.GenerateBlockHash()
+	lastBlockHash := input.LastBlockHash
+	if lastBlockHash == "" {
+		lastBlockHash = rm.generator.GenerateBlockHash()
+	}
 	partsHash := ""
 	lastPartsHash := ""
 	lastCommitHash := ""
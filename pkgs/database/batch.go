@@ -0,0 +1,422 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+	"github.com/jackc/pgx/v5"
+)
+
+// ChunkBatch collects the rows of a single chunk and writes them all within one transaction.
+//
+// It has the same insert methods as the TimescaleDb so it can be used by the data processor
+// in place of the database. The insert methods only queue the rows and never fail, the rows
+// are written on Commit. Either the whole chunk is stored or none of it is.
+//
+// The insert methods are safe for concurrent use.
+type ChunkBatch struct {
+	db *TimescaleDb
+	mu sync.Mutex
+
+	blocks              []sql_data_types.Blocks
+	validatorSignings   []sql_data_types.ValidatorBlockSigning
+	validatorSets       []sql_data_types.ValidatorSet
+	validatorUpdates    []sql_data_types.ValidatorUpdate
+	blockEvents         []sql_data_types.BlockEvent
+	transactionsGeneral []sql_data_types.TransactionGeneral
+	addressTx           []sql_data_types.AddressTx
+	msgSend             []sql_data_types.MsgSend
+	msgCall             []sql_data_types.MsgCall
+	msgAddPackage       []sql_data_types.MsgAddPackage
+	msgRun              []sql_data_types.MsgRun
+	msgGeneric          []sql_data_types.MsgGeneric
+	txEvents            []sql_data_types.TxEvent
+	txSignatures        []sql_data_types.TxSignature
+	grc20Transfers      []sql_data_types.Grc20Transfer
+	nftTransfers        []sql_data_types.NftTransfer
+	nativeChanges       []sql_data_types.NativeBalanceChange
+	packageFiles        []sql_data_types.PackageFile
+	packages            []sql_data_types.Package
+	// the rows of the tables added with RegisterTable
+	tableRows []TableRows
+}
+
+// NewChunkBatch creates a new empty chunk batch that writes to the database
+//
+// Returns:
+//   - *ChunkBatch: the chunk batch
+func (t *TimescaleDb) NewChunkBatch() *ChunkBatch {
+	return &ChunkBatch{db: t}
+}
+
+// InsertBlocks queues the blocks for the next commit
+func (b *ChunkBatch) InsertBlocks(ctx context.Context, blocks []sql_data_types.Blocks) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.blocks = append(b.blocks, blocks...)
+	return nil
+}
+
+// InsertValidatorBlockSignings queues the validator block signings for the next commit
+func (b *ChunkBatch) InsertValidatorBlockSignings(
+	ctx context.Context,
+	validatorBlockSigning []sql_data_types.ValidatorBlockSigning,
+) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.validatorSignings = append(b.validatorSignings, validatorBlockSigning...)
+	return nil
+}
+
+// InsertValidatorSets queues the validator set members for the next commit
+func (b *ChunkBatch) InsertValidatorSets(ctx context.Context, validatorSets []sql_data_types.ValidatorSet) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.validatorSets = append(b.validatorSets, validatorSets...)
+	return nil
+}
+
+// InsertValidatorUpdates queues the validator updates for the next commit
+func (b *ChunkBatch) InsertValidatorUpdates(ctx context.Context, validatorUpdates []sql_data_types.ValidatorUpdate) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.validatorUpdates = append(b.validatorUpdates, validatorUpdates...)
+	return nil
+}
+
+// InsertBlockEvents queues the block events for the next commit
+func (b *ChunkBatch) InsertBlockEvents(ctx context.Context, blockEvents []sql_data_types.BlockEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.blockEvents = append(b.blockEvents, blockEvents...)
+	return nil
+}
+
+// InsertTransactionsGeneral queues the transactions for the next commit
+func (b *ChunkBatch) InsertTransactionsGeneral(
+	ctx context.Context,
+	transactionsGeneral []sql_data_types.TransactionGeneral,
+) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.transactionsGeneral = append(b.transactionsGeneral, transactionsGeneral...)
+	return nil
+}
+
+// InsertAddressTx queues the address transactions for the next commit
+func (b *ChunkBatch) InsertAddressTx(ctx context.Context, addresses []sql_data_types.AddressTx) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addressTx = append(b.addressTx, addresses...)
+	return nil
+}
+
+// InsertMsgSend queues the MsgSend messages for the next commit
+func (b *ChunkBatch) InsertMsgSend(ctx context.Context, messages []sql_data_types.MsgSend) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.msgSend = append(b.msgSend, messages...)
+	return nil
+}
+
+// InsertMsgCall queues the MsgCall messages for the next commit
+func (b *ChunkBatch) InsertMsgCall(ctx context.Context, messages []sql_data_types.MsgCall) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.msgCall = append(b.msgCall, messages...)
+	return nil
+}
+
+// InsertMsgAddPackage queues the MsgAddPackage messages for the next commit
+func (b *ChunkBatch) InsertMsgAddPackage(ctx context.Context, messages []sql_data_types.MsgAddPackage) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.msgAddPackage = append(b.msgAddPackage, messages...)
+	return nil
+}
+
+// InsertMsgRun queues the MsgRun messages for the next commit
+func (b *ChunkBatch) InsertMsgRun(ctx context.Context, messages []sql_data_types.MsgRun) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.msgRun = append(b.msgRun, messages...)
+	return nil
+}
+
+// InsertMsgGeneric queues the MsgGeneric messages for the next commit
+func (b *ChunkBatch) InsertMsgGeneric(ctx context.Context, messages []sql_data_types.MsgGeneric) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.msgGeneric = append(b.msgGeneric, messages...)
+	return nil
+}
+
+// InsertTxEvents queues the transaction events for the next commit
+func (b *ChunkBatch) InsertTxEvents(ctx context.Context, events []sql_data_types.TxEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.txEvents = append(b.txEvents, events...)
+	return nil
+}
+
+// InsertTxSignatures queues the transaction signatures for the next commit,
+// the public keys of the signers are filled on commit
+func (b *ChunkBatch) InsertTxSignatures(ctx context.Context, signatures []sql_data_types.TxSignature) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.txSignatures = append(b.txSignatures, signatures...)
+	return nil
+}
+
+// InsertGrc20Transfers queues the GRC20 transfers for the next commit
+func (b *ChunkBatch) InsertGrc20Transfers(ctx context.Context, transfers []sql_data_types.Grc20Transfer) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.grc20Transfers = append(b.grc20Transfers, transfers...)
+	return nil
+}
+
+// InsertNftTransfers queues the GRC721 transfers for the next commit
+func (b *ChunkBatch) InsertNftTransfers(ctx context.Context, transfers []sql_data_types.NftTransfer) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nftTransfers = append(b.nftTransfers, transfers...)
+	return nil
+}
+
+// InsertNativeBalanceChanges queues the native coin balance changes for the next commit
+func (b *ChunkBatch) InsertNativeBalanceChanges(ctx context.Context, changes []sql_data_types.NativeBalanceChange) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nativeChanges = append(b.nativeChanges, changes...)
+	return nil
+}
+
+// InsertPackageFiles queues the package files for the next commit
+func (b *ChunkBatch) InsertPackageFiles(ctx context.Context, files []sql_data_types.PackageFile) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.packageFiles = append(b.packageFiles, files...)
+	return nil
+}
+
+// InsertPackages queues the packages for the next commit
+func (b *ChunkBatch) InsertPackages(ctx context.Context, packages []sql_data_types.Package) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.packages = append(b.packages, packages...)
+	return nil
+}
+
+// InsertRows queues the rows of the registered table for the next commit
+func (b *ChunkBatch) InsertRows(ctx context.Context, rows TableRows) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tableRows = append(b.tableRows, rows)
+	return nil
+}
+
+// Size returns the amount of the queued rows
+func (b *ChunkBatch) Size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.blocks) + len(b.validatorSignings) + len(b.validatorSets) + len(b.validatorUpdates) +
+		len(b.blockEvents) + len(b.transactionsGeneral) + len(b.addressTx) + len(b.msgSend) + len(b.msgCall) + len(b.msgAddPackage) + len(b.msgRun) +
+		len(b.msgGeneric) + len(b.txEvents) + len(b.txSignatures) + len(b.grc20Transfers) + len(b.nftTransfers) +
+		len(b.nativeChanges) + len(b.packageFiles) + len(b.packages) + b.tableRowCount()
+}
+
+// tableRowCount returns the amount of the queued rows of the registered tables
+func (b *ChunkBatch) tableRowCount() int {
+	count := 0
+	for _, rows := range b.tableRows {
+		count += len(rows.Rows)
+	}
+	return count
+}
+
+// Reset drops all of the queued rows
+func (b *ChunkBatch) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reset()
+}
+
+func (b *ChunkBatch) reset() {
+	b.blocks = nil
+	b.validatorSignings = nil
+	b.validatorSets = nil
+	b.validatorUpdates = nil
+	b.blockEvents = nil
+	b.transactionsGeneral = nil
+	b.addressTx = nil
+	b.msgSend = nil
+	b.msgCall = nil
+	b.msgAddPackage = nil
+	b.msgRun = nil
+	b.msgGeneric = nil
+	b.txEvents = nil
+	b.txSignatures = nil
+	b.grc20Transfers = nil
+	b.nftTransfers = nil
+	b.nativeChanges = nil
+	b.packageFiles = nil
+	b.packages = nil
+	b.tableRows = nil
+}
+
+// Commit writes all of the queued rows within one transaction
+//
+// Usage:
+//
+// # Used by the data processor once the whole chunk is processed
+//
+// The queued rows are dropped after the commit whether it succeeds or not,
+// a failed chunk needs to be processed again.
+//
+// Parameters:
+//   - ctx: the context to use for the transaction
+//
+// Returns:
+//   - error: if any of the copies fails, nothing is stored in that case
+func (b *ChunkBatch) Commit(ctx context.Context) error {
+	return b.commit(ctx, b.db.insertMode)
+}
+
+// CommitSkipExisting writes all of the queued rows within one transaction and keeps the rows
+// that are already stored, whatever the insert mode of the database is
+//
+// Usage:
+//
+// # Used to backfill the gaps, a gap can be a stored block that misses only some of its rows
+//
+// Parameters:
+//   - ctx: the context to use for the transaction
+//
+// Returns:
+//   - error: if any of the copies fails, nothing is stored in that case
+func (b *ChunkBatch) CommitSkipExisting(ctx context.Context) error {
+	return b.commit(ctx, InsertModeSkip)
+}
+
+// commit is a private method that writes the queued rows with the given insert mode
+func (b *ChunkBatch) commit(ctx context.Context, mode InsertMode) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.reset()
+
+	tx, err := b.db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin chunk transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback chunk transaction")
+			}
+		}
+	}()
+
+	// in the skip and update modes the rows go through the temporary tables
+	c := txCopier(tx, mode)
+	if err = copyBlocks(ctx, c, b.blocks); err != nil {
+		return fmt.Errorf("failed to insert blocks: %w", err)
+	}
+	if err = copyValidatorBlockSignings(ctx, c, b.validatorSignings); err != nil {
+		return fmt.Errorf("failed to insert validator block signings: %w", err)
+	}
+	if err = copyValidatorSets(ctx, c, b.validatorSets); err != nil {
+		return fmt.Errorf("failed to insert validator sets: %w", err)
+	}
+	// the missed blocks compare the signings with the sets, so they are calculated after both copies
+	if len(b.validatorSignings) > 0 {
+		heights := signingHeights(b.validatorSignings)
+		if err = refreshMissedBlocks(ctx, tx, b.validatorSignings[0].ChainName, heights); err != nil {
+			return err
+		}
+	}
+	if err = copyValidatorUpdates(ctx, c, b.validatorUpdates); err != nil {
+		return fmt.Errorf("failed to insert validator updates: %w", err)
+	}
+	if err = copyBlockEvents(ctx, c, b.blockEvents); err != nil {
+		return fmt.Errorf("failed to insert block events: %w", err)
+	}
+	if err = copyTransactionsGeneral(ctx, c, b.transactionsGeneral); err != nil {
+		return fmt.Errorf("failed to insert transactions: %w", err)
+	}
+	if err = copyTxEvents(ctx, c, b.txEvents); err != nil {
+		return fmt.Errorf("failed to insert tx events: %w", err)
+	}
+	if err = copyTxSignatures(ctx, c, b.txSignatures); err != nil {
+		return fmt.Errorf("failed to insert tx signatures: %w", err)
+	}
+	if err = fillAddressPubKeys(ctx, tx, b.txSignatures); err != nil {
+		return err
+	}
+	if err = copyAddressTx(ctx, c, b.addressTx); err != nil {
+		return fmt.Errorf("failed to insert address tx: %w", err)
+	}
+	if err = copyMsgSend(ctx, c, b.msgSend); err != nil {
+		return fmt.Errorf("failed to insert MsgSend: %w", err)
+	}
+	if err = copyMsgCall(ctx, c, b.msgCall); err != nil {
+		return fmt.Errorf("failed to insert MsgCall: %w", err)
+	}
+	if err = copyMsgAddPackage(ctx, c, b.msgAddPackage); err != nil {
+		return fmt.Errorf("failed to insert MsgAddPackage: %w", err)
+	}
+	if err = copyMsgRun(ctx, c, b.msgRun); err != nil {
+		return fmt.Errorf("failed to insert MsgRun: %w", err)
+	}
+	if err = copyMsgGeneric(ctx, c, b.msgGeneric); err != nil {
+		return fmt.Errorf("failed to insert MsgGeneric: %w", err)
+	}
+	for _, rows := range b.tableRows {
+		if err = copyTableRows(ctx, c, rows); err != nil {
+			return fmt.Errorf("failed to insert %s: %w", rows.Table, err)
+		}
+	}
+	// the package files are always skipped if they exist, so they don't depend on the insert mode
+	if err = copyPackageFiles(ctx, tx, b.packageFiles); err != nil {
+		return fmt.Errorf("failed to insert package files: %w", err)
+	}
+	if err = copyPackages(ctx, tx, b.packages); err != nil {
+		return fmt.Errorf("failed to insert packages: %w", err)
+	}
+	if err = copyGrc20Transfers(ctx, c, b.grc20Transfers); err != nil {
+		return fmt.Errorf("failed to insert grc20 transfers: %w", err)
+	}
+	// the balances are calculated from the stored transfers, so they are updated after the copy
+	if len(b.grc20Transfers) > 0 {
+		tokens, addresses := grc20BalancePairs(b.grc20Transfers)
+		if err = refreshGrc20Balances(ctx, tx, b.grc20Transfers[0].ChainName, tokens, addresses); err != nil {
+			return err
+		}
+	}
+	if err = copyNftTransfers(ctx, c, b.nftTransfers); err != nil {
+		return fmt.Errorf("failed to insert nft transfers: %w", err)
+	}
+	if len(b.nftTransfers) > 0 {
+		collections, tokenIds := nftOwnerPairs(b.nftTransfers)
+		if err = refreshNftOwners(ctx, tx, b.nftTransfers[0].ChainName, collections, tokenIds); err != nil {
+			return err
+		}
+	}
+	if err = copyNativeBalanceChanges(ctx, c, b.nativeChanges); err != nil {
+		return fmt.Errorf("failed to insert native balance changes: %w", err)
+	}
+	if len(b.nativeChanges) > 0 {
+		addresses, denoms := nativeBalancePairs(b.nativeChanges)
+		if err = refreshNativeBalances(ctx, tx, b.nativeChanges[0].ChainName, addresses, denoms); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit chunk transaction: %w", err)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
 	"github.com/jackc/pgx/v5"
@@ -62,6 +64,468 @@ func (t *TimescaleDb) InsertAddresses(
 // Returns:
 //   - error: an error if the insertion fails
 func (t *TimescaleDb) InsertBlocks(ctx context.Context, blocks []sql_data_types.Blocks) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyBlocks(ctx, c, blocks)
+	})
+}
+
+// InsertValidatorBlockSignings inserts a slice of validator block signings into the database using pgx copy function
+// it will create the copy from slice to the db and then insert it to the database, the missed blocks
+// of the inserted heights are calculated again within the same transaction
+//
+// Usage:
+//
+// # Used for inserting a large number of validator block signings to the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - validatorBlockSigning: a slice of validator block signings to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertValidatorBlockSignings(
+	ctx context.Context,
+	validatorBlockSigning []sql_data_types.ValidatorBlockSigning,
+) (err error) {
+	if len(validatorBlockSigning) == 0 {
+		return nil
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin insert transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback insert transaction")
+			}
+		}
+	}()
+
+	if err = copyValidatorBlockSignings(ctx, txCopier(tx, t.insertMode), validatorBlockSigning); err != nil {
+		return err
+	}
+	heights := signingHeights(validatorBlockSigning)
+	if err = refreshMissedBlocks(ctx, tx, validatorBlockSigning[0].ChainName, heights); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// InsertValidatorSets inserts a slice of validator set members into the database using pgx copy function
+//
+// Usage:
+//
+// # Used for inserting the validator sets stored when the validators hash changed
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - validatorSets: a slice of validator set members to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertValidatorSets(ctx context.Context, validatorSets []sql_data_types.ValidatorSet) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyValidatorSets(ctx, c, validatorSets)
+	})
+}
+
+// InsertValidatorUpdates inserts a slice of validator updates into the database using pgx copy function
+//
+// Usage:
+//
+// # Used for inserting the validator set changes returned by the EndBlock
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - validatorUpdates: a slice of validator updates to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertValidatorUpdates(ctx context.Context, validatorUpdates []sql_data_types.ValidatorUpdate) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyValidatorUpdates(ctx, c, validatorUpdates)
+	})
+}
+
+// InsertBlockEvents inserts a slice of block events into the database using pgx copy function
+//
+// Usage:
+//
+// # Used for inserting the BeginBlock and EndBlock events of the blocks
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - blockEvents: a slice of block events to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertBlockEvents(ctx context.Context, blockEvents []sql_data_types.BlockEvent) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyBlockEvents(ctx, c, blockEvents)
+	})
+}
+
+// InsertTransactionsGeneral inserts a slice of transaction general data into the database using pgx copy function
+// it will create the copy from slice to the db and then insert it to the database
+//
+// Usage:
+//
+// # Used for inserting a large number of transaction general data to the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - transactionsGeneral: a slice of transaction general data to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertTransactionsGeneral(
+	ctx context.Context,
+	transactionsGeneral []sql_data_types.TransactionGeneral,
+) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyTransactionsGeneral(ctx, c, transactionsGeneral)
+	})
+}
+
+// InsertAddressTx inserts a slice of AddressTx into the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - addresses: a slice of AddressTx to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertAddressTx(ctx context.Context, addresses []sql_data_types.AddressTx) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyAddressTx(ctx, c, addresses)
+	})
+}
+
+// InsertMsgSend inserts a slice of MsgSend messages into the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - messages: a slice of MsgSend messages to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertMsgSend(ctx context.Context, messages []sql_data_types.MsgSend) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyMsgSend(ctx, c, messages)
+	})
+}
+
+// InsertMsgCall inserts a slice of MsgCall messages into the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - messages: a slice of MsgCall messages to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertMsgCall(ctx context.Context, messages []sql_data_types.MsgCall) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyMsgCall(ctx, c, messages)
+	})
+}
+
+// InsertMsgAddPackage inserts a slice of MsgAddPackage messages into the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - messages: a slice of MsgAddPackage messages to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertMsgAddPackage(
+	ctx context.Context,
+	messages []sql_data_types.MsgAddPackage,
+) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyMsgAddPackage(ctx, c, messages)
+	})
+}
+
+// InsertMsgRun inserts a slice of MsgRun messages into the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - messages: a slice of MsgRun messages to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertMsgRun(
+	ctx context.Context,
+	messages []sql_data_types.MsgRun,
+) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyMsgRun(ctx, c, messages)
+	})
+}
+
+// InsertMsgGeneric inserts a slice of MsgGeneric messages into the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - messages: a slice of MsgGeneric messages to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertMsgGeneric(
+	ctx context.Context,
+	messages []sql_data_types.MsgGeneric,
+) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyMsgGeneric(ctx, c, messages)
+	})
+}
+
+// InsertTxEvents inserts a slice of transaction events into the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - events: a slice of transaction events to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertTxEvents(
+	ctx context.Context,
+	events []sql_data_types.TxEvent,
+) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyTxEvents(ctx, c, events)
+	})
+}
+
+// InsertPackageFiles inserts a slice of package files into the database
+//
+// The files are stored by the hash of their body, the files that are already stored
+// are skipped no matter which insert mode is used.
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - files: a slice of package files to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertPackageFiles(ctx context.Context, files []sql_data_types.PackageFile) (err error) {
+	if len(files) == 0 {
+		return nil
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin insert transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback insert transaction")
+			}
+		}
+	}()
+
+	if err = copyPackageFiles(ctx, tx, files); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// InsertPackages inserts a slice of packages into the database
+//
+// A package is stored only once, when the package already exists the row keeps the earliest
+// deployment no matter which insert mode is used.
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - packages: a slice of packages to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertPackages(ctx context.Context, packages []sql_data_types.Package) (err error) {
+	if len(packages) == 0 {
+		return nil
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin insert transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback insert transaction")
+			}
+		}
+	}()
+
+	if err = copyPackages(ctx, tx, packages); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// InsertTxSignatures inserts the transaction signatures and fills the public keys of their signers
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - signatures: a slice of transaction signatures to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertTxSignatures(
+	ctx context.Context,
+	signatures []sql_data_types.TxSignature,
+) (err error) {
+	if len(signatures) == 0 {
+		return nil
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin insert transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback insert transaction")
+			}
+		}
+	}()
+
+	if err = copyTxSignatures(ctx, txCopier(tx, t.insertMode), signatures); err != nil {
+		return err
+	}
+	if err = fillAddressPubKeys(ctx, tx, signatures); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// InsertGrc20Transfers inserts the GRC20 transfers and updates the balances of their addresses
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - transfers: a slice of GRC20 transfers to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertGrc20Transfers(
+	ctx context.Context,
+	transfers []sql_data_types.Grc20Transfer,
+) (err error) {
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin insert transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback insert transaction")
+			}
+		}
+	}()
+
+	if err = copyGrc20Transfers(ctx, txCopier(tx, t.insertMode), transfers); err != nil {
+		return err
+	}
+	tokens, addresses := grc20BalancePairs(transfers)
+	if err = refreshGrc20Balances(ctx, tx, transfers[0].ChainName, tokens, addresses); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// InsertNftTransfers inserts the GRC721 transfers and updates the owners of their tokens
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - transfers: a slice of GRC721 transfers to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertNftTransfers(
+	ctx context.Context,
+	transfers []sql_data_types.NftTransfer,
+) (err error) {
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin insert transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback insert transaction")
+			}
+		}
+	}()
+
+	if err = copyNftTransfers(ctx, txCopier(tx, t.insertMode), transfers); err != nil {
+		return err
+	}
+	collections, tokenIds := nftOwnerPairs(transfers)
+	if err = refreshNftOwners(ctx, tx, transfers[0].ChainName, collections, tokenIds); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// InsertNativeBalanceChanges inserts the native coin balance changes and updates the balances of their addresses
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - changes: a slice of balance changes to insert
+//
+// Returns:
+//   - error: an error if the insertion fails
+func (t *TimescaleDb) InsertNativeBalanceChanges(
+	ctx context.Context,
+	changes []sql_data_types.NativeBalanceChange,
+) (err error) {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin insert transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback insert transaction")
+			}
+		}
+	}()
+
+	if err = copyNativeBalanceChanges(ctx, txCopier(tx, t.insertMode), changes); err != nil {
+		return err
+	}
+	addresses, denoms := nativeBalancePairs(changes)
+	if err = refreshNativeBalances(ctx, tx, changes[0].ChainName, addresses, denoms); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// copier is implemented by both the pool and the transaction
+// so the same copy functions can be used for the direct and the chunk inserts
+type copier interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// copyBlocks copies the blocks to the blocks table using the given copier
+func copyBlocks(ctx context.Context, c copier, blocks []sql_data_types.Blocks) error {
 	// Return early if no blocks to insert
 	if len(blocks) == 0 {
 		return nil
@@ -74,32 +538,34 @@ func (t *TimescaleDb) InsertBlocks(ctx context.Context, blocks []sql_data_types.
 			blocks[i].Height,
 			blocks[i].Timestamp,
 			blocks[i].ChainID,
-			blocks[i].ChainName}, nil
+			blocks[i].ChainName,
+			blocks[i].Proposer,
+			blocks[i].NumTxs,
+			blocks[i].TotalTxs,
+			blocks[i].AppHash,
+			blocks[i].LastBlockHash,
+			blocks[i].LastCommitHash,
+			blocks[i].DataHash,
+			blocks[i].ValidatorsHash,
+			blocks[i].NextValidatorsHash,
+			blocks[i].ConsensusHash,
+			blocks[i].GasUsed,
+			blocks[i].GasWanted,
+		}, nil
 	})
 
 	// mark the columns to be inserted
 	columns := blocks[0].TableColumns()
 
 	// insert the data to the db
-	_, err := t.pool.CopyFrom(ctx, pgx.Identifier{"blocks"}, columns, pgxSlice)
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"blocks"}, columns, pgxSlice)
 	return err
 }
 
-// InsertValidatorBlockSignings inserts a slice of validator block signings into the database using pgx copy function
-// it will create the copy from slice to the db and then insert it to the database
-//
-// Usage:
-//
-// # Used for inserting a large number of validator block signings to the database
-//
-// Parameters:
-//   - ctx: the context to use for the insert
-//   - validatorBlockSigning: a slice of validator block signings to insert
-//
-// Returns:
-//   - error: an error if the insertion fails
-func (t *TimescaleDb) InsertValidatorBlockSignings(
+// copyValidatorBlockSignings copies the validator block signings to the validator_block_signing table
+func copyValidatorBlockSignings(
 	ctx context.Context,
+	c copier,
 	validatorBlockSigning []sql_data_types.ValidatorBlockSigning,
 ) error {
 	// Return early if no validator block signings to insert
@@ -117,29 +583,88 @@ func (t *TimescaleDb) InsertValidatorBlockSignings(
 			validatorBlockSigning[i].ChainName}, nil
 	})
 
-	// mark the columns to be inserted
-	columns := validatorBlockSigning[0].TableColumns()
-
-	// insert the data to the db
-	_, err := t.pool.CopyFrom(ctx, pgx.Identifier{"validator_block_signing"}, columns, pgxSlice)
+	// mark the columns to be inserted
+	columns := validatorBlockSigning[0].TableColumns()
+
+	// insert the data to the db
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"validator_block_signing"}, columns, pgxSlice)
+	return err
+}
+
+// copyValidatorSets copies the validator set members to the validator_set table
+func copyValidatorSets(ctx context.Context, c copier, validatorSets []sql_data_types.ValidatorSet) error {
+	if len(validatorSets) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(validatorSets), func(i int) ([]any, error) {
+		return []any{
+			validatorSets[i].BlockHeight,
+			validatorSets[i].Timestamp,
+			validatorSets[i].ChainName,
+			validatorSets[i].Validator,
+			validatorSets[i].ValidatorsHash,
+			validatorSets[i].VotingPower,
+			validatorSets[i].PubKeyType,
+			validatorSets[i].PubKey,
+		}, nil
+	})
+
+	columns := validatorSets[0].TableColumns()
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"validator_set"}, columns, pgxSlice)
+	return err
+}
+
+// copyValidatorUpdates copies the validator updates to the validator_updates table
+func copyValidatorUpdates(ctx context.Context, c copier, validatorUpdates []sql_data_types.ValidatorUpdate) error {
+	if len(validatorUpdates) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(validatorUpdates), func(i int) ([]any, error) {
+		return []any{
+			validatorUpdates[i].BlockHeight,
+			validatorUpdates[i].Timestamp,
+			validatorUpdates[i].ChainName,
+			validatorUpdates[i].Validator,
+			validatorUpdates[i].VotingPower,
+			validatorUpdates[i].PubKeyType,
+			validatorUpdates[i].PubKey,
+		}, nil
+	})
+
+	columns := validatorUpdates[0].TableColumns()
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"validator_updates"}, columns, pgxSlice)
+	return err
+}
+
+// copyBlockEvents copies the block events to the block_events table
+func copyBlockEvents(ctx context.Context, c copier, blockEvents []sql_data_types.BlockEvent) error {
+	if len(blockEvents) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(blockEvents), func(i int) ([]any, error) {
+		return []any{
+			blockEvents[i].BlockHeight,
+			blockEvents[i].Timestamp,
+			blockEvents[i].ChainName,
+			blockEvents[i].Phase,
+			makePgxArray(blockEvents[i].Events),
+			blockEvents[i].EventsCompressed,
+			blockEvents[i].CompressionOn,
+		}, nil
+	})
+
+	columns := blockEvents[0].TableColumns()
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"block_events"}, columns, pgxSlice)
 	return err
 }
 
-// InsertTransactionsGeneral inserts a slice of transaction general data into the database using pgx copy function
-// it will create the copy from slice to the db and then insert it to the database
-//
-// Usage:
-//
-// # Used for inserting a large number of transaction general data to the database
-//
-// Parameters:
-//   - ctx: the context to use for the insert
-//   - transactionsGeneral: a slice of transaction general data to insert
-//
-// Returns:
-//   - error: an error if the insertion fails
-func (t *TimescaleDb) InsertTransactionsGeneral(
+// copyTransactionsGeneral copies the transactions to the transaction_general table
+func copyTransactionsGeneral(
 	ctx context.Context,
+	c copier,
 	transactionsGeneral []sql_data_types.TransactionGeneral,
 ) error {
 	// Return early if no transactions to insert
@@ -161,6 +686,14 @@ func (t *TimescaleDb) InsertTransactionsGeneral(
 			transactionsGeneral[i].GasUsed,
 			transactionsGeneral[i].GasWanted,
 			transactionsGeneral[i].Fee,
+			transactionsGeneral[i].Success,
+			transactionsGeneral[i].TxError,
+			transactionsGeneral[i].TxLog,
+			transactionsGeneral[i].TxInfo,
+			transactionsGeneral[i].Memo,
+			makePgxArray(transactionsGeneral[i].Signers),
+			transactionsGeneral[i].TxIndex,
+			transactionsGeneral[i].GasPrice,
 		}, nil
 	})
 
@@ -168,19 +701,12 @@ func (t *TimescaleDb) InsertTransactionsGeneral(
 	columns := transactionsGeneral[0].TableColumns()
 
 	// insert the data to the db
-	_, err := t.pool.CopyFrom(ctx, pgx.Identifier{"transaction_general"}, columns, pgxSlice)
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"transaction_general"}, columns, pgxSlice)
 	return err
 }
 
-// InsertAddressTx inserts a slice of AddressTx into the database
-//
-// Parameters:
-//   - ctx: the context to use for the insert
-//   - addresses: a slice of AddressTx to insert
-//
-// Returns:
-//   - error: an error if the insertion fails
-func (t *TimescaleDb) InsertAddressTx(ctx context.Context, addresses []sql_data_types.AddressTx) error {
+// copyAddressTx copies the addresses to the address_tx table
+func copyAddressTx(ctx context.Context, c copier, addresses []sql_data_types.AddressTx) error {
 	// Return early if no addresses to insert
 	if len(addresses) == 0 {
 		return nil
@@ -197,19 +723,12 @@ func (t *TimescaleDb) InsertAddressTx(ctx context.Context, addresses []sql_data_
 	})
 
 	columns := addresses[0].TableColumns()
-	_, err := t.pool.CopyFrom(ctx, pgx.Identifier{"address_tx"}, columns, pgxSlice)
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"address_tx"}, columns, pgxSlice)
 	return err
 }
 
-// InsertMsgSend inserts a slice of MsgSend messages into the database
-//
-// Parameters:
-//   - ctx: the context to use for the insert
-//   - messages: a slice of MsgSend messages to insert
-//
-// Returns:
-//   - error: an error if the insertion fails
-func (t *TimescaleDb) InsertMsgSend(ctx context.Context, messages []sql_data_types.MsgSend) error {
+// copyMsgSend copies the messages to the bank_msg_send table
+func copyMsgSend(ctx context.Context, c copier, messages []sql_data_types.MsgSend) error {
 	// Return early if no messages to insert
 	if len(messages) == 0 {
 		return nil
@@ -229,19 +748,12 @@ func (t *TimescaleDb) InsertMsgSend(ctx context.Context, messages []sql_data_typ
 	})
 
 	columns := messages[0].TableColumns()
-	_, err := t.pool.CopyFrom(ctx, pgx.Identifier{"bank_msg_send"}, columns, pgxSlice)
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"bank_msg_send"}, columns, pgxSlice)
 	return err
 }
 
-// InsertMsgCall inserts a slice of MsgCall messages into the database
-//
-// Parameters:
-//   - ctx: the context to use for the insert
-//   - messages: a slice of MsgCall messages to insert
-//
-// Returns:
-//   - error: an error if the insertion fails
-func (t *TimescaleDb) InsertMsgCall(ctx context.Context, messages []sql_data_types.MsgCall) error {
+// copyMsgCall copies the messages to the vm_msg_call table
+func copyMsgCall(ctx context.Context, c copier, messages []sql_data_types.MsgCall) error {
 	// Return early if no messages to insert
 	if len(messages) == 0 {
 		return nil
@@ -255,7 +767,7 @@ func (t *TimescaleDb) InsertMsgCall(ctx context.Context, messages []sql_data_typ
 			messages[i].Caller,
 			messages[i].PkgPath,
 			messages[i].FuncName,
-			messages[i].Args,
+			makePgxArray(messages[i].Args),
 			makePgxArray(messages[i].Send),
 			makePgxArray(messages[i].MaxDeposit),
 			makePgxArray(messages[i].Signers),
@@ -264,22 +776,12 @@ func (t *TimescaleDb) InsertMsgCall(ctx context.Context, messages []sql_data_typ
 	})
 
 	columns := messages[0].TableColumns()
-	_, err := t.pool.CopyFrom(ctx, pgx.Identifier{"vm_msg_call"}, columns, pgxSlice)
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"vm_msg_call"}, columns, pgxSlice)
 	return err
 }
 
-// InsertMsgAddPackage inserts a slice of MsgAddPackage messages into the database
-//
-// Parameters:
-//   - ctx: the context to use for the insert
-//   - messages: a slice of MsgAddPackage messages to insert
-//
-// Returns:
-//   - error: an error if the insertion fails
-func (t *TimescaleDb) InsertMsgAddPackage(
-	ctx context.Context,
-	messages []sql_data_types.MsgAddPackage,
-) error {
+// copyMsgAddPackage copies the messages to the vm_msg_add_package table
+func copyMsgAddPackage(ctx context.Context, c copier, messages []sql_data_types.MsgAddPackage) error {
 	// Return early if no messages to insert
 	if len(messages) == 0 {
 		return nil
@@ -294,6 +796,7 @@ func (t *TimescaleDb) InsertMsgAddPackage(
 			messages[i].PkgPath,
 			messages[i].PkgName,
 			makePgxArray(messages[i].PkgFileNames),
+			makePgxArray(messages[i].PkgFileHashes),
 			makePgxArray(messages[i].Send),
 			makePgxArray(messages[i].MaxDeposit),
 			makePgxArray(messages[i].Signers),
@@ -302,22 +805,12 @@ func (t *TimescaleDb) InsertMsgAddPackage(
 	})
 
 	columns := messages[0].TableColumns()
-	_, err := t.pool.CopyFrom(ctx, pgx.Identifier{"vm_msg_add_package"}, columns, pgxSlice)
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"vm_msg_add_package"}, columns, pgxSlice)
 	return err
 }
 
-// InsertMsgRun inserts a slice of MsgRun messages into the database
-//
-// Parameters:
-//   - ctx: the context to use for the insert
-//   - messages: a slice of MsgRun messages to insert
-//
-// Returns:
-//   - error: an error if the insertion fails
-func (t *TimescaleDb) InsertMsgRun(
-	ctx context.Context,
-	messages []sql_data_types.MsgRun,
-) error {
+// copyMsgRun copies the messages to the vm_msg_run table
+func copyMsgRun(ctx context.Context, c copier, messages []sql_data_types.MsgRun) error {
 	// Return early if no messages to insert
 	if len(messages) == 0 {
 		return nil
@@ -332,6 +825,7 @@ func (t *TimescaleDb) InsertMsgRun(
 			messages[i].PkgPath,
 			messages[i].PkgName,
 			makePgxArray(messages[i].PkgFileNames),
+			makePgxArray(messages[i].PkgFileHashes),
 			makePgxArray(messages[i].Send),
 			makePgxArray(messages[i].MaxDeposit),
 			makePgxArray(messages[i].Signers),
@@ -340,10 +834,442 @@ func (t *TimescaleDb) InsertMsgRun(
 	})
 
 	columns := messages[0].TableColumns()
-	_, err := t.pool.CopyFrom(ctx, pgx.Identifier{"vm_msg_run"}, columns, pgxSlice)
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"vm_msg_run"}, columns, pgxSlice)
+	return err
+}
+
+// copyMsgGeneric copies the messages to the msg_generic table
+func copyMsgGeneric(ctx context.Context, c copier, messages []sql_data_types.MsgGeneric) error {
+	// Return early if no messages to insert
+	if len(messages) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(messages), func(i int) ([]any, error) {
+		return []any{
+			messages[i].TxHash,
+			messages[i].Timestamp,
+			messages[i].ChainName,
+			messages[i].TypeUrl,
+			messages[i].Payload,
+			makePgxArray(messages[i].Addresses),
+			makePgxArray(messages[i].Signers),
+			messages[i].MessageCounter,
+		}, nil
+	})
+
+	columns := messages[0].TableColumns()
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"msg_generic"}, columns, pgxSlice)
+	return err
+}
+
+func copyTxEvents(ctx context.Context, c copier, events []sql_data_types.TxEvent) error {
+	// Return early if no events to insert
+	if len(events) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(events), func(i int) ([]any, error) {
+		return []any{
+			events[i].TxHash,
+			events[i].ChainName,
+			events[i].Timestamp,
+			events[i].EventIndex,
+			events[i].AttrIndex,
+			events[i].BlockHeight,
+			events[i].EventType,
+			events[i].AtType,
+			events[i].PkgPath,
+			events[i].AttrKey,
+			events[i].AttrValue,
+		}, nil
+	})
+
+	columns := events[0].TableColumns()
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"tx_events"}, columns, pgxSlice)
+	return err
+}
+
+// copyTxSignatures copies the transaction signatures to the tx_signatures table
+func copyTxSignatures(ctx context.Context, c copier, signatures []sql_data_types.TxSignature) error {
+	// Return early if no signatures to insert
+	if len(signatures) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(signatures), func(i int) ([]any, error) {
+		return []any{
+			signatures[i].TxHash,
+			signatures[i].ChainName,
+			signatures[i].Timestamp,
+			signatures[i].SignatureIndex,
+			signatures[i].BlockHeight,
+			signatures[i].Signer,
+			signatures[i].PubKeyType,
+			signatures[i].PubKey,
+			signatures[i].Signature,
+			signatures[i].AccountNumber,
+			signatures[i].Sequence,
+		}, nil
+	})
+
+	columns := signatures[0].TableColumns()
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"tx_signatures"}, columns, pgxSlice)
+	return err
+}
+
+// addressPubKeys returns the signers with the public key from the first of their signatures that includes it
+func addressPubKeys(signatures []sql_data_types.TxSignature) ([]int32, []string, [][]byte) {
+	seen := make(map[int32]struct{})
+	signers := make([]int32, 0)
+	types := make([]string, 0)
+	keys := make([][]byte, 0)
+	for _, signature := range signatures {
+		if signature.Signer == nil || signature.PubKeyType == nil || signature.PubKey == nil {
+			continue
+		}
+		if _, ok := seen[*signature.Signer]; ok {
+			continue
+		}
+		seen[*signature.Signer] = struct{}{}
+		signers = append(signers, *signature.Signer)
+		types = append(types, *signature.PubKeyType)
+		keys = append(keys, signature.PubKey)
+	}
+	return signers, types, keys
+}
+
+// fillAddressPubKeys sets the public key of the signers that don't have it yet
+//
+// The address is derived from the public key, so any signature with the key gives the same one
+// and the chunks can be indexed in any order.
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - tx: the transaction the signatures were written with
+//   - signatures: the written signatures
+//
+// Returns:
+//   - error: if the query fails
+func fillAddressPubKeys(ctx context.Context, tx pgx.Tx, signatures []sql_data_types.TxSignature) error {
+	signers, types, keys := addressPubKeys(signatures)
+	if len(signers) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+	UPDATE gno_addresses a
+	SET pub_key_type = p.pub_key_type, pub_key = p.pub_key
+	FROM unnest($2::INTEGER[], $3::TEXT[], $4::BYTEA[]) AS p(id, pub_key_type, pub_key)
+	WHERE a.chain_name = $1
+	AND a.id = p.id
+	AND a.pub_key IS NULL
+	`, signatures[0].ChainName, signers, types, keys)
+	if err != nil {
+		return fmt.Errorf("failed to fill the address public keys: %w", err)
+	}
+	return nil
+}
+
+// copyPackageFiles copies the package files to the package_files table
+//
+// The same file can be deployed many times so the rows always go through the temporary table
+// and the files that already exist are skipped.
+func copyPackageFiles(ctx context.Context, tx pgx.Tx, files []sql_data_types.PackageFile) error {
+	// Return early if no files to insert
+	if len(files) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(files), func(i int) ([]any, error) {
+		return []any{
+			files[i].FileHash,
+			files[i].ChainName,
+			files[i].Size,
+			files[i].Content,
+		}, nil
+	})
+
+	columns := files[0].TableColumns()
+	c := &upsertCopier{tx: tx, mode: InsertModeSkip}
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"package_files"}, columns, pgxSlice)
+	return err
+}
+
+// copyPackages copies the packages to the packages table
+//
+// The chunks can be indexed in any order, so the rows go through the temporary table and
+// the existing package is overwritten only by an earlier or the same deployment.
+func copyPackages(ctx context.Context, tx pgx.Tx, packages []sql_data_types.Package) error {
+	// Return early if no packages to insert
+	if len(packages) == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `DROP TABLE IF EXISTS tmp_packages`); err != nil {
+		return fmt.Errorf("failed to drop temporary table tmp_packages: %w", err)
+	}
+	if _, err := tx.Exec(ctx,
+		`CREATE TEMP TABLE tmp_packages (LIKE packages INCLUDING DEFAULTS) ON COMMIT DROP`,
+	); err != nil {
+		return fmt.Errorf("failed to create temporary table tmp_packages: %w", err)
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(packages), func(i int) ([]any, error) {
+		return []any{
+			packages[i].PkgPath,
+			packages[i].ChainName,
+			packages[i].PkgName,
+			packages[i].Creator,
+			packages[i].FirstHeight,
+			packages[i].FirstTimestamp,
+			packages[i].TxHash,
+			packages[i].FileCount,
+		}, nil
+	})
+	columns := packages[0].TableColumns()
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"tmp_packages"}, columns, pgxSlice); err != nil {
+		return fmt.Errorf("failed to copy to temporary table tmp_packages: %w", err)
+	}
+
+	// the same package can be deployed more than once within a chunk, only the earliest one is kept
+	_, err := tx.Exec(ctx, `
+	INSERT INTO packages (pkg_path, chain_name, pkg_name, creator, first_height, first_timestamp, tx_hash, file_count)
+	SELECT DISTINCT ON (pkg_path, chain_name)
+	pkg_path, chain_name, pkg_name, creator, first_height, first_timestamp, tx_hash, file_count
+	FROM tmp_packages
+	ORDER BY pkg_path, chain_name, first_height
+	ON CONFLICT (pkg_path, chain_name) DO UPDATE SET
+	pkg_name = EXCLUDED.pkg_name,
+	creator = EXCLUDED.creator,
+	first_height = EXCLUDED.first_height,
+	first_timestamp = EXCLUDED.first_timestamp,
+	tx_hash = EXCLUDED.tx_hash,
+	file_count = EXCLUDED.file_count
+	WHERE EXCLUDED.first_height <= packages.first_height
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to insert rows to packages: %w", err)
+	}
+	return nil
+}
+
+func copyGrc20Transfers(ctx context.Context, c copier, transfers []sql_data_types.Grc20Transfer) error {
+	// Return early if no transfers to insert
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(transfers), func(i int) ([]any, error) {
+		return []any{
+			transfers[i].TxHash,
+			transfers[i].ChainName,
+			transfers[i].Timestamp,
+			transfers[i].EventIndex,
+			transfers[i].BlockHeight,
+			transfers[i].TokenPath,
+			transfers[i].EventType,
+			transfers[i].FromAddress,
+			transfers[i].ToAddress,
+			transfers[i].Amount,
+		}, nil
+	})
+
+	columns := transfers[0].TableColumns()
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"grc20_transfers"}, columns, pgxSlice)
+	return err
+}
+
+// grc20BalancePairs returns the unique token and address pairs touched by the transfers
+// as two slices of the same length, the mints and the burns only touch one address
+func grc20BalancePairs(transfers []sql_data_types.Grc20Transfer) ([]string, []int32) {
+	type pair struct {
+		token   string
+		address int32
+	}
+	seen := make(map[pair]struct{})
+	tokens := make([]string, 0)
+	addresses := make([]int32, 0)
+	for _, transfer := range transfers {
+		for _, address := range []*int32{transfer.FromAddress, transfer.ToAddress} {
+			if address == nil {
+				continue
+			}
+			p := pair{token: transfer.TokenPath, address: *address}
+			if _, ok := seen[p]; ok {
+				continue
+			}
+			seen[p] = struct{}{}
+			tokens = append(tokens, p.token)
+			addresses = append(addresses, p.address)
+		}
+	}
+	return tokens, addresses
+}
+
+// refreshGrc20Balances calculates the balances of the given token and address pairs again
+//
+// The balance is the sum of every stored transfer to the address minus the transfers from it,
+// so it stays correct when the chunks are indexed out of order, indexed again or rewound.
+// The pairs need to be unique, a pair given twice would count its transfers twice.
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - tx: the transaction the transfers were written with
+//   - chainName: the name of the chain
+//   - tokens: the token paths of the pairs
+//   - addresses: the address ids of the pairs, at the same index as their token
+//
+// Returns:
+//   - error: if any of the queries fails
+func refreshGrc20Balances(
+	ctx context.Context,
+	tx pgx.Tx,
+	chainName string,
+	tokens []string,
+	addresses []int32,
+) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+	DELETE FROM grc20_balances b
+	USING unnest($2::TEXT[], $3::INTEGER[]) AS p(token_path, address)
+	WHERE b.chain_name = $1
+	AND b.token_path = p.token_path
+	AND b.address = p.address
+	`, chainName, tokens, addresses)
+	if err != nil {
+		return fmt.Errorf("failed to clear grc20 balances: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+	INSERT INTO grc20_balances (token_path, chain_name, address, balance, last_height)
+	SELECT d.token_path, d.chain_name, d.address, SUM(d.delta), MAX(d.block_height)
+	FROM (
+		SELECT tr.token_path, tr.chain_name, tr.to_address AS address, tr.amount AS delta, tr.block_height
+		FROM grc20_transfers tr
+		JOIN unnest($2::TEXT[], $3::INTEGER[]) AS p(token_path, address)
+		ON tr.token_path = p.token_path AND tr.to_address = p.address
+		WHERE tr.chain_name = $1
+		UNION ALL
+		SELECT tr.token_path, tr.chain_name, tr.from_address AS address, -tr.amount AS delta, tr.block_height
+		FROM grc20_transfers tr
+		JOIN unnest($2::TEXT[], $3::INTEGER[]) AS p(token_path, address)
+		ON tr.token_path = p.token_path AND tr.from_address = p.address
+		WHERE tr.chain_name = $1
+	) d
+	GROUP BY d.token_path, d.chain_name, d.address
+	`, chainName, tokens, addresses)
+	if err != nil {
+		return fmt.Errorf("failed to calculate grc20 balances: %w", err)
+	}
+	return nil
+}
+
+func copyNftTransfers(ctx context.Context, c copier, transfers []sql_data_types.NftTransfer) error {
+	// Return early if no transfers to insert
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(transfers), func(i int) ([]any, error) {
+		return []any{
+			transfers[i].TxHash,
+			transfers[i].ChainName,
+			transfers[i].Timestamp,
+			transfers[i].EventIndex,
+			transfers[i].BlockHeight,
+			transfers[i].CollectionPath,
+			transfers[i].Symbol,
+			transfers[i].TokenId,
+			transfers[i].EventType,
+			transfers[i].FromAddress,
+			transfers[i].ToAddress,
+		}, nil
+	})
+
+	columns := transfers[0].TableColumns()
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"nft_transfers"}, columns, pgxSlice)
 	return err
 }
 
+// nftOwnerPairs returns the unique collection and token id pairs of the transfers
+// as two slices of the same length
+func nftOwnerPairs(transfers []sql_data_types.NftTransfer) ([]string, []string) {
+	type pair struct {
+		collection string
+		tokenId    string
+	}
+	seen := make(map[pair]struct{})
+	collections := make([]string, 0)
+	tokenIds := make([]string, 0)
+	for _, transfer := range transfers {
+		p := pair{collection: transfer.CollectionPath, tokenId: transfer.TokenId}
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		collections = append(collections, p.collection)
+		tokenIds = append(tokenIds, p.tokenId)
+	}
+	return collections, tokenIds
+}
+
+// refreshNftOwners sets the owner of the given tokens from their latest stored transfer
+//
+// The latest transfer is the one with the highest height and event index, so the owner stays
+// correct when the chunks are indexed out of order, indexed again or rewound. The transfers of
+// the same token in two transactions of the same block are ordered by the tx hash.
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - tx: the transaction the transfers were written with
+//   - chainName: the name of the chain
+//   - collections: the collection paths of the tokens
+//   - tokenIds: the token ids, at the same index as their collection
+//
+// Returns:
+//   - error: if any of the queries fails
+func refreshNftOwners(
+	ctx context.Context,
+	tx pgx.Tx,
+	chainName string,
+	collections []string,
+	tokenIds []string,
+) error {
+	if len(collections) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+	DELETE FROM nft_owners o
+	USING unnest($2::TEXT[], $3::TEXT[]) AS p(collection_path, token_id)
+	WHERE o.chain_name = $1
+	AND o.collection_path = p.collection_path
+	AND o.token_id = p.token_id
+	`, chainName, collections, tokenIds)
+	if err != nil {
+		return fmt.Errorf("failed to clear nft owners: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+	INSERT INTO nft_owners (collection_path, chain_name, token_id, owner, last_height, last_timestamp, tx_hash)
+	SELECT DISTINCT ON (nt.collection_path, nt.token_id)
+	nt.collection_path, nt.chain_name, nt.token_id, nt.to_address, nt.block_height, nt.timestamp, nt.tx_hash
+	FROM nft_transfers nt
+	JOIN unnest($2::TEXT[], $3::TEXT[]) AS p(collection_path, token_id)
+	ON nt.collection_path = p.collection_path AND nt.token_id = p.token_id
+	WHERE nt.chain_name = $1
+	ORDER BY nt.collection_path, nt.token_id, nt.block_height DESC, nt.tx_hash DESC, nt.event_index DESC
+	`, chainName, collections, tokenIds)
+	if err != nil {
+		return fmt.Errorf("failed to find nft owners: %w", err)
+	}
+	return nil
+}
+
 // makePgxArray is a helper generic function to create a pgx array from a slice
 //
 // In theory it should be similar to pq.Array i think, it should be used for the some composite types and
@@ -366,3 +1292,165 @@ func makePgxArray[T any](v []T) pgtype.Array[T] {
 		Valid:    true,
 	}
 }
+
+func copyNativeBalanceChanges(ctx context.Context, c copier, changes []sql_data_types.NativeBalanceChange) error {
+	// Return early if no changes to insert
+	if len(changes) == 0 {
+		return nil
+	}
+
+	pgxSlice := pgx.CopyFromSlice(len(changes), func(i int) ([]any, error) {
+		return []any{
+			changes[i].TxHash,
+			changes[i].ChainName,
+			changes[i].Timestamp,
+			changes[i].ChangeIndex,
+			changes[i].BlockHeight,
+			changes[i].Address,
+			changes[i].Denom,
+			changes[i].Amount,
+			changes[i].Source,
+		}, nil
+	})
+
+	columns := changes[0].TableColumns()
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"native_balance_changes"}, columns, pgxSlice)
+	return err
+}
+
+// nativeBalancePairs returns the unique address and denom pairs of the balance changes
+// as two slices of the same length
+func nativeBalancePairs(changes []sql_data_types.NativeBalanceChange) ([]int32, []string) {
+	type pair struct {
+		address int32
+		denom   string
+	}
+	seen := make(map[pair]struct{})
+	addresses := make([]int32, 0)
+	denoms := make([]string, 0)
+	for _, change := range changes {
+		p := pair{address: change.Address, denom: change.Denom}
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		addresses = append(addresses, p.address)
+		denoms = append(denoms, p.denom)
+	}
+	return addresses, denoms
+}
+
+// refreshNativeBalances calculates the native balances of the given address and denom pairs again
+//
+// The balance is the sum of every stored change of the address, so it stays correct when the
+// chunks are indexed out of order, indexed again or rewound.
+// The pairs need to be unique, a pair given twice would count its changes twice.
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - tx: the transaction the changes were written with
+//   - chainName: the name of the chain
+//   - addresses: the address ids of the pairs
+//   - denoms: the denoms of the pairs, at the same index as their address
+//
+// Returns:
+//   - error: if any of the queries fails
+func refreshNativeBalances(
+	ctx context.Context,
+	tx pgx.Tx,
+	chainName string,
+	addresses []int32,
+	denoms []string,
+) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+	DELETE FROM native_balances b
+	USING unnest($2::INTEGER[], $3::TEXT[]) AS p(address, denom)
+	WHERE b.chain_name = $1
+	AND b.address = p.address
+	AND b.denom = p.denom
+	`, chainName, addresses, denoms)
+	if err != nil {
+		return fmt.Errorf("failed to clear native balances: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+	INSERT INTO native_balances (address, chain_name, denom, balance, last_height)
+	SELECT c.address, c.chain_name, c.denom, SUM(c.amount), MAX(c.block_height)
+	FROM native_balance_changes c
+	JOIN unnest($2::INTEGER[], $3::TEXT[]) AS p(address, denom)
+	ON c.address = p.address AND c.denom = p.denom
+	WHERE c.chain_name = $1
+	GROUP BY c.address, c.chain_name, c.denom
+	`, chainName, addresses, denoms)
+	if err != nil {
+		return fmt.Errorf("failed to calculate native balances: %w", err)
+	}
+	return nil
+}
+
+// signingHeights returns the heights of the validator block signings
+func signingHeights(signings []sql_data_types.ValidatorBlockSigning) []int64 {
+	heights := make([]int64, 0, len(signings))
+	for _, signing := range signings {
+		heights = append(heights, int64(signing.BlockHeight))
+	}
+	return heights
+}
+
+// refreshMissedBlocks calculates the missed blocks of the given heights again
+//
+// A validator missed a block when it is a member of the latest validator set stored at or
+// below the height and it is not in the signed validators of the height. The set of a height
+// needs to be stored before its signing, otherwise the height is compared with an older set.
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - tx: the transaction the signings were written with
+//   - chainName: the name of the chain
+//   - heights: the heights of the signings
+//
+// Returns:
+//   - error: if any of the queries fails
+func refreshMissedBlocks(ctx context.Context, tx pgx.Tx, chainName string, heights []int64) error {
+	if len(heights) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+	DELETE FROM validator_missed_blocks
+	WHERE chain_name = $1
+	AND block_height = ANY($2::BIGINT[])
+	`, chainName, heights)
+	if err != nil {
+		return fmt.Errorf("failed to clear missed blocks: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+	INSERT INTO validator_missed_blocks (block_height, timestamp, chain_name, validator)
+	SELECT s.block_height, s.timestamp, s.chain_name, vs.validator
+	FROM validator_block_signing s
+	CROSS JOIN LATERAL (
+		SELECT block_height, timestamp
+		FROM validator_set
+		WHERE chain_name = s.chain_name
+		AND block_height <= s.block_height
+		ORDER BY block_height DESC
+		LIMIT 1
+	) latest
+	JOIN validator_set vs
+	ON vs.chain_name = s.chain_name
+	AND vs.block_height = latest.block_height
+	AND vs.timestamp = latest.timestamp
+	WHERE s.chain_name = $1
+	AND s.block_height = ANY($2::BIGINT[])
+	AND NOT vs.validator = ANY(s.signed_vals)
+	`, chainName, heights)
+	if err != nil {
+		return fmt.Errorf("failed to calculate missed blocks: %w", err)
+	}
+	return nil
+}
@@ -299,6 +299,42 @@ func (t *TimescaleDb) execAccQuery(
 	return &addressTxs, nil
 }
 
+// GetAddress gets an address with its public key
+//
+// Usage:
+//
+// # Used to get the public key of an address, it is known once a signature of the address included it
+//
+// Parameters:
+//   - address: the address
+//   - chainName: the name of the chain
+//
+// Returns:
+//   - *AddressInfo: the address with its public key
+//   - error: if the address is not stored or the query fails
+func (t *TimescaleDb) GetAddress(
+	ctx context.Context,
+	address string,
+	chainName string,
+) (*AddressInfo, error) {
+	query := `
+	SELECT
+	address,
+	COALESCE(pub_key_type, '') AS pub_key_type,
+	COALESCE(encode(pub_key, 'base64'), '') AS pub_key
+	FROM gno_addresses
+	WHERE address = $1
+	AND chain_name = $2
+	`
+	row := t.pool.QueryRow(ctx, query, address, chainName)
+	addressInfo := &AddressInfo{}
+	err := row.Scan(&addressInfo.Address, &addressInfo.PubKeyType, &addressInfo.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	return addressInfo, nil
+}
+
 func (t *TimescaleDb) getAccountId(
 	ctx context.Context,
 	address string,
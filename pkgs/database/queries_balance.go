@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+)
+
+// GetChangedNativeBalances gets a page of the native balances of the addresses changed within the height range
+//
+// Usage:
+//
+// # Used by the orchestrator to reconcile the indexed balances with the balances of the node
+//
+// The balances are summed from the changes up to the end of the range, so they can be compared with
+// the node queried at the same height. The addresses are ordered by their id, the next page starts
+// after the id of the last address of the previous page.
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - chainName: the name of the chain
+//   - fromHeight: the start height (exclusive)
+//   - toHeight: the end height (inclusive)
+//   - afterAddressId: the id of the last address of the previous page, 0 for the first page
+//   - limit: the maximum amount of addresses
+//
+// Returns:
+//   - []AddressBalance: the balances of the changed addresses
+//   - error: if the query fails
+func (t *TimescaleDb) GetChangedNativeBalances(
+	ctx context.Context,
+	chainName string,
+	fromHeight uint64,
+	toHeight uint64,
+	afterAddressId int32,
+	limit uint64,
+) ([]AddressBalance, error) {
+	rows, err := t.pool.Query(ctx, `
+	WITH changed AS (
+		SELECT DISTINCT address
+		FROM native_balances
+		WHERE chain_name = $1
+		AND last_height > $2
+		AND last_height <= $3
+		AND address > $4
+		ORDER BY address
+		LIMIT $5
+	)
+	SELECT a.id, a.address, c.denom, SUM(c.amount)::TEXT
+	FROM changed ch
+	JOIN gno_addresses a ON a.id = ch.address AND a.chain_name = $1
+	JOIN native_balance_changes c ON c.address = ch.address AND c.chain_name = $1 AND c.block_height <= $3
+	GROUP BY a.id, a.address, c.denom
+	ORDER BY a.id
+	`, chainName, fromHeight, toHeight, afterAddressId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed native balances: %w", err)
+	}
+	defer rows.Close()
+
+	balances := make([]AddressBalance, 0)
+	for rows.Next() {
+		var id int32
+		var address, denom, amount string
+		if err := rows.Scan(&id, &address, &denom, &amount); err != nil {
+			return nil, fmt.Errorf("failed to get changed native balances: %w", err)
+		}
+		value, ok := new(big.Int).SetString(amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid native balance %s of %s", amount, address)
+		}
+		if n := len(balances); n == 0 || balances[n-1].AddressId != id {
+			balances = append(balances, AddressBalance{AddressId: id, Address: address, Balances: make(map[string]*big.Int)})
+		}
+		balances[len(balances)-1].Balances[denom] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get changed native balances: %w", err)
+	}
+	return balances, nil
+}
+
+// InsertNativeBalanceAdjustments stores the corrections found by the reconciliation at the given height
+//
+// The adjustments get the timestamp of the block at the height and they are added to the balances
+// like any other change, the rewind below the height removes them.
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - chainName: the name of the chain
+//   - height: the height the balances were compared at
+//   - adjustments: the differences between the node and the indexed balances
+//
+// Returns:
+//   - error: if the block is not stored or the insert fails
+func (t *TimescaleDb) InsertNativeBalanceAdjustments(
+	ctx context.Context,
+	chainName string,
+	height uint64,
+	adjustments []sql_data_types.NativeBalanceChange,
+) error {
+	if len(adjustments) == 0 {
+		return nil
+	}
+	var timestamp time.Time
+	err := t.pool.QueryRow(ctx, `
+	SELECT timestamp
+	FROM blocks
+	WHERE chain_name = $1
+	AND height = $2
+	`, chainName, height).Scan(&timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to get the timestamp of block %d: %w", height, err)
+	}
+	for i := range adjustments {
+		adjustments[i].Timestamp = timestamp
+	}
+	return t.InsertNativeBalanceChanges(ctx, adjustments)
+}
@@ -2,6 +2,9 @@ package database
 
 import (
 	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
 )
 
 // FindExistingAccounts finds the existing accounts in the database
@@ -184,3 +187,150 @@ func (t *TimescaleDb) GetLastBlockHeight(ctx context.Context, chainName string)
 	}
 	return lastBlockHeight, nil
 }
+
+// GetBlockHashes gets the stored block hashes for a range of heights for a given chain
+//
+// Usage:
+//
+// # Used by the orchestrator to check if the new blocks link to the stored chain
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - chainName: the name of the chain
+//   - fromHeight: the start height (inclusive)
+//   - toHeight: the end height (inclusive)
+//
+// Returns:
+//   - map[uint64][]byte: the map of heights and their hashes, missing heights are not in the map
+//   - error: if the query fails
+func (t *TimescaleDb) GetBlockHashes(
+	ctx context.Context,
+	chainName string,
+	fromHeight uint64,
+	toHeight uint64,
+) (map[uint64][]byte, error) {
+	query := `
+	SELECT height, hash
+	FROM blocks
+	WHERE chain_name = $1
+	AND height BETWEEN $2 AND $3
+	`
+	rows, err := t.pool.Query(ctx, query, chainName, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := make(map[uint64][]byte)
+	for rows.Next() {
+		var height uint64
+		var hash []byte
+		if err := rows.Scan(&height, &hash); err != nil {
+			return nil, err
+		}
+		hashes[height] = hash
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// GetValidatorsHash gets the validators hash of the latest stored validator set at or below a height
+//
+// Usage:
+//
+// # Used by the orchestrator to know if the first block of a chunk changed the validator set
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - chainName: the name of the chain
+//   - height: the height to look from
+//
+// Returns:
+//   - []byte: the validators hash, nil if no set is stored at or below the height
+//   - error: if the query fails
+func (t *TimescaleDb) GetValidatorsHash(ctx context.Context, chainName string, height uint64) ([]byte, error) {
+	query := `
+	SELECT validators_hash
+	FROM validator_set
+	WHERE chain_name = $1
+	AND block_height <= $2
+	ORDER BY block_height DESC
+	LIMIT 1
+	`
+	var hash []byte
+	err := t.pool.QueryRow(ctx, query, chainName, height).Scan(&hash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return hash, nil
+}
+
+// GetMissingHeightRanges finds the heights that are missing from the blocks or the
+// validator_block_signing table for a given chain and groups them into continuous ranges
+//
+// Usage:
+//
+// # Used by the orchestrator to find and backfill the gaps left by the failed chunks
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - chainName: the name of the chain
+//   - fromHeight: the start height (inclusive)
+//   - toHeight: the end height (inclusive)
+//
+// Returns:
+//   - []HeightRange: the missing ranges ordered by height
+//   - error: if the query fails
+func (t *TimescaleDb) GetMissingHeightRanges(
+	ctx context.Context,
+	chainName string,
+	fromHeight uint64,
+	toHeight uint64,
+) ([]HeightRange, error) {
+	query := `
+	WITH missing AS (
+		SELECT s.height
+		FROM generate_series($2::bigint, $3::bigint) AS s(height)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM blocks b
+			WHERE b.chain_name = $1
+			AND b.height = s.height
+		)
+		OR NOT EXISTS (
+			SELECT 1 FROM validator_block_signing v
+			WHERE v.chain_name = $1
+			AND v.block_height = s.height
+		)
+	)
+	SELECT MIN(height), MAX(height)
+	FROM (
+		SELECT height, height - ROW_NUMBER() OVER (ORDER BY height) AS grp
+		FROM missing
+	) grouped
+	GROUP BY grp
+	ORDER BY MIN(height)
+	`
+	rows, err := t.pool.Query(ctx, query, chainName, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	gaps := make([]HeightRange, 0)
+	for rows.Next() {
+		var gap HeightRange
+		if err := rows.Scan(&gap.FromHeight, &gap.ToHeight); err != nil {
+			return nil, err
+		}
+		gaps = append(gaps, gap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return gaps, nil
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+
+	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/sql_data_types"
+)
+
+// InsertIndexerProgress records a range of blocks that was fully processed
+//
+// Usage:
+//
+// # Used by the orchestrator after every chunk that was processed without errors
+//
+// If the range directly continues a range already recorded for the same chain and mode
+// the existing range is extended, otherwise a new range is inserted. This keeps the
+// table small in live mode where every chunk is usually only a block or two.
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - chainName: the name of the chain
+//   - runningMode: the running mode of the indexer (live or historic)
+//   - fromHeight: the start height of the range (inclusive)
+//   - toHeight: the end height of the range (inclusive)
+//
+// Returns:
+//   - error: if the query fails
+func (t *TimescaleDb) InsertIndexerProgress(
+	ctx context.Context,
+	chainName string,
+	runningMode string,
+	fromHeight uint64,
+	toHeight uint64,
+) error {
+	extend := `
+	UPDATE indexer_progress
+	SET to_height = $4, completed_at = now()
+	WHERE chain_name = $1
+	AND running_mode = $2
+	AND to_height = $3 - 1
+	`
+	tag, err := t.pool.Exec(ctx, extend, chainName, runningMode, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	insert := `
+	INSERT INTO indexer_progress (chain_name, running_mode, from_height, to_height, completed_at)
+	VALUES ($1, $2, $3, $4, now())
+	ON CONFLICT (chain_name, running_mode, from_height)
+	DO UPDATE SET
+		to_height = GREATEST(indexer_progress.to_height, EXCLUDED.to_height),
+		completed_at = EXCLUDED.completed_at
+	`
+	_, err = t.pool.Exec(ctx, insert, chainName, runningMode, fromHeight, toHeight)
+	return err
+}
+
+// GetIndexerProgress gets the processed ranges that overlap the given heights
+//
+// Usage:
+//
+// # Used by the orchestrator to resume the historic process
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - chainName: the name of the chain
+//   - runningMode: the running mode of the indexer (live or historic)
+//   - fromHeight: the start height (inclusive)
+//   - toHeight: the end height (inclusive)
+//
+// Returns:
+//   - []sql_data_types.IndexerProgress: the processed ranges ordered by the start height
+//   - error: if the query fails
+func (t *TimescaleDb) GetIndexerProgress(
+	ctx context.Context,
+	chainName string,
+	runningMode string,
+	fromHeight uint64,
+	toHeight uint64,
+) ([]sql_data_types.IndexerProgress, error) {
+	query := `
+	SELECT chain_name, running_mode, from_height, to_height, completed_at
+	FROM indexer_progress
+	WHERE chain_name = $1
+	AND running_mode = $2
+	AND from_height <= $4
+	AND to_height >= $3
+	ORDER BY from_height ASC
+	`
+	rows, err := t.pool.Query(ctx, query, chainName, runningMode, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progress := make([]sql_data_types.IndexerProgress, 0)
+	for rows.Next() {
+		var p sql_data_types.IndexerProgress
+		if err := rows.Scan(&p.ChainName, &p.RunningMode, &p.FromHeight, &p.ToHeight, &p.CompletedAt); err != nil {
+			return nil, err
+		}
+		progress = append(progress, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
@@ -3,8 +3,10 @@ package database
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	dictloader "github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/dict_loader"
 	"github.com/Cogwheel-Validator/spectra-gnoland-indexer/pkgs/events_proto"
@@ -52,7 +54,20 @@ func (t *TimescaleDb) GetTransaction(ctx context.Context, txHash string, chainNa
 	tx.gas_used,
 	tx.gas_wanted,
 	tx.fee,
-	tx.msg_types
+	tx.msg_types,
+	tx.success,
+	COALESCE(tx.tx_error, '') AS tx_error,
+	COALESCE(tx.tx_log, '') AS tx_log,
+	COALESCE(tx.tx_info, '') AS tx_info,
+	COALESCE(tx.memo, '') AS memo,
+	array(
+		SELECT gn.address
+		FROM unnest(tx.signers) WITH ORDINALITY AS s(id, ord)
+		JOIN gno_addresses gn ON gn.id = s.id
+		ORDER BY s.ord
+	) AS signers,
+	COALESCE(tx.tx_index, 0) AS tx_index,
+	tx.gas_price
 	FROM transaction_general tx
 	WHERE tx.tx_hash = decode($1, 'base64')
 	AND tx.chain_name = $2
@@ -70,6 +85,14 @@ func (t *TimescaleDb) GetTransaction(ctx context.Context, txHash string, chainNa
 		&transaction.GasWanted,
 		&transaction.Fee,
 		&transaction.MsgTypes,
+		&transaction.Success,
+		&transaction.TxError,
+		&transaction.TxLog,
+		&transaction.TxInfo,
+		&transaction.Memo,
+		&transaction.Signers,
+		&transaction.TxIndex,
+		&transaction.GasPrice,
 	)
 	if err != nil {
 		log.Println("error getting transaction", err)
@@ -91,7 +114,13 @@ func (t *TimescaleDb) GetTransaction(ctx context.Context, txHash string, chainNa
 // Parameters:
 //   - chainName: the name of the chain
 //   - x: the number of transactions to get
-func (t *TimescaleDb) GetLastXTransactions(ctx context.Context, chainName string, x uint64) ([]*Transaction, error) {
+//   - failedOnly: if true only the transactions that failed to execute are returned
+func (t *TimescaleDb) GetLastXTransactions(
+	ctx context.Context,
+	chainName string,
+	x uint64,
+	failedOnly bool,
+) ([]*Transaction, error) {
 	query := `
 	SELECT
 	encode(tx.tx_hash, 'base64') AS tx_hash,
@@ -103,13 +132,27 @@ func (t *TimescaleDb) GetLastXTransactions(ctx context.Context, chainName string
 	tx.gas_used,
 	tx.gas_wanted,
 	tx.fee,
-	tx.msg_types
+	tx.msg_types,
+	tx.success,
+	COALESCE(tx.tx_error, '') AS tx_error,
+	COALESCE(tx.tx_log, '') AS tx_log,
+	COALESCE(tx.tx_info, '') AS tx_info,
+	COALESCE(tx.memo, '') AS memo,
+	array(
+		SELECT gn.address
+		FROM unnest(tx.signers) WITH ORDINALITY AS s(id, ord)
+		JOIN gno_addresses gn ON gn.id = s.id
+		ORDER BY s.ord
+	) AS signers,
+	COALESCE(tx.tx_index, 0) AS tx_index,
+	tx.gas_price
 	FROM transaction_general tx
 	WHERE tx.chain_name = $1
-	ORDER BY tx.timestamp DESC
+	AND ($3 = false OR tx.success = false)
+	ORDER BY tx.block_height DESC, COALESCE(tx.tx_index, 0) DESC, tx.tx_hash DESC
 	LIMIT $2
 	`
-	rows, err := t.pool.Query(ctx, query, chainName, x)
+	rows, err := t.pool.Query(ctx, query, chainName, x, failedOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +170,16 @@ func (t *TimescaleDb) GetLastXTransactions(ctx context.Context, chainName string
 			&transaction.GasUsed,
 			&transaction.GasWanted,
 			&transaction.Fee,
-			&transaction.MsgTypes)
+			&transaction.MsgTypes,
+			&transaction.Success,
+			&transaction.TxError,
+			&transaction.TxLog,
+			&transaction.TxInfo,
+			&transaction.Memo,
+			&transaction.Signers,
+			&transaction.TxIndex,
+			&transaction.GasPrice,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -173,10 +225,23 @@ func (t *TimescaleDb) GetTransactionsByOffset(
 	tx.gas_used,
 	tx.gas_wanted,
 	tx.fee,
-	tx.msg_types
+	tx.msg_types,
+	tx.success,
+	COALESCE(tx.tx_error, '') AS tx_error,
+	COALESCE(tx.tx_log, '') AS tx_log,
+	COALESCE(tx.tx_info, '') AS tx_info,
+	COALESCE(tx.memo, '') AS memo,
+	array(
+		SELECT gn.address
+		FROM unnest(tx.signers) WITH ORDINALITY AS s(id, ord)
+		JOIN gno_addresses gn ON gn.id = s.id
+		ORDER BY s.ord
+	) AS signers,
+	COALESCE(tx.tx_index, 0) AS tx_index,
+	tx.gas_price
 	FROM transaction_general tx
 	WHERE tx.chain_name = $1
-	ORDER BY tx.timestamp DESC
+	ORDER BY tx.block_height DESC, COALESCE(tx.tx_index, 0) DESC, tx.tx_hash DESC
 	LIMIT $2 OFFSET $3
 	`
 	rows, err := t.pool.Query(ctx, query, chainName, limit, offset)
@@ -187,7 +252,24 @@ func (t *TimescaleDb) GetTransactionsByOffset(
 	transactions := make([]*Transaction, 0)
 	for rows.Next() {
 		transaction := &FullTxData{}
-		err := rows.Scan(&transaction.TxHash, &transaction.Timestamp, &transaction.BlockHeight, &transaction.TxEvents, &transaction.GasUsed, &transaction.GasWanted, &transaction.Fee, &transaction.MsgTypes)
+		err := rows.Scan(
+			&transaction.TxHash,
+			&transaction.Timestamp,
+			&transaction.BlockHeight,
+			&transaction.TxEvents,
+			&transaction.GasUsed,
+			&transaction.GasWanted,
+			&transaction.Fee,
+			&transaction.MsgTypes,
+			&transaction.Success,
+			&transaction.TxError,
+			&transaction.TxLog,
+			&transaction.TxInfo,
+			&transaction.Memo,
+			&transaction.Signers,
+			&transaction.TxIndex,
+			&transaction.GasPrice,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -203,23 +285,35 @@ func (t *TimescaleDb) GetTransactionsByOffset(
 	return transactions, nil
 }
 
+// GetTransactionsByCursor gets the transactions before the cursor for a given chain name,
+// the transactions are ordered by the block height and their position within the block
+//
+// Usage:
+//
+// # Used to paginate through the transactions, an empty cursor returns the latest transactions
+//
+// Parameters:
+//   - chainName: the name of the chain
+//   - cursor: the cursor in form of block_height|tx_index|tx_hash(base64url encoded)
+//   - limit: the limit of the transactions to get
+//   - failedOnly: if true only the transactions that failed to execute are returned
+//
+// Returns:
+//   - []*Transaction: the transactions
+//   - error: if the query fails
 func (t *TimescaleDb) GetTransactionsByCursor(
 	ctx context.Context,
 	chainName string,
 	cursor string,
 	limit uint64,
+	failedOnly bool,
 ) ([]*Transaction, error) {
 	var query string
 	// if txHash and timestamp are nil make same query as GetLastXTransactions
 	if cursor == "" {
-		return t.GetLastXTransactions(ctx, chainName, limit)
+		return t.GetLastXTransactions(ctx, chainName, limit, failedOnly)
 	}
-	timestamp, txHash, err := unmarshalCursorParam(cursor)
-	if err != nil {
-		return nil, err
-	}
-
-	decodedTxHash, err := base64.URLEncoding.Strict().DecodeString(txHash)
+	height, txIndex, txHash, err := parseTxListCursor(cursor)
 	if err != nil {
 		return nil, err
 	}
@@ -234,14 +328,28 @@ func (t *TimescaleDb) GetTransactionsByCursor(
 	tx.gas_used,
 	tx.gas_wanted,
 	tx.fee,
-	tx.msg_types
+	tx.msg_types,
+	tx.success,
+	COALESCE(tx.tx_error, '') AS tx_error,
+	COALESCE(tx.tx_log, '') AS tx_log,
+	COALESCE(tx.tx_info, '') AS tx_info,
+	COALESCE(tx.memo, '') AS memo,
+	array(
+		SELECT gn.address
+		FROM unnest(tx.signers) WITH ORDINALITY AS s(id, ord)
+		JOIN gno_addresses gn ON gn.id = s.id
+		ORDER BY s.ord
+	) AS signers,
+	COALESCE(tx.tx_index, 0) AS tx_index,
+	tx.gas_price
 	FROM transaction_general tx
 	WHERE tx.chain_name = $1
-	AND (tx.timestamp, tx.tx_hash) < ($2, $3)
-	ORDER BY tx.timestamp DESC, tx.tx_hash DESC
-	LIMIT $4
+	AND (tx.block_height, COALESCE(tx.tx_index, 0), tx.tx_hash) < ($2, $3, $4)
+	AND ($6 = false OR tx.success = false)
+	ORDER BY tx.block_height DESC, COALESCE(tx.tx_index, 0) DESC, tx.tx_hash DESC
+	LIMIT $5
 	`
-	args := []any{chainName, timestamp, decodedTxHash, limit}
+	args := []any{chainName, height, txIndex, txHash, limit, failedOnly}
 	rows, err := t.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
@@ -261,6 +369,14 @@ func (t *TimescaleDb) GetTransactionsByCursor(
 			&transaction.GasWanted,
 			&transaction.Fee,
 			&transaction.MsgTypes,
+			&transaction.Success,
+			&transaction.TxError,
+			&transaction.TxLog,
+			&transaction.TxInfo,
+			&transaction.Memo,
+			&transaction.Signers,
+			&transaction.TxIndex,
+			&transaction.GasPrice,
 		)
 		if err != nil {
 			return nil, err
@@ -277,6 +393,27 @@ func (t *TimescaleDb) GetTransactionsByCursor(
 	return transactions, nil
 }
 
+// parseTxListCursor reads the block height, the tx index and the tx hash from the cursor
+func parseTxListCursor(cursor string) (uint64, int32, []byte, error) {
+	parts := strings.Split(cursor, "|")
+	if len(parts) != 3 {
+		return 0, 0, nil, fmt.Errorf("invalid cursor")
+	}
+	height, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	txIndex, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	txHash, err := base64.URLEncoding.Strict().DecodeString(parts[2])
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("error decoding tx hash: %w", err)
+	}
+	return height, int32(txIndex), txHash, nil
+}
+
 func decompressEvents(txEvents []byte) ([]byte, error) {
 	decompressed, err := zstdReader.DecodeAll(txEvents, nil)
 	if err != nil {
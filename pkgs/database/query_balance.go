@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+)
+
+// GetAddressBalances gets the native coin balances of an address
+//
+// Usage:
+//
+// # Used to get the native balances of an address, now or at a past height
+//
+// Without the height the balances are read from the native_balances table, with the height
+// they are summed from the balance changes up to and including the height.
+//
+// Parameters:
+//   - address: the address
+//   - chainName: the name of the chain
+//   - atHeight: the height of the balances, nil for the latest
+//
+// Returns:
+//   - []*NativeBalance: the non zero balances of the address
+//   - error: if the query fails
+func (t *TimescaleDb) GetAddressBalances(
+	ctx context.Context,
+	address string,
+	chainName string,
+	atHeight *uint64,
+) ([]*NativeBalance, error) {
+	query := `
+	SELECT
+	nb.denom,
+	nb.balance::TEXT AS balance,
+	nb.last_height
+	FROM native_balances nb
+	JOIN gno_addresses gn ON nb.address = gn.id
+	WHERE gn.address = $1
+	AND nb.chain_name = $2
+	AND nb.balance <> 0
+	ORDER BY nb.denom
+	`
+	args := []any{address, chainName}
+	if atHeight != nil {
+		query = `
+		SELECT
+		nc.denom,
+		SUM(nc.amount)::TEXT AS balance,
+		MAX(nc.block_height) AS last_height
+		FROM native_balance_changes nc
+		JOIN gno_addresses gn ON nc.address = gn.id
+		WHERE gn.address = $1
+		AND nc.chain_name = $2
+		AND nc.block_height <= $3
+		GROUP BY nc.denom
+		HAVING SUM(nc.amount) <> 0
+		ORDER BY nc.denom
+		`
+		args = append(args, *atHeight)
+	}
+	rows, err := t.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make([]*NativeBalance, 0)
+	for rows.Next() {
+		balance := &NativeBalance{}
+		if err := rows.Scan(&balance.Denom, &balance.Balance, &balance.LastHeight); err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
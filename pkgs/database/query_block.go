@@ -25,7 +25,18 @@ func (t *TimescaleDb) GetBlock(ctx context.Context, height uint64, chainName str
 	SELECT encode(hash, 'base64'), 
 	height, 
 	timestamp, 
-	chain_id
+	chain_id,
+	COALESCE((SELECT address FROM gno_validators WHERE id = proposer AND chain_name = blocks.chain_name), ''),
+	COALESCE(total_txs, 0),
+	COALESCE(encode(app_hash, 'base64'), ''),
+	COALESCE(encode(last_block_hash, 'base64'), ''),
+	COALESCE(encode(last_commit_hash, 'base64'), ''),
+	COALESCE(encode(data_hash, 'base64'), ''),
+	COALESCE(encode(validators_hash, 'base64'), ''),
+	COALESCE(encode(next_validators_hash, 'base64'), ''),
+	COALESCE(encode(consensus_hash, 'base64'), ''),
+	COALESCE(gas_used, 0),
+	COALESCE(gas_wanted, 0)
 	FROM blocks
 	WHERE height = $1
 	AND chain_name = $2
@@ -74,7 +85,18 @@ func (t *TimescaleDb) GetLatestBlock(ctx context.Context, chainName string) (*Bl
 	SELECT encode(hash, 'base64'), 
 	height, 
 	timestamp, 
-	chain_id
+	chain_id,
+	COALESCE((SELECT address FROM gno_validators WHERE id = proposer AND chain_name = blocks.chain_name), ''),
+	COALESCE(total_txs, 0),
+	COALESCE(encode(app_hash, 'base64'), ''),
+	COALESCE(encode(last_block_hash, 'base64'), ''),
+	COALESCE(encode(last_commit_hash, 'base64'), ''),
+	COALESCE(encode(data_hash, 'base64'), ''),
+	COALESCE(encode(validators_hash, 'base64'), ''),
+	COALESCE(encode(next_validators_hash, 'base64'), ''),
+	COALESCE(encode(consensus_hash, 'base64'), ''),
+	COALESCE(gas_used, 0),
+	COALESCE(gas_wanted, 0)
 	FROM blocks
 	WHERE chain_name = $1
 	ORDER BY height DESC
@@ -137,7 +159,18 @@ func (t *TimescaleDb) GetLastXBlocks(ctx context.Context, chainName string, x ui
 	SELECT encode(hash, 'base64'), 
 	height, 
 	timestamp, 
-	chain_id
+	chain_id,
+	COALESCE((SELECT address FROM gno_validators WHERE id = proposer AND chain_name = blocks.chain_name), ''),
+	COALESCE(total_txs, 0),
+	COALESCE(encode(app_hash, 'base64'), ''),
+	COALESCE(encode(last_block_hash, 'base64'), ''),
+	COALESCE(encode(last_commit_hash, 'base64'), ''),
+	COALESCE(encode(data_hash, 'base64'), ''),
+	COALESCE(encode(validators_hash, 'base64'), ''),
+	COALESCE(encode(next_validators_hash, 'base64'), ''),
+	COALESCE(encode(consensus_hash, 'base64'), ''),
+	COALESCE(gas_used, 0),
+	COALESCE(gas_wanted, 0)
 	FROM blocks
 	WHERE chain_name = $1
 	ORDER BY height DESC
@@ -203,7 +236,18 @@ func (t *TimescaleDb) GetFromToBlocks(ctx context.Context, fromHeight uint64, to
 	SELECT encode(hash, 'base64'), 
 	height, 
 	timestamp, 
-	chain_id
+	chain_id,
+	COALESCE((SELECT address FROM gno_validators WHERE id = proposer AND chain_name = blocks.chain_name), ''),
+	COALESCE(total_txs, 0),
+	COALESCE(encode(app_hash, 'base64'), ''),
+	COALESCE(encode(last_block_hash, 'base64'), ''),
+	COALESCE(encode(last_commit_hash, 'base64'), ''),
+	COALESCE(encode(data_hash, 'base64'), ''),
+	COALESCE(encode(validators_hash, 'base64'), ''),
+	COALESCE(encode(next_validators_hash, 'base64'), ''),
+	COALESCE(encode(consensus_hash, 'base64'), ''),
+	COALESCE(gas_used, 0),
+	COALESCE(gas_wanted, 0)
 	FROM blocks
 	WHERE height >= $1 AND height <= $2
 	AND chain_name = $3
@@ -259,7 +303,23 @@ func (t *TimescaleDb) fetchBlocksData(ctx context.Context, query string, args ..
 	blocks := make([]*BlockData, 0)
 	for rows.Next() {
 		block := &BlockData{}
-		err := rows.Scan(&block.Hash, &block.Height, &block.Timestamp, &block.ChainID)
+		err := rows.Scan(
+			&block.Hash,
+			&block.Height,
+			&block.Timestamp,
+			&block.ChainID,
+			&block.Proposer,
+			&block.TotalTxs,
+			&block.AppHash,
+			&block.LastBlockHash,
+			&block.LastCommitHash,
+			&block.DataHash,
+			&block.ValidatorsHash,
+			&block.NextValidatorsHash,
+			&block.ConsensusHash,
+			&block.GasUsed,
+			&block.GasWanted,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -287,3 +347,103 @@ func (t *TimescaleDb) fetchTransactionData(ctx context.Context, query string, ar
 	}
 	return txs, rows.Err()
 }
+
+// GetBlockEvents gets the events of a block that are not tied to a transaction and its validator updates
+//
+// Usage:
+//
+// # Used to get the BeginBlock and EndBlock events and the validator updates of a block
+//
+// Parameters:
+//   - height: the height of the block
+//   - chainName: the name of the chain
+//
+// Returns:
+//   - *BlockEvents: the block events, the lists are empty if the block has none
+//   - error: if the query fails or the block is not indexed
+func (t *TimescaleDb) GetBlockEvents(ctx context.Context, height uint64, chainName string) (*BlockEvents, error) {
+	var indexed bool
+	err := t.pool.QueryRow(ctx, `
+	SELECT EXISTS (SELECT 1 FROM blocks WHERE height = $1 AND chain_name = $2)
+	`, height, chainName).Scan(&indexed)
+	if err != nil {
+		return nil, err
+	}
+	if !indexed {
+		return nil, fmt.Errorf("block not found at height %d", height)
+	}
+
+	blockEvents := &BlockEvents{
+		Height:           height,
+		BeginBlock:       make([]Event, 0),
+		EndBlock:         make([]Event, 0),
+		ValidatorUpdates: make([]*Validator, 0),
+	}
+
+	rows, err := t.pool.Query(ctx, `
+	SELECT phase, events, events_compressed, compression_on
+	FROM block_events
+	WHERE block_height = $1
+	AND chain_name = $2
+	`, height, chainName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var phase string
+		var native []Event
+		var compressed []byte
+		var compressionOn bool
+		if err := rows.Scan(&phase, &native, &compressed, &compressionOn); err != nil {
+			return nil, err
+		}
+		events := native
+		if compressionOn {
+			decoded, err := decodeEvents(compressed)
+			if err != nil {
+				return nil, err
+			}
+			events = *decoded
+		}
+		switch phase {
+		case "begin_block":
+			blockEvents.BeginBlock = append(blockEvents.BeginBlock, events...)
+		case "end_block":
+			blockEvents.EndBlock = append(blockEvents.EndBlock, events...)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = t.pool.Query(ctx, `
+	SELECT
+	gv.address,
+	vu.voting_power,
+	vu.pub_key_type,
+	encode(vu.pub_key, 'base64') AS pub_key
+	FROM validator_updates vu
+	JOIN gno_validators gv ON vu.validator = gv.id
+	WHERE vu.block_height = $1
+	AND vu.chain_name = $2
+	ORDER BY vu.voting_power DESC, gv.address
+	`, height, chainName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		validator := &Validator{}
+		if err := rows.Scan(
+			&validator.Address,
+			&validator.VotingPower,
+			&validator.PubKeyType,
+			&validator.PubKey,
+		); err != nil {
+			return nil, err
+		}
+		blockEvents.ValidatorUpdates = append(blockEvents.ValidatorUpdates, validator)
+	}
+	return blockEvents, rows.Err()
+}
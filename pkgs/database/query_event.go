@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+// EventFilter holds the optional filters of the events query, nil filters are not applied
+type EventFilter struct {
+	EventType *string
+	PkgPath   *string
+	AttrKey   *string
+	AttrValue *string
+}
+
+// GetEvents pages through the events emitted by the transactions, newest first
+//
+// An event matches the attribute filter if any of its attributes matches, the returned
+// event still holds all of its attributes.
+//
+// Parameters:
+//   - ctx: the context of the query
+//   - chainName: the name of the chain
+//   - filter: the filters of the events
+//   - cursor: the cursor of the previous page, nil for the first page
+//   - limit: the maximum number of events
+//
+// Returns:
+//   - []*TxEvent: the events
+//   - string: the cursor of the next page, empty if there are no more events
+//   - error: if the query fails
+func (t *TimescaleDb) GetEvents(
+	ctx context.Context,
+	chainName string,
+	filter EventFilter,
+	cursor *string,
+	limit uint64,
+) ([]*TxEvent, string, error) {
+	var cursorTimestamp *time.Time
+	var cursorTxHash []byte
+	var cursorIndex int16
+	if cursor != nil {
+		timestamp, txHash, index, err := parseTxCursor(*cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		cursorTimestamp, cursorTxHash, cursorIndex = &timestamp, txHash, index
+	}
+
+	// Fetch limit+1 to detect if there are more events
+	query := `
+	WITH matched AS (
+		SELECT DISTINCT te.tx_hash, te.timestamp, te.event_index
+		FROM tx_events te
+		WHERE te.chain_name = $1
+		AND ($2::TEXT IS NULL OR te.event_type = $2)
+		AND ($3::TEXT IS NULL OR te.pkg_path = $3)
+		AND ($4::TEXT IS NULL OR te.attr_key = $4)
+		AND ($5::TEXT IS NULL OR te.attr_value = $5)
+		AND ($6::timestamptz IS NULL OR (te.timestamp, te.tx_hash, te.event_index) < ($6::timestamptz, $7, $8))
+		ORDER BY te.timestamp DESC, te.tx_hash DESC, te.event_index DESC
+		LIMIT $9
+	)
+	SELECT
+	encode(m.tx_hash, 'base64') AS tx_hash,
+	m.timestamp,
+	m.event_index,
+	te.block_height,
+	te.event_type,
+	COALESCE(te.at_type, '') AS at_type,
+	COALESCE(te.pkg_path, '') AS pkg_path,
+	COALESCE(array_agg(te.attr_key ORDER BY te.attr_index) FILTER (WHERE te.attr_key IS NOT NULL), '{}') AS attr_keys,
+	COALESCE(array_agg(te.attr_value ORDER BY te.attr_index) FILTER (WHERE te.attr_key IS NOT NULL), '{}') AS attr_values
+	FROM matched m
+	JOIN tx_events te ON te.tx_hash = m.tx_hash
+	AND te.timestamp = m.timestamp
+	AND te.event_index = m.event_index
+	AND te.chain_name = $1
+	GROUP BY m.tx_hash, m.timestamp, m.event_index, te.block_height, te.event_type, te.at_type, te.pkg_path
+	ORDER BY m.timestamp DESC, m.tx_hash DESC, m.event_index DESC
+	`
+	rows, err := t.pool.Query(
+		ctx, query, chainName,
+		filter.EventType, filter.PkgPath, filter.AttrKey, filter.AttrValue,
+		cursorTimestamp, cursorTxHash, cursorIndex, limit+1,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	events := make([]*TxEvent, 0)
+	for rows.Next() {
+		event := &TxEvent{}
+		var keys, values []string
+		err := rows.Scan(
+			&event.TxHash,
+			&event.Timestamp,
+			&event.EventIndex,
+			&event.BlockHeight,
+			&event.Type,
+			&event.AtType,
+			&event.PkgPath,
+			&keys,
+			&values,
+		)
+		if err != nil {
+			return nil, "", err
+		}
+		event.Attributes = make([]Attribute, len(keys))
+		for i := range keys {
+			event.Attributes[i] = Attribute{Key: keys[i], Value: values[i]}
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if len(events) > int(limit) {
+		events = events[:limit]
+		last := events[len(events)-1]
+		nextCursor, err := makeTxCursor(last.Timestamp, last.TxHash, last.EventIndex)
+		if err != nil {
+			return nil, "", err
+		}
+		return events, nextCursor, nil
+	}
+	return events, "", nil
+}
@@ -316,6 +316,73 @@ func (t *TimescaleDb) GetMsgRun(
 	return msgRuns, nil
 }
 
+// GetMsgGeneric gets the generic messages for a given transaction hash
+//
+// Usage:
+//
+// # Used to get the messages that don't have their own table for a given transaction hash
+//
+// Parameters:
+//   - txHash: the hash of the transaction
+//   - chainName: the name of the chain
+//
+// Returns:
+//   - []*MsgGeneric: the generic messages
+//   - error: if the query fails
+func (t *TimescaleDb) GetMsgGeneric(
+	ctx context.Context,
+	txHash string,
+	chainName string,
+) ([]*MsgGeneric, error) {
+	query := `
+	SELECT 
+	encode(mg.tx_hash, 'base64') AS tx_hash,
+	mg.message_counter,
+	mg.timestamp,
+	mg.type_url,
+	mg.payload::text,
+	array(
+		SELECT gn.address 
+		FROM unnest(coalesce(mg.addresses, '{}')) AS address_id
+		JOIN gno_addresses gn ON gn.id = address_id
+	) AS addresses,
+	array(
+		SELECT gn.address 
+		FROM unnest(mg.signers) AS signer_id
+		JOIN gno_addresses gn ON gn.id = signer_id
+	) AS signers
+	FROM msg_generic mg
+	WHERE mg.tx_hash = decode($1, 'base64')
+	AND mg.chain_name = $2
+	`
+	rows, err := t.pool.Query(ctx, query, txHash, chainName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	msgGenerics := make([]*MsgGeneric, 0)
+	for rows.Next() {
+		msgGeneric := &MsgGeneric{}
+		err := rows.Scan(
+			&msgGeneric.TxHash,
+			&msgGeneric.MessageCounter,
+			&msgGeneric.Timestamp,
+			&msgGeneric.TypeUrl,
+			&msgGeneric.Payload,
+			&msgGeneric.Addresses,
+			&msgGeneric.Signers,
+		)
+		if err != nil {
+			return nil, err
+		}
+		msgGenerics = append(msgGenerics, msgGeneric)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return msgGenerics, nil
+}
+
 // GetMsgTypes gets the message type for a given transaction hash
 //
 // Usage:
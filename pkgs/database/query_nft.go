@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetCollectionTokens gets the tokens of a GRC721 collection with their current owner
+//
+// Usage:
+//
+// # Used to list the tokens of a collection, the latest transferred come first
+//
+// Parameters:
+//   - collectionPath: the realm path of the collection
+//   - chainName: the name of the chain
+//   - limit: the amount of tokens to return
+//   - page: the page of the tokens, starts from 0
+//
+// Returns:
+//   - []*Nft: the tokens, the burned tokens included
+//   - uint64: the total amount of tokens
+//   - error: if the query fails
+func (t *TimescaleDb) GetCollectionTokens(
+	ctx context.Context,
+	collectionPath string,
+	chainName string,
+	limit uint64,
+	page uint64,
+) ([]*Nft, uint64, error) {
+	var total uint64
+	err := t.pool.QueryRow(ctx, `
+	SELECT COUNT(*) FROM nft_owners WHERE collection_path = $1 AND chain_name = $2
+	`, collectionPath, chainName).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+	SELECT
+	o.collection_path,
+	o.token_id,
+	COALESCE(gn.address, '') AS owner,
+	o.owner IS NULL AS burned,
+	o.last_height,
+	o.last_timestamp,
+	encode(o.tx_hash, 'base64') AS tx_hash
+	FROM nft_owners o
+	LEFT JOIN gno_addresses gn ON o.owner = gn.id
+	WHERE o.collection_path = $1
+	AND o.chain_name = $2
+	ORDER BY o.last_height DESC, o.token_id
+	LIMIT $3 OFFSET $4
+	`
+	rows, err := t.pool.Query(ctx, query, collectionPath, chainName, limit, page*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	nfts, err := scanNfts(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return nfts, total, nil
+}
+
+// GetAddressNfts gets the GRC721 tokens owned by an address
+//
+// Usage:
+//
+// # Used to get the NFTs of an address
+//
+// Parameters:
+//   - address: the address
+//   - chainName: the name of the chain
+//
+// Returns:
+//   - []*Nft: the tokens owned by the address
+//   - error: if the query fails
+func (t *TimescaleDb) GetAddressNfts(ctx context.Context, address string, chainName string) ([]*Nft, error) {
+	query := `
+	SELECT
+	o.collection_path,
+	o.token_id,
+	gn.address AS owner,
+	FALSE AS burned,
+	o.last_height,
+	o.last_timestamp,
+	encode(o.tx_hash, 'base64') AS tx_hash
+	FROM nft_owners o
+	JOIN gno_addresses gn ON o.owner = gn.id
+	WHERE gn.address = $1
+	AND o.chain_name = $2
+	ORDER BY o.collection_path, o.token_id
+	`
+	rows, err := t.pool.Query(ctx, query, address, chainName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanNfts(rows)
+}
+
+// GetNftHistory gets the ownership history of a GRC721 token
+//
+// Usage:
+//
+// # Used to get every transfer of a token, the latest come first
+//
+// Parameters:
+//   - collectionPath: the realm path of the collection
+//   - tokenId: the id of the token
+//   - chainName: the name of the chain
+//
+// Returns:
+//   - []*NftTransfer: the transfers, mints and burns of the token
+//   - error: if the query fails
+func (t *TimescaleDb) GetNftHistory(
+	ctx context.Context,
+	collectionPath string,
+	tokenId string,
+	chainName string,
+) ([]*NftTransfer, error) {
+	query := `
+	SELECT
+	encode(nt.tx_hash, 'base64') AS tx_hash,
+	nt.timestamp,
+	nt.block_height,
+	nt.event_index,
+	nt.collection_path,
+	COALESCE(nt.symbol, '') AS symbol,
+	nt.token_id,
+	nt.event_type,
+	COALESCE(fa.address, '') AS from_address,
+	COALESCE(ta.address, '') AS to_address
+	FROM nft_transfers nt
+	LEFT JOIN gno_addresses fa ON nt.from_address = fa.id
+	LEFT JOIN gno_addresses ta ON nt.to_address = ta.id
+	WHERE nt.collection_path = $1
+	AND nt.token_id = $2
+	AND nt.chain_name = $3
+	ORDER BY nt.block_height DESC, nt.tx_hash DESC, nt.event_index DESC
+	`
+	rows, err := t.pool.Query(ctx, query, collectionPath, tokenId, chainName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	transfers := make([]*NftTransfer, 0)
+	for rows.Next() {
+		transfer := &NftTransfer{}
+		err := rows.Scan(
+			&transfer.TxHash,
+			&transfer.Timestamp,
+			&transfer.BlockHeight,
+			&transfer.EventIndex,
+			&transfer.CollectionPath,
+			&transfer.Symbol,
+			&transfer.TokenId,
+			&transfer.EventType,
+			&transfer.From,
+			&transfer.To,
+		)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// scanNfts reads the token owner rows
+func scanNfts(rows pgx.Rows) ([]*Nft, error) {
+	nfts := make([]*Nft, 0)
+	for rows.Next() {
+		nft := &Nft{}
+		err := rows.Scan(
+			&nft.CollectionPath,
+			&nft.TokenId,
+			&nft.Owner,
+			&nft.Burned,
+			&nft.LastHeight,
+			&nft.LastTimestamp,
+			&nft.TxHash,
+		)
+		if err != nil {
+			return nil, err
+		}
+		nfts = append(nfts, nft)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nfts, nil
+}
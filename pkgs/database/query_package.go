@@ -0,0 +1,490 @@
+package database
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// packageDeployment is the message that deployed a package, either MsgAddPackage or MsgRun
+type packageDeployment struct {
+	txHash     string
+	timestamp  time.Time
+	msgType    string
+	fileNames  []string
+	fileHashes [][]byte
+}
+
+// getPackageDeployment gets the latest deployment of the package or the one from the given transaction
+func (t *TimescaleDb) getPackageDeployment(
+	ctx context.Context,
+	pkgPath string,
+	chainName string,
+	txHash *string,
+) (*packageDeployment, error) {
+	query := `
+	SELECT
+	encode(d.tx_hash, 'base64') AS tx_hash,
+	d.timestamp,
+	d.msg_type,
+	d.pkg_file_names,
+	d.pkg_file_hashes
+	FROM (
+		SELECT tx_hash, timestamp, message_counter, 'vm_msg_add_package' AS msg_type, pkg_file_names, pkg_file_hashes
+		FROM vm_msg_add_package
+		WHERE pkg_path = $1 AND chain_name = $2
+		UNION ALL
+		SELECT tx_hash, timestamp, message_counter, 'vm_msg_run' AS msg_type, pkg_file_names, pkg_file_hashes
+		FROM vm_msg_run
+		WHERE pkg_path = $1 AND chain_name = $2
+	) d
+	WHERE $3::TEXT IS NULL OR d.tx_hash = decode($3, 'base64')
+	ORDER BY d.timestamp DESC, d.message_counter DESC
+	LIMIT 1
+	`
+	deployment := &packageDeployment{}
+	err := t.pool.QueryRow(ctx, query, pkgPath, chainName, txHash).Scan(
+		&deployment.txHash,
+		&deployment.timestamp,
+		&deployment.msgType,
+		&deployment.fileNames,
+		&deployment.fileHashes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// GetPackageSource gets the source files of a package as of the given deployment
+//
+// Usage:
+//
+// # Used to list the source files of a deployed package
+//
+// The deployments stored before the files were archived have no file hashes, their files
+// are listed only by name.
+//
+// Parameters:
+//   - pkgPath: the path of the package
+//   - chainName: the name of the chain
+//   - txHash: the hash of the deployment transaction (base64 encoded), nil for the latest deployment
+//
+// Returns:
+//   - *PackageSource: the deployment and its files
+//   - error: if the query fails or the package is not found
+func (t *TimescaleDb) GetPackageSource(
+	ctx context.Context,
+	pkgPath string,
+	chainName string,
+	txHash *string,
+) (*PackageSource, error) {
+	deployment, err := t.getPackageDeployment(ctx, pkgPath, chainName, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make(map[string]int32)
+	if len(deployment.fileHashes) > 0 {
+		rows, err := t.pool.Query(ctx, `
+		SELECT file_hash, size
+		FROM package_files
+		WHERE file_hash = ANY($1) AND chain_name = $2
+		`, deployment.fileHashes, chainName)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var hash []byte
+			var size int32
+			if err := rows.Scan(&hash, &size); err != nil {
+				return nil, err
+			}
+			sizes[string(hash)] = size
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	source := &PackageSource{
+		PkgPath:   pkgPath,
+		TxHash:    deployment.txHash,
+		Timestamp: deployment.timestamp,
+		MsgType:   deployment.msgType,
+		Files:     make([]PackageSourceFile, 0, len(deployment.fileNames)),
+	}
+	for i, name := range deployment.fileNames {
+		file := PackageSourceFile{Name: name}
+		if i < len(deployment.fileHashes) {
+			hash := deployment.fileHashes[i]
+			file.Hash = base64.StdEncoding.EncodeToString(hash)
+			file.Size, file.Archived = sizes[string(hash)]
+		}
+		source.Files = append(source.Files, file)
+	}
+	return source, nil
+}
+
+// GetPackageFile gets a single source file of a package as of the given deployment
+//
+// Usage:
+//
+// # Used to download a source file of a deployed package
+//
+// Parameters:
+//   - pkgPath: the path of the package
+//   - fileName: the name of the file
+//   - chainName: the name of the chain
+//   - txHash: the hash of the deployment transaction (base64 encoded), nil for the latest deployment
+//
+// Returns:
+//   - *PackageFileContent: the decompressed file
+//   - error: if the query fails or the file is not found
+func (t *TimescaleDb) GetPackageFile(
+	ctx context.Context,
+	pkgPath string,
+	fileName string,
+	chainName string,
+	txHash *string,
+) (*PackageFileContent, error) {
+	deployment, err := t.getPackageDeployment(ctx, pkgPath, chainName, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var hash []byte
+	for i, name := range deployment.fileNames {
+		if name == fileName && i < len(deployment.fileHashes) {
+			hash = deployment.fileHashes[i]
+			break
+		}
+	}
+	if hash == nil {
+		return nil, fmt.Errorf("file %s is not archived for package %s", fileName, pkgPath)
+	}
+
+	var compressed []byte
+	err = t.pool.QueryRow(ctx, `
+	SELECT content
+	FROM package_files
+	WHERE file_hash = $1 AND chain_name = $2
+	`, hash, chainName).Scan(&compressed)
+	if err != nil {
+		return nil, err
+	}
+	// the package files are compressed without the dictionary, the reader can decode both
+	content, err := zstdReader.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress file %s: %w", fileName, err)
+	}
+	return &PackageFileContent{
+		Name:    fileName,
+		Hash:    base64.StdEncoding.EncodeToString(hash),
+		Content: content,
+	}, nil
+}
+
+// GetPackages gets a page of the packages, the latest deployed packages come first
+//
+// Usage:
+//
+// # Used to list the packages added to the chain
+//
+// Parameters:
+//   - chainName: the name of the chain
+//   - limit: the amount of packages to return
+//   - page: the page of the packages, starts from 0
+//
+// Returns:
+//   - []*Package: the packages
+//   - uint64: the total amount of packages
+//   - error: if the query fails
+func (t *TimescaleDb) GetPackages(
+	ctx context.Context,
+	chainName string,
+	limit uint64,
+	page uint64,
+) ([]*Package, uint64, error) {
+	var total uint64
+	err := t.pool.QueryRow(ctx, `SELECT COUNT(*) FROM packages WHERE chain_name = $1`, chainName).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+	SELECT
+	p.pkg_path,
+	p.pkg_name,
+	gn.address AS creator,
+	p.first_height,
+	p.first_timestamp,
+	encode(p.tx_hash, 'base64') AS tx_hash,
+	p.file_count
+	FROM packages p
+	LEFT JOIN gno_addresses gn ON p.creator = gn.id
+	WHERE p.chain_name = $1
+	ORDER BY p.first_height DESC, p.pkg_path
+	LIMIT $2 OFFSET $3
+	`
+	rows, err := t.pool.Query(ctx, query, chainName, limit, page*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	packages := make([]*Package, 0)
+	for rows.Next() {
+		pkg := &Package{}
+		err := rows.Scan(
+			&pkg.PkgPath,
+			&pkg.PkgName,
+			&pkg.Creator,
+			&pkg.FirstHeight,
+			&pkg.FirstTimestamp,
+			&pkg.TxHash,
+			&pkg.FileCount,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		packages = append(packages, pkg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return packages, total, nil
+}
+
+// GetPackage gets a package with its deployment history
+//
+// Usage:
+//
+// # Used to get the details of a package
+//
+// Parameters:
+//   - pkgPath: the path of the package
+//   - chainName: the name of the chain
+//
+// Returns:
+//   - *PackageDetails: the package and every MsgAddPackage with its path, newest first
+//   - error: if the query fails or the package is not found
+func (t *TimescaleDb) GetPackage(
+	ctx context.Context,
+	pkgPath string,
+	chainName string,
+) (*PackageDetails, error) {
+	pkg := &PackageDetails{}
+	err := t.pool.QueryRow(ctx, `
+	SELECT
+	p.pkg_path,
+	p.pkg_name,
+	gn.address AS creator,
+	p.first_height,
+	p.first_timestamp,
+	encode(p.tx_hash, 'base64') AS tx_hash,
+	p.file_count
+	FROM packages p
+	LEFT JOIN gno_addresses gn ON p.creator = gn.id
+	WHERE p.pkg_path = $1
+	AND p.chain_name = $2
+	`, pkgPath, chainName).Scan(
+		&pkg.PkgPath,
+		&pkg.PkgName,
+		&pkg.Creator,
+		&pkg.FirstHeight,
+		&pkg.FirstTimestamp,
+		&pkg.TxHash,
+		&pkg.FileCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := t.pool.Query(ctx, `
+	SELECT
+	encode(vmap.tx_hash, 'base64') AS tx_hash,
+	tx.block_height,
+	vmap.timestamp,
+	gn.address AS creator,
+	COALESCE(cardinality(vmap.pkg_file_names), 0) AS file_count,
+	tx.success
+	FROM vm_msg_add_package vmap
+	JOIN transaction_general tx
+	ON tx.tx_hash = vmap.tx_hash
+	AND tx.timestamp = vmap.timestamp
+	AND tx.chain_name = vmap.chain_name
+	LEFT JOIN gno_addresses gn ON vmap.creator = gn.id
+	WHERE vmap.pkg_path = $1
+	AND vmap.chain_name = $2
+	ORDER BY vmap.timestamp DESC, vmap.message_counter DESC
+	`, pkgPath, chainName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	pkg.Deployments = make([]PackageDeployment, 0)
+	for rows.Next() {
+		var deployment PackageDeployment
+		err := rows.Scan(
+			&deployment.TxHash,
+			&deployment.BlockHeight,
+			&deployment.Timestamp,
+			&deployment.Creator,
+			&deployment.FileCount,
+			&deployment.Success,
+		)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Deployments = append(pkg.Deployments, deployment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// GetPackageCalls gets a page of the MsgCall messages that call the package, newest first
+//
+// Usage:
+//
+// # Used to page through the calls of a realm
+//
+// Parameters:
+//   - pkgPath: the path of the package
+//   - chainName: the name of the chain
+//   - cursor: the cursor returned by the previous page, nil for the first page
+//   - limit: the amount of calls to return
+//
+// Returns:
+//   - []*MsgCall: the calls
+//   - string: the cursor of the next page, empty if there are no more calls
+//   - error: if the query fails or the cursor is not valid
+func (t *TimescaleDb) GetPackageCalls(
+	ctx context.Context,
+	pkgPath string,
+	chainName string,
+	cursor *string,
+	limit uint64,
+) ([]*MsgCall, string, error) {
+	var cursorTimestamp *time.Time
+	var cursorTxHash []byte
+	var cursorCounter int16
+	if cursor != nil {
+		timestamp, txHash, counter, err := parseTxCursor(*cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		cursorTimestamp, cursorTxHash, cursorCounter = &timestamp, txHash, counter
+	}
+
+	// Fetch limit+1 to detect if there are more rows
+	query := `
+	SELECT
+	encode(vmc.tx_hash, 'base64') AS tx_hash,
+	vmc.message_counter,
+	vmc.timestamp,
+	gn.address AS caller,
+	vmc.pkg_path,
+	vmc.func_name,
+	vmc.args,
+	vmc.send,
+	vmc.max_deposit,
+	array(
+		SELECT gn.address
+		FROM unnest(vmc.signers) AS signer_id
+		JOIN gno_addresses gn ON gn.id = signer_id
+	) AS signers
+	FROM vm_msg_call vmc
+	LEFT JOIN gno_addresses gn ON vmc.caller = gn.id
+	WHERE vmc.pkg_path = $1
+	AND vmc.chain_name = $2
+	AND ($3::timestamptz IS NULL OR (vmc.timestamp, vmc.tx_hash, vmc.message_counter) < ($3::timestamptz, $4, $5))
+	ORDER BY vmc.timestamp DESC, vmc.tx_hash DESC, vmc.message_counter DESC
+	LIMIT $6
+	`
+	rows, err := t.pool.Query(ctx, query, pkgPath, chainName, cursorTimestamp, cursorTxHash, cursorCounter, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	msgCalls := make([]*MsgCall, 0)
+	for rows.Next() {
+		msgCall := &MsgCall{}
+		err := rows.Scan(
+			&msgCall.TxHash,
+			&msgCall.MessageCounter,
+			&msgCall.Timestamp,
+			&msgCall.Caller,
+			&msgCall.PkgPath,
+			&msgCall.FuncName,
+			&msgCall.Args,
+			&msgCall.Send,
+			&msgCall.MaxDeposit,
+			&msgCall.Signers,
+		)
+		if err != nil {
+			return nil, "", err
+		}
+		msgCalls = append(msgCalls, msgCall)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if len(msgCalls) > int(limit) {
+		msgCalls = msgCalls[:limit]
+		last := msgCalls[len(msgCalls)-1]
+		nextCursor, err := makeCallsCursor(last)
+		if err != nil {
+			return nil, "", err
+		}
+		return msgCalls, nextCursor, nil
+	}
+	return msgCalls, "", nil
+}
+
+// makeCallsCursor creates the cursor of the next page of calls
+// it also holds the message counter since a transaction can call the realm more than once
+func makeCallsCursor(last *MsgCall) (string, error) {
+	return makeTxCursor(last.Timestamp, last.TxHash, last.MessageCounter)
+}
+
+// makeTxCursor creates a cursor from the timestamp, the tx hash (base64 encoded) and
+// the position of the row within the transaction
+func makeTxCursor(timestamp time.Time, b64TxHash string, counter int16) (string, error) {
+	txHash, err := base64.StdEncoding.DecodeString(b64TxHash)
+	if err != nil {
+		return "", fmt.Errorf("error decoding tx hash: %w", err)
+	}
+	return strings.Join([]string{
+		timestamp.Format(time.RFC3339Nano),
+		base64.URLEncoding.Strict().EncodeToString(txHash),
+		strconv.Itoa(int(counter)),
+	}, "|"), nil
+}
+
+// parseTxCursor reads the timestamp, the tx hash and the counter from the cursor
+func parseTxCursor(cursor string) (time.Time, []byte, int16, error) {
+	parts := strings.Split(cursor, "|")
+	if len(parts) != 3 {
+		return time.Time{}, nil, 0, fmt.Errorf("invalid cursor")
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, nil, 0, fmt.Errorf("invalid cursor: %w", err)
+	}
+	txHash, err := base64.URLEncoding.Strict().DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, nil, 0, fmt.Errorf("error decoding tx hash: %w", err)
+	}
+	counter, err := strconv.ParseInt(parts[2], 10, 16)
+	if err != nil {
+		return time.Time{}, nil, 0, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return timestamp, txHash, int16(counter), nil
+}
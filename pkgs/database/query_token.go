@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetTokens gets the GRC20 tokens with their holder count and supply
+//
+// Usage:
+//
+// # Used to list the GRC20 tokens, the tokens with the most holders come first
+//
+// Parameters:
+//   - chainName: the name of the chain
+//   - limit: the amount of tokens to return
+//   - page: the page of the tokens, starts from 0
+//
+// Returns:
+//   - []*Token: the tokens
+//   - uint64: the total amount of tokens
+//   - error: if the query fails
+func (t *TimescaleDb) GetTokens(
+	ctx context.Context,
+	chainName string,
+	limit uint64,
+	page uint64,
+) ([]*Token, uint64, error) {
+	var total uint64
+	err := t.pool.QueryRow(ctx, `
+	SELECT COUNT(DISTINCT token_path) FROM grc20_balances WHERE chain_name = $1
+	`, chainName).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+	SELECT
+	token_path,
+	COUNT(*) FILTER (WHERE balance > 0) AS holder_count,
+	SUM(balance)::TEXT AS supply,
+	MAX(last_height) AS last_height
+	FROM grc20_balances
+	WHERE chain_name = $1
+	GROUP BY token_path
+	ORDER BY holder_count DESC, token_path
+	LIMIT $2 OFFSET $3
+	`
+	rows, err := t.pool.Query(ctx, query, chainName, limit, page*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	tokens := make([]*Token, 0)
+	for rows.Next() {
+		token := &Token{}
+		if err := rows.Scan(&token.TokenPath, &token.HolderCount, &token.Supply, &token.LastHeight); err != nil {
+			return nil, 0, err
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return tokens, total, nil
+}
+
+// GetTokenHolders gets the addresses that hold a GRC20 token
+//
+// Usage:
+//
+// # Used to list the holders of a token, the largest balances come first
+//
+// Parameters:
+//   - tokenPath: the path of the token
+//   - chainName: the name of the chain
+//   - limit: the amount of holders to return
+//   - page: the page of the holders, starts from 0
+//
+// Returns:
+//   - []*TokenBalance: the balances of the holders
+//   - uint64: the total amount of holders
+//   - error: if the query fails
+func (t *TimescaleDb) GetTokenHolders(
+	ctx context.Context,
+	tokenPath string,
+	chainName string,
+	limit uint64,
+	page uint64,
+) ([]*TokenBalance, uint64, error) {
+	var total uint64
+	err := t.pool.QueryRow(ctx, `
+	SELECT COUNT(*) FROM grc20_balances WHERE token_path = $1 AND chain_name = $2 AND balance > 0
+	`, tokenPath, chainName).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+	SELECT
+	gb.token_path,
+	gn.address,
+	gb.balance::TEXT AS balance,
+	gb.last_height
+	FROM grc20_balances gb
+	JOIN gno_addresses gn ON gb.address = gn.id
+	WHERE gb.token_path = $1
+	AND gb.chain_name = $2
+	AND gb.balance > 0
+	ORDER BY gb.balance DESC, gn.address
+	LIMIT $3 OFFSET $4
+	`
+	rows, err := t.pool.Query(ctx, query, tokenPath, chainName, limit, page*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	holders, err := scanTokenBalances(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return holders, total, nil
+}
+
+// GetAddressTokens gets the GRC20 tokens held by an address
+//
+// Usage:
+//
+// # Used to get the token balances of an address
+//
+// Parameters:
+//   - address: the address
+//   - chainName: the name of the chain
+//
+// Returns:
+//   - []*TokenBalance: the positive balances of the address
+//   - error: if the query fails
+func (t *TimescaleDb) GetAddressTokens(
+	ctx context.Context,
+	address string,
+	chainName string,
+) ([]*TokenBalance, error) {
+	query := `
+	SELECT
+	gb.token_path,
+	gn.address,
+	gb.balance::TEXT AS balance,
+	gb.last_height
+	FROM grc20_balances gb
+	JOIN gno_addresses gn ON gb.address = gn.id
+	WHERE gn.address = $1
+	AND gb.chain_name = $2
+	AND gb.balance > 0
+	ORDER BY gb.token_path
+	`
+	rows, err := t.pool.Query(ctx, query, address, chainName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTokenBalances(rows)
+}
+
+// scanTokenBalances reads the token balance rows
+func scanTokenBalances(rows pgx.Rows) ([]*TokenBalance, error) {
+	balances := make([]*TokenBalance, 0)
+	for rows.Next() {
+		balance := &TokenBalance{}
+		if err := rows.Scan(&balance.TokenPath, &balance.Address, &balance.Balance, &balance.LastHeight); err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
@@ -0,0 +1,307 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetValidatorSet gets the validator set stored at or below a height
+//
+// Usage:
+//
+// # Used to get the current validator set or the set that signed a past height
+//
+// Parameters:
+//   - chainName: the name of the chain
+//   - atHeight: the height of the set, nil for the latest
+//
+// Returns:
+//   - *ValidatorSetData: the validator set with its validators
+//   - error: if the query fails or no set is stored
+func (t *TimescaleDb) GetValidatorSet(
+	ctx context.Context,
+	chainName string,
+	atHeight *uint64,
+) (*ValidatorSetData, error) {
+	heightFilter := ""
+	args := []any{chainName}
+	if atHeight != nil {
+		heightFilter = "AND block_height <= $2"
+		args = append(args, *atHeight)
+	}
+	query := fmt.Sprintf(`
+	WITH latest AS (
+		SELECT block_height, timestamp
+		FROM validator_set
+		WHERE chain_name = $1
+		%s
+		ORDER BY block_height DESC
+		LIMIT 1
+	)
+	SELECT
+	vs.block_height,
+	vs.timestamp,
+	gv.address,
+	vs.voting_power,
+	vs.pub_key_type,
+	encode(vs.pub_key, 'base64') AS pub_key
+	FROM validator_set vs
+	JOIN latest ON vs.block_height = latest.block_height AND vs.timestamp = latest.timestamp
+	JOIN gno_validators gv ON vs.validator = gv.id
+	WHERE vs.chain_name = $1
+	ORDER BY vs.voting_power DESC, gv.address
+	`, heightFilter)
+	rows, err := t.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	validatorSet := &ValidatorSetData{Validators: make([]*Validator, 0)}
+	for rows.Next() {
+		validator := &Validator{}
+		if err := rows.Scan(
+			&validatorSet.Height,
+			&validatorSet.Timestamp,
+			&validator.Address,
+			&validator.VotingPower,
+			&validator.PubKeyType,
+			&validator.PubKey,
+		); err != nil {
+			return nil, err
+		}
+		validatorSet.TotalPower += validator.VotingPower
+		validatorSet.Validators = append(validatorSet.Validators, validator)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(validatorSet.Validators) == 0 {
+		return nil, fmt.Errorf("no validator set stored")
+	}
+	return validatorSet, nil
+}
+
+// GetValidator gets a validator with its current and historical voting power
+//
+// Usage:
+//
+// # Used to get the details of a validator
+//
+// The history has a row for every stored set where the voting power of the validator changed,
+// a set without the validator counts as zero voting power.
+//
+// Parameters:
+//   - validatorAddress: the address of the validator
+//   - chainName: the name of the chain
+//
+// Returns:
+//   - *ValidatorDetails: the validator
+//   - error: if the validator doesn't exist or the query fails
+func (t *TimescaleDb) GetValidator(
+	ctx context.Context,
+	validatorAddress string,
+	chainName string,
+) (*ValidatorDetails, error) {
+	var validatorId int32
+	err := t.pool.QueryRow(ctx, `
+	SELECT id FROM gno_validators WHERE address = $1 AND chain_name = $2
+	`, validatorAddress, chainName).Scan(&validatorId)
+	if err != nil {
+		return nil, fmt.Errorf("validator seems to not exist: %w", err)
+	}
+
+	query := `
+	WITH sets AS (
+		SELECT DISTINCT block_height, timestamp
+		FROM validator_set
+		WHERE chain_name = $1
+	),
+	powers AS (
+		SELECT
+		sets.block_height,
+		sets.timestamp,
+		COALESCE(vs.voting_power, 0) AS voting_power
+		FROM sets
+		LEFT JOIN validator_set vs
+			ON vs.chain_name = $1
+			AND vs.block_height = sets.block_height
+			AND vs.timestamp = sets.timestamp
+			AND vs.validator = $2
+	),
+	changes AS (
+		SELECT
+		block_height,
+		timestamp,
+		voting_power,
+		LAG(voting_power) OVER (ORDER BY block_height) AS previous_power
+		FROM powers
+	)
+	SELECT block_height, timestamp, voting_power
+	FROM changes
+	WHERE voting_power IS DISTINCT FROM previous_power
+	AND NOT (previous_power IS NULL AND voting_power = 0)
+	ORDER BY block_height DESC
+	`
+	rows, err := t.pool.Query(ctx, query, chainName, validatorId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	validator := &ValidatorDetails{Address: validatorAddress, History: make([]*ValidatorPower, 0)}
+	for rows.Next() {
+		power := &ValidatorPower{}
+		if err := rows.Scan(&power.Height, &power.Timestamp, &power.VotingPower); err != nil {
+			return nil, err
+		}
+		validator.History = append(validator.History, power)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(validator.History) > 0 {
+		validator.VotingPower = validator.History[0].VotingPower
+		validator.InSet = validator.VotingPower > 0
+	}
+
+	// the pub key is taken from the latest set the validator was in
+	err = t.pool.QueryRow(ctx, `
+	SELECT pub_key_type, encode(pub_key, 'base64')
+	FROM validator_set
+	WHERE chain_name = $1
+	AND validator = $2
+	ORDER BY block_height DESC
+	LIMIT 1
+	`, chainName, validatorId).Scan(&validator.PubKeyType, &validator.PubKey)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	return validator, nil
+}
+
+// GetValidatorsUptime gets the uptime of the validators over the latest indexed blocks
+//
+// Usage:
+//
+// # Used for the uptime leaderboard of the validators
+//
+// A validator counts a block as active when it is in the validator set that signs the block.
+// The uptime is the share of the active blocks the validator signed, the streaks are the
+// consecutive missed heights and the current streak is the one that reaches the last indexed height.
+//
+// Parameters:
+//   - chainName: the name of the chain
+//   - blocks: the amount of the latest indexed blocks in the window
+//
+// Returns:
+//   - *ValidatorsUptime: the window with the validators sorted by the uptime, the highest first
+//   - error: if the query fails or no signings are stored
+func (t *TimescaleDb) GetValidatorsUptime(
+	ctx context.Context,
+	chainName string,
+	blocks uint64,
+) (*ValidatorsUptime, error) {
+	var toHeight *uint64
+	err := t.pool.QueryRow(ctx, `
+	SELECT MAX(block_height) FROM validator_block_signing WHERE chain_name = $1
+	`, chainName).Scan(&toHeight)
+	if err != nil {
+		return nil, err
+	}
+	if toHeight == nil {
+		return nil, fmt.Errorf("no validator block signings stored")
+	}
+	uptime := &ValidatorsUptime{FromHeight: 1, ToHeight: *toHeight, Validators: make([]*ValidatorUptime, 0)}
+	if blocks > 0 && *toHeight > blocks {
+		uptime.FromHeight = *toHeight - blocks + 1
+	}
+
+	query := `
+	WITH sets AS (
+		SELECT
+		block_height,
+		timestamp,
+		LEAD(block_height) OVER (ORDER BY block_height) AS next_height
+		FROM (
+			SELECT DISTINCT block_height, timestamp
+			FROM validator_set
+			WHERE chain_name = $1
+		) s
+	),
+	active AS (
+		SELECT vs.validator, COUNT(*) AS active_blocks
+		FROM validator_block_signing s
+		JOIN sets
+			ON s.block_height >= sets.block_height
+			AND (sets.next_height IS NULL OR s.block_height < sets.next_height)
+		JOIN validator_set vs
+			ON vs.chain_name = $1
+			AND vs.block_height = sets.block_height
+			AND vs.timestamp = sets.timestamp
+		WHERE s.chain_name = $1
+		AND s.block_height BETWEEN $2 AND $3
+		GROUP BY vs.validator
+	),
+	missed AS (
+		SELECT
+		validator,
+		block_height,
+		block_height - ROW_NUMBER() OVER (PARTITION BY validator ORDER BY block_height) AS streak
+		FROM validator_missed_blocks
+		WHERE chain_name = $1
+		AND block_height BETWEEN $2 AND $3
+	),
+	streaks AS (
+		SELECT validator, COUNT(*) AS streak_length, MAX(block_height) AS last_height
+		FROM missed
+		GROUP BY validator, streak
+	),
+	missed_totals AS (
+		SELECT
+		validator,
+		SUM(streak_length)::BIGINT AS missed_blocks,
+		MAX(streak_length) AS longest_streak,
+		COALESCE(MAX(streak_length) FILTER (WHERE last_height = $3), 0) AS current_streak
+		FROM streaks
+		GROUP BY validator
+	)
+	SELECT
+	gv.address,
+	a.active_blocks,
+	COALESCE(mt.missed_blocks, 0) AS missed_blocks,
+	round((a.active_blocks - COALESCE(mt.missed_blocks, 0))::numeric / a.active_blocks * 100, 2) AS uptime_pct,
+	COALESCE(mt.longest_streak, 0) AS longest_streak,
+	COALESCE(mt.current_streak, 0) AS current_streak
+	FROM active a
+	JOIN gno_validators gv ON a.validator = gv.id
+	LEFT JOIN missed_totals mt ON a.validator = mt.validator
+	ORDER BY uptime_pct DESC, gv.address
+	`
+	rows, err := t.pool.Query(ctx, query, chainName, uptime.FromHeight, uptime.ToHeight)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		validator := &ValidatorUptime{}
+		if err := rows.Scan(
+			&validator.Address,
+			&validator.ActiveBlocks,
+			&validator.MissedBlocks,
+			&validator.Uptime,
+			&validator.LongestMissStreak,
+			&validator.CurrentMissStreak,
+		); err != nil {
+			return nil, err
+		}
+		uptime.Validators = append(uptime.Validators, validator)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return uptime, nil
+}
@@ -0,0 +1,243 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// rewindHeightTables holds the tables that store the height directly
+// and the name of the height column
+var rewindHeightTables = []struct {
+	table  string
+	column string
+}{
+	{"validator_block_signing", "block_height"},
+	{"validator_set", "block_height"},
+	{"validator_missed_blocks", "block_height"},
+	{"validator_updates", "block_height"},
+	{"block_events", "block_height"},
+	// a package first deployed above the height is removed, it is added again when the block is indexed
+	{"packages", "first_height"},
+	// the reconciliation rows have no transaction, so the balance changes are removed by their height
+	{"native_balance_changes", "block_height"},
+	{"blocks", "height"},
+}
+
+// rewindTxTables holds the hypertables that are linked to the transaction_general by the tx hash
+// the package_files table is not rewound, the files are shared by the deployments and a file
+// that is no longer linked to any message is harmless. The app specific tables are added by RegisterTable.
+var rewindTxTables = []string{
+	"bank_msg_send",
+	"vm_msg_call",
+	"vm_msg_add_package",
+	"vm_msg_run",
+	"msg_generic",
+	"tx_events",
+	"tx_signatures",
+	"grc20_transfers",
+	"nft_transfers",
+	"address_tx",
+}
+
+// RewindToHeight deletes every row above the given height for a given chain
+//
+// Usage:
+//
+// # Used by the orchestrator when the stored chain diverged from the rpc node (fork or node swap)
+//
+// All of the deletes are done within one transaction so the database either keeps the old data
+// or it is rewound completely. The tables linked by the tx hash are cleaned first and the
+// transaction_general is cleaned after them since it is used to find the tx hashes.
+// The GRC20 balances of the addresses and the owners of the NFTs with a removed transfer are
+// calculated again at the end, and so are the native balances of the addresses with a removed change.
+//
+// Parameters:
+//   - ctx: the context to use for the query
+//   - chainName: the name of the chain
+//   - height: the last height to keep, everything above it is deleted
+//
+// Returns:
+//   - error: if any of the deletes fails
+func (t *TimescaleDb) RewindToHeight(ctx context.Context, chainName string, height uint64) (err error) {
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin rewind transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback rewind transaction")
+			}
+		}
+	}()
+
+	// the balances are calculated from the transfers, so the touched pairs are read before the delete
+	tokens, addresses, err := rewindGrc20Pairs(ctx, tx, chainName, height)
+	if err != nil {
+		return err
+	}
+	collections, tokenIds, err := rewindNftPairs(ctx, tx, chainName, height)
+	if err != nil {
+		return err
+	}
+	nativeAddresses, denoms, err := rewindNativePairs(ctx, tx, chainName, height)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range getRewindTxTables() {
+		query := fmt.Sprintf(`
+		DELETE FROM %s m
+		USING transaction_general tx
+		WHERE tx.chain_name = $1
+		AND tx.block_height > $2
+		AND m.chain_name = tx.chain_name
+		AND m.tx_hash = tx.tx_hash
+		AND m.timestamp = tx.timestamp
+		`, pgx.Identifier{table}.Sanitize())
+		if _, err = tx.Exec(ctx, query, chainName, height); err != nil {
+			return fmt.Errorf("failed to rewind %s: %w", table, err)
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `
+	DELETE FROM transaction_general
+	WHERE chain_name = $1
+	AND block_height > $2
+	`, chainName, height); err != nil {
+		return fmt.Errorf("failed to rewind transaction_general: %w", err)
+	}
+
+	for _, hTable := range rewindHeightTables {
+		query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE chain_name = $1
+		AND %s > $2
+		`, pgx.Identifier{hTable.table}.Sanitize(), pgx.Identifier{hTable.column}.Sanitize())
+		if _, err = tx.Exec(ctx, query, chainName, height); err != nil {
+			return fmt.Errorf("failed to rewind %s: %w", hTable.table, err)
+		}
+	}
+
+	if err = refreshGrc20Balances(ctx, tx, chainName, tokens, addresses); err != nil {
+		return fmt.Errorf("failed to rewind grc20_balances: %w", err)
+	}
+	if err = refreshNftOwners(ctx, tx, chainName, collections, tokenIds); err != nil {
+		return fmt.Errorf("failed to rewind nft_owners: %w", err)
+	}
+	if err = refreshNativeBalances(ctx, tx, chainName, nativeAddresses, denoms); err != nil {
+		return fmt.Errorf("failed to rewind native_balances: %w", err)
+	}
+
+	// the processed ranges above the height are not valid anymore
+	if _, err = tx.Exec(ctx, `
+	DELETE FROM indexer_progress
+	WHERE chain_name = $1
+	AND from_height > $2
+	`, chainName, height); err != nil {
+		return fmt.Errorf("failed to rewind indexer_progress: %w", err)
+	}
+	if _, err = tx.Exec(ctx, `
+	UPDATE indexer_progress
+	SET to_height = $2
+	WHERE chain_name = $1
+	AND to_height > $2
+	`, chainName, height); err != nil {
+		return fmt.Errorf("failed to rewind indexer_progress: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit rewind transaction: %w", err)
+	}
+	return nil
+}
+
+// rewindGrc20Pairs returns the unique token and address pairs of the GRC20 transfers above the height
+func rewindGrc20Pairs(ctx context.Context, tx pgx.Tx, chainName string, height uint64) ([]string, []int32, error) {
+	rows, err := tx.Query(ctx, `
+	SELECT token_path, from_address AS address
+	FROM grc20_transfers
+	WHERE chain_name = $1 AND block_height > $2 AND from_address IS NOT NULL
+	UNION
+	SELECT token_path, to_address AS address
+	FROM grc20_transfers
+	WHERE chain_name = $1 AND block_height > $2 AND to_address IS NOT NULL
+	`, chainName, height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find the rewound grc20 transfers: %w", err)
+	}
+	defer rows.Close()
+	tokens := make([]string, 0)
+	addresses := make([]int32, 0)
+	for rows.Next() {
+		var token string
+		var address int32
+		if err := rows.Scan(&token, &address); err != nil {
+			return nil, nil, fmt.Errorf("failed to find the rewound grc20 transfers: %w", err)
+		}
+		tokens = append(tokens, token)
+		addresses = append(addresses, address)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find the rewound grc20 transfers: %w", err)
+	}
+	return tokens, addresses, nil
+}
+
+// rewindNftPairs returns the unique collection and token id pairs of the GRC721 transfers above the height
+func rewindNftPairs(ctx context.Context, tx pgx.Tx, chainName string, height uint64) ([]string, []string, error) {
+	rows, err := tx.Query(ctx, `
+	SELECT DISTINCT collection_path, token_id
+	FROM nft_transfers
+	WHERE chain_name = $1 AND block_height > $2
+	`, chainName, height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find the rewound nft transfers: %w", err)
+	}
+	defer rows.Close()
+	collections := make([]string, 0)
+	tokenIds := make([]string, 0)
+	for rows.Next() {
+		var collection, tokenId string
+		if err := rows.Scan(&collection, &tokenId); err != nil {
+			return nil, nil, fmt.Errorf("failed to find the rewound nft transfers: %w", err)
+		}
+		collections = append(collections, collection)
+		tokenIds = append(tokenIds, tokenId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find the rewound nft transfers: %w", err)
+	}
+	return collections, tokenIds, nil
+}
+
+// rewindNativePairs returns the unique address and denom pairs of the native balance changes above the height
+func rewindNativePairs(ctx context.Context, tx pgx.Tx, chainName string, height uint64) ([]int32, []string, error) {
+	rows, err := tx.Query(ctx, `
+	SELECT DISTINCT address, denom
+	FROM native_balance_changes
+	WHERE chain_name = $1 AND block_height > $2
+	`, chainName, height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find the rewound native balance changes: %w", err)
+	}
+	defer rows.Close()
+	addresses := make([]int32, 0)
+	denoms := make([]string, 0)
+	for rows.Next() {
+		var address int32
+		var denom string
+		if err := rows.Scan(&address, &denom); err != nil {
+			return nil, nil, fmt.Errorf("failed to find the rewound native balance changes: %w", err)
+		}
+		addresses = append(addresses, address)
+		denoms = append(denoms, denom)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find the rewound native balance changes: %w", err)
+	}
+	return addresses, denoms, nil
+}
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// tablesMu guards the upsertKeys and the rewindTxTables, the app specific tables
+// are added to them with RegisterTable
+var tablesMu sync.RWMutex
+
+// registeredTables holds the columns of the tables added with RegisterTable
+var registeredTables = make(map[string][]string)
+
+// Table describes an app specific table that is written with the generic rows
+//
+// The table needs to be created by the app, it needs the tx_hash, chain_name and timestamp
+// columns since the rows are removed together with their transaction on a rewind.
+type Table struct {
+	// Name of the table
+	Name string
+	// Columns written by the copy, in the same order as the values of the rows
+	Columns []string
+	// Key holds the primary key columns of the table, used by the skip and update insert modes
+	Key []string
+}
+
+// TableRows holds the rows of the registered table
+type TableRows struct {
+	Table string
+	// Rows hold the values of every row in the order of the table columns
+	Rows [][]any
+}
+
+// RegisterTable adds the table to the tables written by the indexer
+//
+// Usage:
+//
+// # Used when the app specific message types are registered, before the indexer is started
+//
+// Parameters:
+//   - table: the table with its columns and the primary key
+//
+// Returns:
+//   - error: if the table is not valid or it is already known
+func RegisterTable(table Table) error {
+	if table.Name == "" {
+		return errors.New("table name is required")
+	}
+	if len(table.Columns) == 0 || len(table.Key) == 0 {
+		return fmt.Errorf("table %s needs the columns and the key", table.Name)
+	}
+	for _, col := range []string{"tx_hash", "chain_name", "timestamp"} {
+		if !slices.Contains(table.Columns, col) {
+			return fmt.Errorf("table %s needs the %s column", table.Name, col)
+		}
+	}
+	for _, col := range table.Key {
+		if !slices.Contains(table.Columns, col) {
+			return fmt.Errorf("key column %s is not a column of table %s", col, table.Name)
+		}
+	}
+
+	tablesMu.Lock()
+	defer tablesMu.Unlock()
+	if _, ok := upsertKeys[table.Name]; ok {
+		return fmt.Errorf("table %s is already registered", table.Name)
+	}
+	upsertKeys[table.Name] = upsertKey{columns: slices.Clone(table.Key), constraint: true}
+	registeredTables[table.Name] = slices.Clone(table.Columns)
+	rewindTxTables = append(rewindTxTables, table.Name)
+	return nil
+}
+
+// tableColumns returns the columns of the registered table
+func tableColumns(table string) ([]string, bool) {
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	columns, ok := registeredTables[table]
+	return columns, ok
+}
+
+// getUpsertKey returns the identifying columns of the table
+func getUpsertKey(table string) (upsertKey, bool) {
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	key, ok := upsertKeys[table]
+	return key, ok
+}
+
+// getRewindTxTables returns the tables that are rewound by the tx hash
+func getRewindTxTables() []string {
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	return slices.Clone(rewindTxTables)
+}
+
+// InsertRows inserts the rows of the registered table into the database
+//
+// Parameters:
+//   - ctx: the context to use for the insert
+//   - rows: the rows of the registered table
+//
+// Returns:
+//   - error: if the table is not registered or the insertion fails
+func (t *TimescaleDb) InsertRows(ctx context.Context, rows TableRows) error {
+	return t.withCopier(ctx, func(c copier) error {
+		return copyTableRows(ctx, c, rows)
+	})
+}
+
+func copyTableRows(ctx context.Context, c copier, rows TableRows) error {
+	// Return early if no rows to insert
+	if len(rows.Rows) == 0 {
+		return nil
+	}
+
+	columns, ok := tableColumns(rows.Table)
+	if !ok {
+		return fmt.Errorf("table %s is not registered", rows.Table)
+	}
+	_, err := c.CopyFrom(ctx, pgx.Identifier{rows.Table}, columns, pgx.CopyFromRows(rows.Rows))
+	return err
+}
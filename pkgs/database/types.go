@@ -1,6 +1,7 @@
 package database
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -9,6 +10,8 @@ import (
 // TimescaleDb is the database connection pool
 type TimescaleDb struct {
 	pool *pgxpool.Pool
+	// how the rows are written to the hypertables, copy if empty
+	insertMode InsertMode
 }
 
 // DatabasePoolConfig is the configuration for the database pool.
@@ -29,3 +32,18 @@ type DatabasePoolConfig struct {
 	PoolHealthCheckPeriod     time.Duration
 	PoolMaxConnLifetimeJitter time.Duration
 }
+
+// HeightRange is a range of block heights, both ends are inclusive
+type HeightRange struct {
+	FromHeight uint64
+	ToHeight   uint64
+}
+
+// AddressBalance is the indexed native balance of an address at a height,
+// the reconciliation compares it with the balance of the node
+type AddressBalance struct {
+	AddressId int32
+	Address   string
+	// the balance of every denom the address had a change of
+	Balances map[string]*big.Int
+}
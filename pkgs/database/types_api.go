@@ -9,12 +9,23 @@ import (
 
 // BlockData represents the actual block data returned in the response body
 type BlockData struct {
-	Hash      string    `json:"hash" doc:"Block hash (base64 encoded)"`
-	Height    uint64    `json:"height" doc:"Block height"`
-	Timestamp time.Time `json:"timestamp" doc:"Block timestamp"`
-	ChainID   string    `json:"chain_id" doc:"Chain identifier"`
-	Txs       []string  `json:"txs" doc:"Transactions (base64 encoded)"`
-	TxCounter int       `json:"tx_count" doc:"Number of transactions in the block"`
+	Hash               string    `json:"hash" doc:"Block hash (base64 encoded)"`
+	Height             uint64    `json:"height" doc:"Block height"`
+	Timestamp          time.Time `json:"timestamp" doc:"Block timestamp"`
+	ChainID            string    `json:"chain_id" doc:"Chain identifier"`
+	Proposer           string    `json:"proposer" doc:"Address of the validator that proposed the block, empty if unknown"`
+	Txs                []string  `json:"txs" doc:"Transactions (base64 encoded)"`
+	TxCounter          int       `json:"tx_count" doc:"Number of transactions in the block"`
+	TotalTxs           uint64    `json:"total_txs" doc:"Number of transactions of the chain up to the block, 0 if unknown"`
+	AppHash            string    `json:"app_hash" doc:"App hash (base64 encoded)"`
+	LastBlockHash      string    `json:"last_block_hash" doc:"Hash of the previous block (base64 encoded)"`
+	LastCommitHash     string    `json:"last_commit_hash" doc:"Hash of the last commit (base64 encoded)"`
+	DataHash           string    `json:"data_hash" doc:"Hash of the transactions (base64 encoded)"`
+	ValidatorsHash     string    `json:"validators_hash" doc:"Hash of the validator set signing the block (base64 encoded)"`
+	NextValidatorsHash string    `json:"next_validators_hash" doc:"Hash of the validator set of the next block (base64 encoded)"`
+	ConsensusHash      string    `json:"consensus_hash" doc:"Hash of the consensus params (base64 encoded)"`
+	GasUsed            uint64    `json:"gas_used" doc:"Gas used by the transactions of the block"`
+	GasWanted          uint64    `json:"gas_wanted" doc:"Gas wanted by the transactions of the block"`
 }
 
 type Event struct {
@@ -24,6 +35,15 @@ type Event struct {
 	PkgPath    string      `json:"pkg_path" doc:"Package path"`
 }
 
+// TxEvent is an event emitted by a transaction together with its position
+type TxEvent struct {
+	TxHash      string    `json:"tx_hash" doc:"Transaction hash (base64 encoded)"`
+	Timestamp   time.Time `json:"timestamp" doc:"Transaction timestamp"`
+	BlockHeight uint64    `json:"block_height" doc:"Block height"`
+	EventIndex  int16     `json:"event_index" doc:"Order of the event within the transaction, starts from 0"`
+	Event
+}
+
 type Attribute struct {
 	Key   string `json:"key" doc:"Attribute key"`
 	Value string `json:"value" doc:"Attribute value"`
@@ -52,7 +72,7 @@ type MsgCall struct {
 	Send           []Amount  `json:"send" doc:"Send amount"`
 	PkgPath        string    `json:"pkg_path" doc:"Package path"`
 	FuncName       string    `json:"func_name" doc:"Function name"`
-	Args           string    `json:"args" doc:"Arguments"`
+	Args           []string  `json:"args" doc:"Arguments in the order they were passed to the function"`
 	MaxDeposit     []Amount  `json:"max_deposit" doc:"Max deposit"`
 	Signers        []string  `json:"signers" doc:"Signers (addresses)"`
 }
@@ -83,6 +103,16 @@ type MsgRun struct {
 	Signers        []string  `json:"signers" doc:"Signers (addresses)"`
 }
 
+type MsgGeneric struct {
+	MessageCounter int16     `json:"message_counter" doc:"Transaction order integer, starts from 0"`
+	TxHash         string    `json:"tx_hash" doc:"Transaction hash (base64 encoded)"`
+	Timestamp      time.Time `json:"timestamp" doc:"Transaction timestamp"`
+	TypeUrl        string    `json:"type_url" doc:"Amino type url of the message"`
+	Payload        string    `json:"payload" doc:"Message encoded as json"`
+	Addresses      []string  `json:"addresses" doc:"Addresses involved in the message"`
+	Signers        []string  `json:"signers" doc:"Signers (addresses)"`
+}
+
 type Transaction struct {
 	TxHash      string    `json:"tx_hash" doc:"Transaction hash (base64 encoded)"`
 	Timestamp   time.Time `json:"timestamp" doc:"Transaction timestamp"`
@@ -92,6 +122,14 @@ type Transaction struct {
 	GasWanted   uint64    `json:"gas_wanted" doc:"Gas wanted"`
 	Fee         Amount    `json:"fee" doc:"Fee"`
 	MsgTypes    []string  `json:"msg_types" doc:"Message types"`
+	Success     bool      `json:"success" doc:"Whether the transaction was executed successfully"`
+	TxError     string    `json:"tx_error,omitempty" doc:"Transaction error type (only for failed transactions)"`
+	TxLog       string    `json:"tx_log,omitempty" doc:"Transaction execution log"`
+	TxInfo      string    `json:"tx_info,omitempty" doc:"Transaction execution info"`
+	Memo        string    `json:"memo,omitempty" doc:"Transaction memo"`
+	Signers     []string  `json:"signers" doc:"Signers (addresses)"`
+	TxIndex     int32     `json:"tx_index" doc:"Position of the transaction within the block"`
+	GasPrice    *float64  `json:"gas_price,omitempty" doc:"Fee amount paid per unit of gas used (not set when the transaction used no gas)"`
 }
 
 type FullTxData struct {
@@ -105,6 +143,14 @@ type FullTxData struct {
 	GasWanted          uint64
 	Fee                Amount
 	MsgTypes           []string
+	Success            bool
+	TxError            string
+	TxLog              string
+	TxInfo             string
+	Memo               string
+	Signers            []string
+	TxIndex            int32
+	GasPrice           *float64
 }
 
 func (f *FullTxData) ToTransaction(decode func([]byte) (*[]Event, error)) (*Transaction, error) {
@@ -119,6 +165,14 @@ func (f *FullTxData) ToTransaction(decode func([]byte) (*[]Event, error)) (*Tran
 		GasWanted:   f.GasWanted,
 		Fee:         f.Fee,
 		MsgTypes:    f.MsgTypes,
+		Success:     f.Success,
+		TxError:     f.TxError,
+		TxLog:       f.TxLog,
+		TxInfo:      f.TxInfo,
+		Memo:        f.Memo,
+		Signers:     f.Signers,
+		TxIndex:     f.TxIndex,
+		GasPrice:    f.GasPrice,
 	}
 	if f.CompressionOn {
 		events, err := decode(f.TxEventsCompressed)
@@ -141,6 +195,19 @@ type BlockSigners struct {
 	SignedVals  []string `json:"signed_vals" doc:"Signed validators (addresses)"`
 }
 
+type BlockEvents struct {
+	Height           uint64       `json:"height" doc:"Block height"`
+	BeginBlock       []Event      `json:"begin_block" doc:"Events emitted in the BeginBlock of the block"`
+	EndBlock         []Event      `json:"end_block" doc:"Events emitted in the EndBlock of the block"`
+	ValidatorUpdates []*Validator `json:"validator_updates" doc:"Validator set changes of the EndBlock, 0 voting power removes the validator"`
+}
+
+type AddressInfo struct {
+	Address    string `json:"address" doc:"Gno address"`
+	PubKeyType string `json:"pub_key_type,omitempty" doc:"Amino type of the pub key (not set until a signature of the address includes it)"`
+	PubKey     string `json:"pub_key,omitempty" doc:"Pub key (base64 encoded)"`
+}
+
 type AddressTx struct {
 	Hash      string    `json:"hash" doc:"Transaction hash (base64 encoded)"`
 	Timestamp time.Time `json:"timestamp" doc:"Transaction timestamp"`
@@ -176,3 +243,135 @@ type ValidatorSigning struct {
 	TotalBlocks  int64      `json:"blocks_total" doc:"Total blocks"`
 	SigningRate  float64    `json:"signing_rate" doc:"Signing rate percentage"`
 }
+
+type PackageSource struct {
+	PkgPath   string              `json:"pkg_path" doc:"Package path"`
+	TxHash    string              `json:"tx_hash" doc:"Hash of the deployment transaction (base64 encoded)"`
+	Timestamp time.Time           `json:"timestamp" doc:"Deployment timestamp"`
+	MsgType   string              `json:"msg_type" doc:"Message that deployed the package: vm_msg_add_package or vm_msg_run"`
+	Files     []PackageSourceFile `json:"files" doc:"Source files of the package"`
+}
+
+type PackageSourceFile struct {
+	Name     string `json:"name" doc:"File name"`
+	Hash     string `json:"hash,omitempty" doc:"sha256 hash of the file body (base64 encoded)"`
+	Size     int32  `json:"size" doc:"Size of the file body in bytes"`
+	Archived bool   `json:"archived" doc:"True if the file body is stored and can be downloaded"`
+}
+
+type PackageFileContent struct {
+	Name    string `json:"name" doc:"File name"`
+	Hash    string `json:"hash" doc:"sha256 hash of the file body (base64 encoded)"`
+	Content []byte `json:"content" doc:"File body"`
+}
+
+type Package struct {
+	PkgPath        string    `json:"pkg_path" doc:"Package path"`
+	PkgName        string    `json:"pkg_name" doc:"Package name"`
+	Creator        string    `json:"creator" doc:"Creator address of the first deployment"`
+	FirstHeight    uint64    `json:"first_height" doc:"Block height of the first deployment"`
+	FirstTimestamp time.Time `json:"first_timestamp" doc:"Timestamp of the first deployment"`
+	TxHash         string    `json:"tx_hash" doc:"Hash of the first deployment transaction (base64 encoded)"`
+	FileCount      int32     `json:"file_count" doc:"Number of files of the first deployment"`
+}
+
+type PackageDetails struct {
+	Package
+	Deployments []PackageDeployment `json:"deployments" doc:"Every MsgAddPackage with the package path, newest first"`
+}
+
+type PackageDeployment struct {
+	TxHash      string    `json:"tx_hash" doc:"Transaction hash (base64 encoded)"`
+	BlockHeight uint64    `json:"block_height" doc:"Block height"`
+	Timestamp   time.Time `json:"timestamp" doc:"Transaction timestamp"`
+	Creator     string    `json:"creator" doc:"Creator address"`
+	FileCount   int       `json:"file_count" doc:"Number of deployed files"`
+	Success     bool      `json:"success" doc:"False if the deployment transaction failed"`
+}
+
+type Token struct {
+	TokenPath   string `json:"token_path" doc:"Token path, the realm path and the symbol of the token"`
+	HolderCount uint64 `json:"holder_count" doc:"Number of addresses with a positive balance"`
+	Supply      string `json:"supply" doc:"Sum of the balances"`
+	LastHeight  uint64 `json:"last_height" doc:"Block height of the last transfer"`
+}
+
+type TokenBalance struct {
+	TokenPath  string `json:"token_path" doc:"Token path, the realm path and the symbol of the token"`
+	Address    string `json:"address" doc:"Holder address"`
+	Balance    string `json:"balance" doc:"Balance in the smallest unit of the token"`
+	LastHeight uint64 `json:"last_height" doc:"Block height of the last transfer of the address"`
+}
+
+type NativeBalance struct {
+	Denom      string `json:"denom" doc:"Coin denomination"`
+	Balance    string `json:"balance" doc:"Balance in the smallest unit of the coin"`
+	LastHeight uint64 `json:"last_height" doc:"Block height of the last balance change of the address"`
+}
+
+type Validator struct {
+	Address     string `json:"address" doc:"Validator address"`
+	VotingPower int64  `json:"voting_power" doc:"Voting power"`
+	PubKeyType  string `json:"pub_key_type" doc:"Amino type of the pub key"`
+	PubKey      string `json:"pub_key" doc:"Pub key (base64 encoded)"`
+}
+
+type ValidatorSetData struct {
+	Height     uint64       `json:"height" doc:"Block height from which the set signs the blocks"`
+	Timestamp  time.Time    `json:"timestamp" doc:"Timestamp of the block"`
+	TotalPower int64        `json:"total_power" doc:"Voting power of the whole set"`
+	Validators []*Validator `json:"validators" doc:"Validators of the set, the highest voting power first"`
+}
+
+type ValidatorPower struct {
+	Height      uint64    `json:"height" doc:"Block height of the change"`
+	Timestamp   time.Time `json:"timestamp" doc:"Timestamp of the block"`
+	VotingPower int64     `json:"voting_power" doc:"Voting power from the height, 0 when the validator left the set"`
+}
+
+type ValidatorDetails struct {
+	Address     string            `json:"address" doc:"Validator address"`
+	InSet       bool              `json:"in_set" doc:"Whether the validator is in the latest stored set"`
+	VotingPower int64             `json:"voting_power" doc:"Current voting power"`
+	PubKeyType  string            `json:"pub_key_type" doc:"Amino type of the pub key"`
+	PubKey      string            `json:"pub_key" doc:"Pub key (base64 encoded)"`
+	History     []*ValidatorPower `json:"history" doc:"Changes of the voting power, the latest first"`
+}
+
+type ValidatorUptime struct {
+	Address           string  `json:"address" doc:"Validator address"`
+	ActiveBlocks      int64   `json:"active_blocks" doc:"Blocks in the window signed by a set the validator was in"`
+	MissedBlocks      int64   `json:"missed_blocks" doc:"Active blocks the validator didn't sign"`
+	Uptime            float64 `json:"uptime" doc:"Uptime percentage, the share of the active blocks the validator signed"`
+	LongestMissStreak int64   `json:"longest_miss_streak" doc:"Most consecutive blocks missed in the window"`
+	CurrentMissStreak int64   `json:"current_miss_streak" doc:"Consecutive blocks missed up to the last indexed block"`
+}
+
+type ValidatorsUptime struct {
+	FromHeight uint64             `json:"from_height" doc:"First block height of the window"`
+	ToHeight   uint64             `json:"to_height" doc:"Last block height of the window, the latest indexed block"`
+	Validators []*ValidatorUptime `json:"validators" doc:"Validators active in the window, the highest uptime first"`
+}
+
+type Nft struct {
+	CollectionPath string    `json:"collection_path" doc:"Realm path of the collection"`
+	TokenId        string    `json:"token_id" doc:"Token id"`
+	Owner          string    `json:"owner" doc:"Owner address, empty if the token is burned"`
+	Burned         bool      `json:"burned" doc:"True if the token is burned"`
+	LastHeight     uint64    `json:"last_height" doc:"Block height of the latest transfer"`
+	LastTimestamp  time.Time `json:"last_timestamp" doc:"Timestamp of the latest transfer"`
+	TxHash         string    `json:"tx_hash" doc:"Hash of the latest transfer transaction (base64 encoded)"`
+}
+
+type NftTransfer struct {
+	TxHash         string    `json:"tx_hash" doc:"Transaction hash (base64 encoded)"`
+	Timestamp      time.Time `json:"timestamp" doc:"Transaction timestamp"`
+	BlockHeight    uint64    `json:"block_height" doc:"Block height"`
+	EventIndex     int16     `json:"event_index" doc:"Order of the event within the transaction, starts from 0"`
+	CollectionPath string    `json:"collection_path" doc:"Realm path of the collection"`
+	Symbol         string    `json:"symbol" doc:"Symbol of the collection"`
+	TokenId        string    `json:"token_id" doc:"Token id"`
+	EventType      string    `json:"event_type" doc:"Transfer, Mint or Burn"`
+	From           string    `json:"from" doc:"Sender address, empty for a mint"`
+	To             string    `json:"to" doc:"Receiver address, empty for a burn"`
+}
@@ -0,0 +1,214 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// InsertMode selects how the rows are written to the hypertables
+type InsertMode string
+
+const (
+	// InsertModeCopy writes the rows with a plain COPY, fails on the rows that already exist
+	InsertModeCopy InsertMode = "copy"
+	// InsertModeSkip keeps the existing rows and writes only the new ones
+	InsertModeSkip InsertMode = "skip"
+	// InsertModeUpdate overwrites the existing rows with the new values
+	InsertModeUpdate InsertMode = "update"
+)
+
+// ParseInsertMode converts the string to the insert mode
+//
+// Parameters:
+//   - mode: the insert mode as a string, empty string is the copy mode
+//
+// Returns:
+//   - InsertMode: the insert mode
+//   - error: if the mode is not valid
+func ParseInsertMode(mode string) (InsertMode, error) {
+	switch InsertMode(mode) {
+	case "", InsertModeCopy:
+		return InsertModeCopy, nil
+	case InsertModeSkip:
+		return InsertModeSkip, nil
+	case InsertModeUpdate:
+		return InsertModeUpdate, nil
+	default:
+		return "", fmt.Errorf("invalid insert mode %q, please choose between copy, skip and update", mode)
+	}
+}
+
+// upsertKey holds the columns that identify a row of the table
+type upsertKey struct {
+	columns []string
+	// true if the columns are the primary key of the table and can be used with ON CONFLICT
+	constraint bool
+}
+
+// upsertKeys holds the identifying columns of every table written by the indexer,
+// they need to match the primary keys from the sql_data_types.
+// The keys of the app specific tables are added by RegisterTable.
+var upsertKeys = map[string]upsertKey{
+	"blocks":                  {[]string{"height", "timestamp", "chain_name"}, true},
+	"validator_block_signing": {[]string{"block_height", "timestamp", "chain_name"}, true},
+	"validator_set":           {[]string{"block_height", "timestamp", "chain_name", "validator"}, true},
+	"validator_updates":       {[]string{"block_height", "timestamp", "chain_name", "validator"}, true},
+	"block_events":            {[]string{"block_height", "timestamp", "chain_name", "phase"}, true},
+	"transaction_general":     {[]string{"tx_hash", "chain_name", "timestamp"}, true},
+	"bank_msg_send":           {[]string{"tx_hash", "timestamp", "chain_name", "message_counter"}, true},
+	"vm_msg_call":             {[]string{"tx_hash", "timestamp", "chain_name", "message_counter"}, true},
+	"vm_msg_add_package":      {[]string{"tx_hash", "timestamp", "chain_name", "message_counter"}, true},
+	"vm_msg_run":              {[]string{"tx_hash", "timestamp", "chain_name", "message_counter"}, true},
+	"msg_generic":             {[]string{"tx_hash", "timestamp", "chain_name", "message_counter"}, true},
+	"package_files":           {[]string{"file_hash", "chain_name"}, true},
+	"tx_events":               {[]string{"tx_hash", "chain_name", "timestamp", "event_index", "attr_index"}, true},
+	"tx_signatures":           {[]string{"tx_hash", "chain_name", "timestamp", "signature_index"}, true},
+	"grc20_transfers":         {[]string{"tx_hash", "chain_name", "timestamp", "event_index"}, true},
+	"nft_transfers":           {[]string{"tx_hash", "chain_name", "timestamp", "event_index"}, true},
+	"native_balance_changes":  {[]string{"tx_hash", "chain_name", "timestamp", "change_index"}, true},
+	// address_tx has no primary key so the rows are matched manually
+	"address_tx": {[]string{"address", "tx_hash", "chain_name", "timestamp"}, false},
+}
+
+// SetInsertMode sets how the rows are written to the hypertables
+//
+// Usage:
+//
+// # Used when the indexer is started, the skip and update modes allow re-indexing of already stored ranges
+//
+// Parameters:
+//   - mode: the insert mode
+func (t *TimescaleDb) SetInsertMode(mode InsertMode) {
+	t.insertMode = mode
+}
+
+// withCopier runs the copy with the copier that matches the insert mode.
+// The skip and update modes need a transaction for the temporary table so one is opened for them.
+func (t *TimescaleDb) withCopier(ctx context.Context, copyFn func(c copier) error) (err error) {
+	if t.insertMode == "" || t.insertMode == InsertModeCopy {
+		return copyFn(t.pool)
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin insert transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				l.Error().Err(rbErr).Msg("failed to rollback insert transaction")
+			}
+		}
+	}()
+
+	if err = copyFn(txCopier(tx, t.insertMode)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// txCopier returns the copier for the transaction that matches the insert mode
+func txCopier(tx pgx.Tx, mode InsertMode) copier {
+	if mode == "" || mode == InsertModeCopy {
+		return tx
+	}
+	return &upsertCopier{tx: tx, mode: mode}
+}
+
+// upsertCopier copies the rows to a temporary table first and then moves them to the
+// target table while skipping or updating the rows that already exist
+type upsertCopier struct {
+	tx   pgx.Tx
+	mode InsertMode
+}
+
+// CopyFrom implements the copier interface
+func (u *upsertCopier) CopyFrom(
+	ctx context.Context,
+	tableName pgx.Identifier,
+	columnNames []string,
+	rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	table := strings.Join(tableName, ".")
+	key, ok := getUpsertKey(table)
+	if !ok {
+		return 0, fmt.Errorf("no upsert key defined for table %s", table)
+	}
+
+	target := tableName.Sanitize()
+	tmpName := "tmp_" + strings.Join(tableName, "_")
+	tmp := pgx.Identifier{tmpName}.Sanitize()
+
+	if _, err := u.tx.Exec(ctx, fmt.Sprintf(`DROP TABLE IF EXISTS %s`, tmp)); err != nil {
+		return 0, fmt.Errorf("failed to drop temporary table %s: %w", tmpName, err)
+	}
+	if _, err := u.tx.Exec(ctx, fmt.Sprintf(
+		`CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP`, tmp, target,
+	)); err != nil {
+		return 0, fmt.Errorf("failed to create temporary table %s: %w", tmpName, err)
+	}
+	if _, err := u.tx.CopyFrom(ctx, pgx.Identifier{tmpName}, columnNames, rowSrc); err != nil {
+		return 0, fmt.Errorf("failed to copy to temporary table %s: %w", tmpName, err)
+	}
+
+	columns := sanitizeColumns(columnNames)
+	keyColumns := sanitizeColumns(key.columns)
+	matchKeys := make([]string, len(keyColumns))
+	for i, col := range keyColumns {
+		matchKeys[i] = fmt.Sprintf("x.%s = t.%s", col, col)
+	}
+	match := strings.Join(matchKeys, " AND ")
+	selectColumns := "t." + strings.Join(columns, ", t.")
+	insert := fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM %s t`,
+		target, strings.Join(columns, ", "), selectColumns, tmp)
+
+	var query string
+	switch {
+	case key.constraint && u.mode == InsertModeSkip:
+		query = fmt.Sprintf(`%s ON CONFLICT (%s) DO NOTHING`, insert, strings.Join(keyColumns, ", "))
+	case key.constraint && u.mode == InsertModeUpdate:
+		updates := make([]string, 0, len(columns))
+		for _, col := range columns {
+			if !containsColumn(keyColumns, col) {
+				updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", col, col))
+			}
+		}
+		query = fmt.Sprintf(`%s ON CONFLICT (%s) DO UPDATE SET %s`,
+			insert, strings.Join(keyColumns, ", "), strings.Join(updates, ", "))
+	case u.mode == InsertModeSkip:
+		query = fmt.Sprintf(`%s WHERE NOT EXISTS (SELECT 1 FROM %s x WHERE %s)`, insert, target, match)
+	default:
+		// without a constraint the existing rows are deleted and written again
+		if _, err := u.tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s x USING %s t WHERE %s`, target, tmp, match)); err != nil {
+			return 0, fmt.Errorf("failed to delete existing rows from %s: %w", table, err)
+		}
+		query = insert
+	}
+
+	tag, err := u.tx.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert rows to %s: %w", table, err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func sanitizeColumns(columns []string) []string {
+	sanitized := make([]string, len(columns))
+	for i, col := range columns {
+		sanitized[i] = pgx.Identifier{col}.Sanitize()
+	}
+	return sanitized
+}
+
+func containsColumn(columns []string, column string) bool {
+	for _, col := range columns {
+		if col == column {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
 	dbinit "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/db_init"
 )
 
@@ -14,17 +16,33 @@ import (
 //   - Height (uint64)
 //   - Timestamp (time.Time)
 //   - Chain ID (string)
-//   - Proposer address (int32)
-//   - Txs ([][]byte)
 //   - Chain Name (string)
+//   - Proposer (int32, pull from the gno_validators table)
+//   - Num txs and total txs (uint64, from the block header)
+//   - Header hashes (bytea, app, last block, last commit, data, validators, next validators and consensus)
+//   - Gas used and gas wanted (uint64, the totals of the block transactions)
+//
+// The header columns are nullable since the blocks indexed by the older versions don't have them.
 //
 // PRIMARY KEY (height, timestamp, chain_name)
 type Blocks struct {
-	Hash      []byte    `db:"hash" dbtype:"bytea" nullable:"false" primary:"false"`
-	Height    uint64    `db:"height" dbtype:"bigint" nullable:"false" primary:"true"`
-	Timestamp time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
-	ChainID   string    `db:"chain_id" dbtype:"TEXT" nullable:"false" primary:"false"`
-	ChainName string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Hash               []byte    `db:"hash" dbtype:"bytea" nullable:"false" primary:"false"`
+	Height             uint64    `db:"height" dbtype:"bigint" nullable:"false" primary:"true"`
+	Timestamp          time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	ChainID            string    `db:"chain_id" dbtype:"TEXT" nullable:"false" primary:"false"`
+	ChainName          string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Proposer           int32     `db:"proposer" dbtype:"integer" nullable:"true" primary:"false"`
+	NumTxs             uint64    `db:"num_txs" dbtype:"bigint" nullable:"true" primary:"false"`
+	TotalTxs           uint64    `db:"total_txs" dbtype:"bigint" nullable:"true" primary:"false"`
+	AppHash            []byte    `db:"app_hash" dbtype:"bytea" nullable:"true" primary:"false"`
+	LastBlockHash      []byte    `db:"last_block_hash" dbtype:"bytea" nullable:"true" primary:"false"`
+	LastCommitHash     []byte    `db:"last_commit_hash" dbtype:"bytea" nullable:"true" primary:"false"`
+	DataHash           []byte    `db:"data_hash" dbtype:"bytea" nullable:"true" primary:"false"`
+	ValidatorsHash     []byte    `db:"validators_hash" dbtype:"bytea" nullable:"true" primary:"false"`
+	NextValidatorsHash []byte    `db:"next_validators_hash" dbtype:"bytea" nullable:"true" primary:"false"`
+	ConsensusHash      []byte    `db:"consensus_hash" dbtype:"bytea" nullable:"true" primary:"false"`
+	GasUsed            uint64    `db:"gas_used" dbtype:"bigint" nullable:"true" primary:"false"`
+	GasWanted          uint64    `db:"gas_wanted" dbtype:"bigint" nullable:"true" primary:"false"`
 }
 
 func (b Blocks) TableName() string {
@@ -84,6 +102,173 @@ func (vbs ValidatorBlockSigning) TableColumns() []string {
 	return columns
 }
 
+// ValidatorSet represents a member of the validator set with database mapping information
+// the set is stored at the height where the validators hash of the block header changed,
+// one row for every validator of the set
+//
+// Stores:
+//   - Block height (uint64, the first height signed by the set)
+//   - Timestamp (time.Time)
+//   - Chain Name (string)
+//   - Validator (int32, pull from the gno_validators table)
+//   - Validators hash (bytea, the validators hash of the block header)
+//   - Voting power (int64)
+//   - Pub key type (string, the amino type of the pub key)
+//   - Pub key (bytea)
+//
+// PRIMARY KEY (block_height, timestamp, chain_name, validator)
+type ValidatorSet struct {
+	BlockHeight    uint64    `db:"block_height" dbtype:"bigint" nullable:"false" primary:"true"`
+	Timestamp      time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	ChainName      string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Validator      int32     `db:"validator" dbtype:"integer" nullable:"false" primary:"true"`
+	ValidatorsHash []byte    `db:"validators_hash" dbtype:"bytea" nullable:"false" primary:"false"`
+	VotingPower    int64     `db:"voting_power" dbtype:"bigint" nullable:"false" primary:"false"`
+	PubKeyType     string    `db:"pub_key_type" dbtype:"TEXT" nullable:"false" primary:"false"`
+	PubKey         []byte    `db:"pub_key" dbtype:"bytea" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the ValidatorSet struct
+func (vs ValidatorSet) TableName() string {
+	return "validator_set"
+}
+
+// GetTableInfo returns the table info for the ValidatorSet struct
+func (vs ValidatorSet) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(vs, vs.TableName())
+}
+
+func (vs ValidatorSet) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(vs)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// ValidatorMissedBlock represents a block the validator didn't sign while it was in the validator set,
+// the rows are derived from the validator_block_signing and validator_set tables
+//
+// Stores:
+//   - Block height (uint64)
+//   - Timestamp (time.Time)
+//   - Chain Name (string)
+//   - Validator (int32, pull from the gno_validators table)
+//
+// PRIMARY KEY (block_height, timestamp, chain_name, validator)
+type ValidatorMissedBlock struct {
+	BlockHeight uint64    `db:"block_height" dbtype:"bigint" nullable:"false" primary:"true"`
+	Timestamp   time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	ChainName   string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Validator   int32     `db:"validator" dbtype:"integer" nullable:"false" primary:"true"`
+}
+
+// TableName returns the name of the table for the ValidatorMissedBlock struct
+func (vmb ValidatorMissedBlock) TableName() string {
+	return "validator_missed_blocks"
+}
+
+// GetTableInfo returns the table info for the ValidatorMissedBlock struct
+func (vmb ValidatorMissedBlock) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(vmb, vmb.TableName())
+}
+
+func (vmb ValidatorMissedBlock) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(vmb)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// ValidatorUpdate represents a change of the validator set returned by the EndBlock of a block
+//
+// Stores:
+//   - Block height (uint64)
+//   - Timestamp (time.Time)
+//   - Chain Name (string)
+//   - Validator (int32, pull from the gno_validators table)
+//   - Voting power (int64, zero when the validator is removed from the set)
+//   - Pub key type (string, the amino type of the pub key)
+//   - Pub key (bytea)
+//
+// PRIMARY KEY (block_height, timestamp, chain_name, validator)
+type ValidatorUpdate struct {
+	BlockHeight uint64    `db:"block_height" dbtype:"bigint" nullable:"false" primary:"true"`
+	Timestamp   time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	ChainName   string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Validator   int32     `db:"validator" dbtype:"integer" nullable:"false" primary:"true"`
+	VotingPower int64     `db:"voting_power" dbtype:"bigint" nullable:"false" primary:"false"`
+	PubKeyType  string    `db:"pub_key_type" dbtype:"TEXT" nullable:"false" primary:"false"`
+	PubKey      []byte    `db:"pub_key" dbtype:"bytea" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the ValidatorUpdate struct
+func (vu ValidatorUpdate) TableName() string {
+	return "validator_updates"
+}
+
+// GetTableInfo returns the table info for the ValidatorUpdate struct
+func (vu ValidatorUpdate) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(vu, vu.TableName())
+}
+
+func (vu ValidatorUpdate) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(vu)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// BlockEvent represents the events of a block that are not tied to a transaction,
+// one row for the BeginBlock and one for the EndBlock events of the block if there are any
+//
+// Stores:
+//   - Block height (uint64)
+//   - Timestamp (time.Time)
+//   - Chain Name (string)
+//   - Phase (string, begin_block or end_block)
+//   - Events ([]Event, the native format) or Events compressed (bytea)
+//   - Compression on (bool)
+//
+// PRIMARY KEY (block_height, timestamp, chain_name, phase)
+type BlockEvent struct {
+	BlockHeight      uint64    `db:"block_height" dbtype:"bigint" nullable:"false" primary:"true"`
+	Timestamp        time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	ChainName        string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Phase            string    `db:"phase" dbtype:"TEXT" nullable:"false" primary:"true"`
+	Events           []Event   `db:"events" dbtype:"event[]" nullable:"true" primary:"false"`
+	EventsCompressed []byte    `db:"events_compressed" dbtype:"bytea" nullable:"true" primary:"false"`
+	CompressionOn    bool      `db:"compression_on" dbtype:"boolean" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the BlockEvent struct
+func (be BlockEvent) TableName() string {
+	return "block_events"
+}
+
+// GetTableInfo returns the table info for the BlockEvent struct
+func (be BlockEvent) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(be, be.TableName())
+}
+
+func (be BlockEvent) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(be)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
 // AddressTx represents a transaction with database mapping information
 //
 // Stores:
@@ -133,6 +318,10 @@ func (at AddressTx) TableColumns() []string {
 // - GasUsed (uint64)
 // - GasWanted (uint64)
 // - Fee (Fee)
+// - Success (bool)
+// - TxError (string)
+// - TxLog (string)
+// - TxInfo (string)
 //
 // PRIMARY KEY (tx_hash, chain_name, timestamp)
 type TransactionGeneral struct {
@@ -149,6 +338,18 @@ type TransactionGeneral struct {
 	GasUsed            uint64  `db:"gas_used" dbtype:"bigint" nullable:"false" primary:"false"`
 	GasWanted          uint64  `db:"gas_wanted" dbtype:"bigint" nullable:"false" primary:"false"`
 	Fee                Amount  `db:"fee" dbtype:"amount" nullable:"false" primary:"false"`
+	// execution result of the transaction, failed transactions are still included in the block
+	// so the error type url, log and info are stored to tell them apart
+	Success bool    `db:"success" dbtype:"boolean" nullable:"false" primary:"false"`
+	TxError string  `db:"tx_error" dbtype:"TEXT" nullable:"true" primary:"false"`
+	TxLog   string  `db:"tx_log" dbtype:"TEXT" nullable:"true" primary:"false"`
+	TxInfo  string  `db:"tx_info" dbtype:"TEXT" nullable:"true" primary:"false"`
+	Memo    string  `db:"memo" dbtype:"TEXT" nullable:"true" primary:"false"`
+	Signers []int32 `db:"signers" dbtype:"INTEGER[]" nullable:"true" primary:"false"`
+	// position of the transaction within the block
+	TxIndex int32 `db:"tx_index" dbtype:"integer" nullable:"true" primary:"false"`
+	// fee amount paid per unit of gas used, nil when the transaction used no gas
+	GasPrice *float64 `db:"gas_price" dbtype:"DOUBLE PRECISION" nullable:"true" primary:"false"`
 }
 
 // TableName returns the name of the table for the TransactionGeneral struct
@@ -238,7 +439,7 @@ func (ms MsgSend) TableColumns() []string {
 //
 // Returns:
 //   - *TxAddresses: grouped addresses for this transaction
-func (ms *MsgSend) GetAllAddresses() *TxAddresses {
+func (ms MsgSend) GetAllAddresses() *TxAddresses {
 	txAddresses := NewTxAddresses(ms.TxHash)
 	txAddresses.AddAddress(ms.FromAddress)
 	if ms.ToAddress != 0 {
@@ -259,7 +460,7 @@ func (ms *MsgSend) GetAllAddresses() *TxAddresses {
 //   - Caller (int32)
 //   - PkgPath (string)
 //   - FuncName (string)
-//   - Args (string)
+//   - Args (string[])
 //   - Send (Amount[])
 //   - MaxDeposit (Amount[])
 //   - Signers (int32[])
@@ -274,7 +475,7 @@ type MsgCall struct {
 	Caller         int32    `db:"caller" dbtype:"INTEGER" nullable:"false" primary:"false"`
 	PkgPath        string   `db:"pkg_path" dbtype:"TEXT" nullable:"true" primary:"false"`
 	FuncName       string   `db:"func_name" dbtype:"TEXT" nullable:"true" primary:"false"`
-	Args           string   `db:"args" dbtype:"TEXT" nullable:"true" primary:"false"`
+	Args           []string `db:"args" dbtype:"TEXT[]" nullable:"true" primary:"false"`
 	Send           []Amount `db:"send" dbtype:"amount[]" nullable:"true" primary:"false"`
 	MaxDeposit     []Amount `db:"max_deposit" dbtype:"amount[]" nullable:"true" primary:"false"`
 	Signers        []int32  `db:"signers" dbtype:"INTEGER[]" nullable:"false" primary:"false"`
@@ -308,7 +509,7 @@ func (mc MsgCall) TableColumns() []string {
 //
 // Returns:
 //   - *TxAddresses: grouped addresses for this transaction
-func (mc *MsgCall) GetAllAddresses() *TxAddresses {
+func (mc MsgCall) GetAllAddresses() *TxAddresses {
 	txAddresses := NewTxAddresses(mc.TxHash)
 	txAddresses.AddAddress(mc.Caller)
 	for _, addr := range mc.Signers {
@@ -326,6 +527,7 @@ func (mc *MsgCall) GetAllAddresses() *TxAddresses {
 // - PkgPath (string)
 // - PkgName (string)
 // - PkgFileNames (string[])
+// - PkgFileHashes (bytea[])
 // - Send (Amount[])
 // - MaxDeposit (Amount[])
 // - Signers (int32[])
@@ -342,8 +544,10 @@ type MsgAddPackage struct {
 	PkgPath      string   `db:"pkg_path" dbtype:"TEXT" nullable:"true" primary:"false"`
 	PkgName      string   `db:"pkg_name" dbtype:"TEXT" nullable:"true" primary:"false"`
 	PkgFileNames []string `db:"pkg_file_names" dbtype:"TEXT[]" nullable:"true" primary:"false"`
-	Send         []Amount `db:"send" dbtype:"amount[]" nullable:"true" primary:"false"`
-	MaxDeposit   []Amount `db:"max_deposit" dbtype:"amount[]" nullable:"true" primary:"false"`
+	// sha256 hashes of the file bodies in the same order as the file names, pull from the package_files table
+	PkgFileHashes [][]byte `db:"pkg_file_hashes" dbtype:"BYTEA[]" nullable:"true" primary:"false"`
+	Send          []Amount `db:"send" dbtype:"amount[]" nullable:"true" primary:"false"`
+	MaxDeposit    []Amount `db:"max_deposit" dbtype:"amount[]" nullable:"true" primary:"false"`
 	// signers are the addresses that signed the transaction
 	Signers        []int32 `db:"signers" dbtype:"INTEGER[]" nullable:"false" primary:"false"`
 	MessageCounter int16   `db:"message_counter" dbtype:"smallint" nullable:"false" primary:"true"`
@@ -376,7 +580,7 @@ func (ma MsgAddPackage) TableColumns() []string {
 //
 // Returns:
 //   - *TxAddresses: grouped addresses for this transaction
-func (ma *MsgAddPackage) GetAllAddresses() *TxAddresses {
+func (ma MsgAddPackage) GetAllAddresses() *TxAddresses {
 	txAddresses := NewTxAddresses(ma.TxHash)
 	txAddresses.AddAddress(ma.Creator)
 	for _, addr := range ma.Signers {
@@ -395,6 +599,7 @@ func (ma *MsgAddPackage) GetAllAddresses() *TxAddresses {
 // - PkgPath (string)
 // - PkgName (string)
 // - PkgFileNames (string[])
+// - PkgFileHashes (bytea[])
 // - Send (Amount[])
 // - MaxDeposit (Amount[])
 // - Signers (int32[])
@@ -410,8 +615,10 @@ type MsgRun struct {
 	PkgPath      string   `db:"pkg_path" dbtype:"TEXT" nullable:"true" primary:"false"`
 	PkgName      string   `db:"pkg_name" dbtype:"TEXT" nullable:"true" primary:"false"`
 	PkgFileNames []string `db:"pkg_file_names" dbtype:"TEXT[]" nullable:"true" primary:"false"`
-	Send         []Amount `db:"send" dbtype:"amount[]" nullable:"true" primary:"false"`
-	MaxDeposit   []Amount `db:"max_deposit" dbtype:"amount[]" nullable:"true" primary:"false"`
+	// sha256 hashes of the file bodies in the same order as the file names, pull from the package_files table
+	PkgFileHashes [][]byte `db:"pkg_file_hashes" dbtype:"BYTEA[]" nullable:"true" primary:"false"`
+	Send          []Amount `db:"send" dbtype:"amount[]" nullable:"true" primary:"false"`
+	MaxDeposit    []Amount `db:"max_deposit" dbtype:"amount[]" nullable:"true" primary:"false"`
 	// signers are the addresses that signed the transaction
 	Signers        []int32 `db:"signers" dbtype:"INTEGER[]" nullable:"false" primary:"false"`
 	MessageCounter int16   `db:"message_counter" dbtype:"smallint" nullable:"false" primary:"true"`
@@ -445,7 +652,7 @@ func (mr MsgRun) GetTableInfo() (*dbinit.TableInfo, error) {
 //
 // Returns:
 //   - *TxAddresses: grouped addresses for this transaction
-func (mr *MsgRun) GetAllAddresses() *TxAddresses {
+func (mr MsgRun) GetAllAddresses() *TxAddresses {
 	txAddresses := NewTxAddresses(mr.TxHash)
 	txAddresses.AddAddress(mr.Caller)
 	for _, addr := range mr.Signers {
@@ -453,3 +660,317 @@ func (mr *MsgRun) GetAllAddresses() *TxAddresses {
 	}
 	return txAddresses
 }
+
+// MsgGeneric represents any message that doesn't have its own table
+//
+// Stores:
+// - TxHash (bytea)
+// - Timestamp (time.Time)
+// - ChainName (string)
+// - TypeUrl (string)
+// - Payload (string, amino JSON of the message)
+// - Addresses (int32[])
+// - Signers (int32[])
+// - MessageCounter (int16)
+//
+// PRIMARY KEY (tx_hash, chain_name, timestamp, message_counter)
+type MsgGeneric struct {
+	TxHash    []byte    `db:"tx_hash" dbtype:"bytea" nullable:"false" primary:"true"`
+	Timestamp time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	ChainName string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	// amino type url of the message, for example /bank.MsgMultiSend
+	TypeUrl string `db:"type_url" dbtype:"TEXT" nullable:"false" primary:"false"`
+	// the messages not registered with amino are stored as the type url and the base64 value
+	Payload string `db:"payload" dbtype:"JSONB" nullable:"false" primary:"false"`
+	// gno addresses of the message signers, pull from the gno_addresses table
+	Addresses []int32 `db:"addresses" dbtype:"INTEGER[]" nullable:"true" primary:"false"`
+	// signers are the addresses that signed the transaction
+	Signers        []int32 `db:"signers" dbtype:"INTEGER[]" nullable:"false" primary:"false"`
+	MessageCounter int16   `db:"message_counter" dbtype:"smallint" nullable:"false" primary:"true"`
+}
+
+// A method to get the columns of the struct
+// Useful in GnoMessage interface
+func (mg MsgGeneric) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(mg)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+func (mg MsgGeneric) TableName() string {
+	return "msg_generic"
+}
+
+// GetTableInfo returns the table info for the MsgGeneric struct
+func (mg MsgGeneric) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(mg, mg.TableName())
+}
+
+// GetAllAddresses returns all the addresses that are involved in the message
+// Groups the message addresses and signers for this transaction
+//
+// Returns:
+//   - *TxAddresses: grouped addresses for this transaction
+func (mg MsgGeneric) GetAllAddresses() *TxAddresses {
+	txAddresses := NewTxAddresses(mg.TxHash)
+	for _, addr := range mg.Addresses {
+		txAddresses.AddAddress(addr)
+	}
+	for _, addr := range mg.Signers {
+		txAddresses.AddAddress(addr)
+	}
+	return txAddresses
+}
+
+// TxEvent represents a single attribute of an event emitted by a transaction
+// every attribute has its own row so the events can be searched without decompressing the transactions
+//
+// Stores:
+// - TxHash (bytea)
+// - ChainName (string)
+// - Timestamp (time.Time)
+// - EventIndex (int16, order of the event within the transaction)
+// - AttrIndex (int16, order of the attribute within the event)
+// - BlockHeight (uint64)
+// - EventType (string)
+// - AtType (string)
+// - PkgPath (string)
+// - AttrKey (string, null for an event without attributes)
+// - AttrValue (string, null for an event without attributes)
+//
+// PRIMARY KEY (tx_hash, chain_name, timestamp, event_index, attr_index)
+type TxEvent struct {
+	TxHash      []byte    `db:"tx_hash" dbtype:"bytea" nullable:"false" primary:"true"`
+	ChainName   string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Timestamp   time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	EventIndex  int16     `db:"event_index" dbtype:"smallint" nullable:"false" primary:"true"`
+	AttrIndex   int16     `db:"attr_index" dbtype:"smallint" nullable:"false" primary:"true"`
+	BlockHeight uint64    `db:"block_height" dbtype:"bigint" nullable:"false" primary:"false"`
+	EventType   string    `db:"event_type" dbtype:"TEXT" nullable:"false" primary:"false"`
+	AtType      string    `db:"at_type" dbtype:"TEXT" nullable:"true" primary:"false"`
+	PkgPath     string    `db:"pkg_path" dbtype:"TEXT" nullable:"true" primary:"false"`
+	AttrKey     *string   `db:"attr_key" dbtype:"TEXT" nullable:"true" primary:"false"`
+	AttrValue   *string   `db:"attr_value" dbtype:"TEXT" nullable:"true" primary:"false"`
+}
+
+// TableName returns the name of the table for the TxEvent struct
+func (te TxEvent) TableName() string {
+	return "tx_events"
+}
+
+// GetTableInfo returns the table info for the TxEvent struct
+func (te TxEvent) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(te, te.TableName())
+}
+
+func (te TxEvent) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(te)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// TxSignature represents a signature of a transaction, the signatures are in the same order as the signers
+//
+// Stores:
+// - TxHash (bytea)
+// - ChainName (string)
+// - Timestamp (time.Time)
+// - SignatureIndex (int16, order of the signature within the transaction)
+// - BlockHeight (uint64)
+// - Signer (int32, address id, null if the signer can't be known)
+// - PubKeyType (string, null when the signature comes without the public key)
+// - PubKey (bytea, null when the signature comes without the public key)
+// - Signature (bytea)
+// - AccountNumber (uint64, account number of the signer, null if it can't be known)
+// - Sequence (uint64, sequence of the signer the transaction was signed with, null if it can't be known)
+//
+// PRIMARY KEY (tx_hash, chain_name, timestamp, signature_index)
+type TxSignature struct {
+	TxHash         []byte    `db:"tx_hash" dbtype:"bytea" nullable:"false" primary:"true"`
+	ChainName      string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Timestamp      time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	SignatureIndex int16     `db:"signature_index" dbtype:"smallint" nullable:"false" primary:"true"`
+	BlockHeight    uint64    `db:"block_height" dbtype:"bigint" nullable:"false" primary:"false"`
+	Signer         *int32    `db:"signer" dbtype:"integer" nullable:"true" primary:"false"`
+	PubKeyType     *string   `db:"pub_key_type" dbtype:"TEXT" nullable:"true" primary:"false"`
+	PubKey         []byte    `db:"pub_key" dbtype:"bytea" nullable:"true" primary:"false"`
+	Signature      []byte    `db:"signature" dbtype:"bytea" nullable:"false" primary:"false"`
+	AccountNumber  *uint64   `db:"account_number" dbtype:"bigint" nullable:"true" primary:"false"`
+	Sequence       *uint64   `db:"sequence" dbtype:"bigint" nullable:"true" primary:"false"`
+}
+
+// TableName returns the name of the table for the TxSignature struct
+func (ts TxSignature) TableName() string {
+	return "tx_signatures"
+}
+
+// GetTableInfo returns the table info for the TxSignature struct
+func (ts TxSignature) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(ts, ts.TableName())
+}
+
+func (ts TxSignature) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(ts)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// Grc20Transfer represents a transfer, mint or burn of a GRC20 token
+// recognised from the events emitted by the token realm
+//
+// Stores:
+// - TxHash (bytea)
+// - ChainName (string)
+// - Timestamp (time.Time)
+// - EventIndex (int16, order of the event within the transaction)
+// - BlockHeight (uint64)
+// - TokenPath (string, the realm that emitted the event)
+// - EventType (string, Transfer, Mint or Burn)
+// - FromAddress (int32, null for a mint)
+// - ToAddress (int32, null for a burn)
+// - Amount (numeric)
+//
+// PRIMARY KEY (tx_hash, chain_name, timestamp, event_index)
+type Grc20Transfer struct {
+	TxHash      []byte         `db:"tx_hash" dbtype:"bytea" nullable:"false" primary:"true"`
+	ChainName   string         `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Timestamp   time.Time      `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	EventIndex  int16          `db:"event_index" dbtype:"smallint" nullable:"false" primary:"true"`
+	BlockHeight uint64         `db:"block_height" dbtype:"bigint" nullable:"false" primary:"false"`
+	TokenPath   string         `db:"token_path" dbtype:"TEXT" nullable:"false" primary:"false"`
+	EventType   string         `db:"event_type" dbtype:"TEXT" nullable:"false" primary:"false"`
+	FromAddress *int32         `db:"from_address" dbtype:"integer" nullable:"true" primary:"false"`
+	ToAddress   *int32         `db:"to_address" dbtype:"integer" nullable:"true" primary:"false"`
+	Amount      pgtype.Numeric `db:"amount" dbtype:"NUMERIC" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the Grc20Transfer struct
+func (gt Grc20Transfer) TableName() string {
+	return "grc20_transfers"
+}
+
+// GetTableInfo returns the table info for the Grc20Transfer struct
+func (gt Grc20Transfer) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(gt, gt.TableName())
+}
+
+func (gt Grc20Transfer) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(gt)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// NftTransfer represents a transfer, mint or burn of a GRC721 token
+// recognised from the events emitted by the collection realm
+//
+// Stores:
+// - TxHash (bytea)
+// - ChainName (string)
+// - Timestamp (time.Time)
+// - EventIndex (int16, order of the event within the transaction)
+// - BlockHeight (uint64)
+// - CollectionPath (string, the realm that emitted the event)
+// - Symbol (string, the slug of the collection)
+// - TokenId (string)
+// - EventType (string, Transfer, Mint or Burn)
+// - FromAddress (int32, null for a mint)
+// - ToAddress (int32, null for a burn)
+//
+// PRIMARY KEY (tx_hash, chain_name, timestamp, event_index)
+type NftTransfer struct {
+	TxHash         []byte    `db:"tx_hash" dbtype:"bytea" nullable:"false" primary:"true"`
+	ChainName      string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Timestamp      time.Time `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	EventIndex     int16     `db:"event_index" dbtype:"smallint" nullable:"false" primary:"true"`
+	BlockHeight    uint64    `db:"block_height" dbtype:"bigint" nullable:"false" primary:"false"`
+	CollectionPath string    `db:"collection_path" dbtype:"TEXT" nullable:"false" primary:"false"`
+	Symbol         string    `db:"symbol" dbtype:"TEXT" nullable:"true" primary:"false"`
+	TokenId        string    `db:"token_id" dbtype:"TEXT" nullable:"false" primary:"false"`
+	EventType      string    `db:"event_type" dbtype:"TEXT" nullable:"false" primary:"false"`
+	FromAddress    *int32    `db:"from_address" dbtype:"integer" nullable:"true" primary:"false"`
+	ToAddress      *int32    `db:"to_address" dbtype:"integer" nullable:"true" primary:"false"`
+}
+
+// TableName returns the name of the table for the NftTransfer struct
+func (nt NftTransfer) TableName() string {
+	return "nft_transfers"
+}
+
+// GetTableInfo returns the table info for the NftTransfer struct
+func (nt NftTransfer) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(nt, nt.TableName())
+}
+
+func (nt NftTransfer) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(nt)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// NativeBalanceChange represents a change of the native coin balance of an address
+// derived from the bank sends, the coins sent with the vm messages, the fees and the storage
+// deposit events, the reconciliation with the node stores its corrections with an empty tx hash
+//
+// Stores:
+// - TxHash (bytea, empty for a reconciliation)
+// - ChainName (string)
+// - Timestamp (time.Time)
+// - ChangeIndex (int16, order of the change within the transaction)
+// - BlockHeight (uint64)
+// - Address (int32, pull from the gno_addresses table)
+// - Denom (string)
+// - Amount (numeric, negative when the coins leave the address)
+// - Source (string, fee, send, call_send, add_package_send, storage_deposit, storage_refund or reconcile)
+//
+// PRIMARY KEY (tx_hash, chain_name, timestamp, change_index)
+type NativeBalanceChange struct {
+	TxHash      []byte         `db:"tx_hash" dbtype:"bytea" nullable:"false" primary:"true"`
+	ChainName   string         `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Timestamp   time.Time      `db:"timestamp" dbtype:"timestamptz" nullable:"false" primary:"true"`
+	ChangeIndex int16          `db:"change_index" dbtype:"smallint" nullable:"false" primary:"true"`
+	BlockHeight uint64         `db:"block_height" dbtype:"bigint" nullable:"false" primary:"false"`
+	Address     int32          `db:"address" dbtype:"integer" nullable:"false" primary:"false"`
+	Denom       string         `db:"denom" dbtype:"TEXT" nullable:"false" primary:"false"`
+	Amount      pgtype.Numeric `db:"amount" dbtype:"NUMERIC" nullable:"false" primary:"false"`
+	Source      string         `db:"source" dbtype:"TEXT" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the NativeBalanceChange struct
+func (nc NativeBalanceChange) TableName() string {
+	return "native_balance_changes"
+}
+
+// GetTableInfo returns the table info for the NativeBalanceChange struct
+func (nc NativeBalanceChange) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(nc, nc.TableName())
+}
+
+func (nc NativeBalanceChange) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(nc)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
@@ -2,6 +2,9 @@ package sql_data_types
 
 import (
 	"reflect"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 
 	dbinit "github.com/Cogwheel-Validator/spectra-gnoland-indexer/indexer/db_init"
 )
@@ -43,6 +46,8 @@ func (ta *TxAddresses) GetAddressList() []int32 {
 // - Address (string)
 // - ID (int32)
 // - Chain ID (string)
+// - PubKeyType (string, null until a signature of the address includes the public key)
+// - PubKey (bytea, null until a signature of the address includes the public key)
 // PRIMARY KEY (id), UNIQUE (address, chain_id)
 type GnoAddress struct {
 	// any of the values can't be a null value and there shouldn't be any duplicates
@@ -50,6 +55,9 @@ type GnoAddress struct {
 	ID      int32  `db:"id" dbtype:"INTEGER GENERATED ALWAYS AS IDENTITY" nullable:"false" primary:"false" unique:"true"`
 	// use type enum chain_name from postgres
 	ChainName string `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"false" unique:"true"`
+	// the public key is filled from the first signature of the address that includes it
+	PubKeyType *string `db:"pub_key_type" dbtype:"TEXT" nullable:"true" primary:"false"`
+	PubKey     []byte  `db:"pub_key" dbtype:"bytea" nullable:"true" primary:"false"`
 }
 
 // TableName returns the name of the table for the GnoAddress struct
@@ -85,6 +93,231 @@ func (gv GnoValidatorAddress) GetTableInfo() (*dbinit.TableInfo, error) {
 	return dbinit.GetTableInfo(gv, gv.TableName())
 }
 
+// IndexerProgress represents a range of blocks that the indexer has fully processed
+// Stores:
+// - Chain Name (string)
+// - Running mode (string, live or historic)
+// - From height (uint64)
+// - To height (uint64)
+// - Completed at (time.Time)
+// PRIMARY KEY (chain_name, running_mode, from_height)
+type IndexerProgress struct {
+	ChainName   string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	RunningMode string    `db:"running_mode" dbtype:"TEXT" nullable:"false" primary:"true"`
+	FromHeight  uint64    `db:"from_height" dbtype:"BIGINT" nullable:"false" primary:"true"`
+	ToHeight    uint64    `db:"to_height" dbtype:"BIGINT" nullable:"false" primary:"false"`
+	CompletedAt time.Time `db:"completed_at" dbtype:"TIMESTAMPTZ" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the IndexerProgress struct
+func (ip IndexerProgress) TableName() string {
+	return "indexer_progress"
+}
+
+// GetTableInfo returns the table info for the IndexerProgress struct
+func (ip IndexerProgress) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(ip, ip.TableName())
+}
+
+// PackageFile represents a source file of a deployed package, stored once per unique file body
+// Stores:
+// - File hash (bytea, sha256 of the file body)
+// - Chain Name (string)
+// - Size (int32, size of the file body before the compression)
+// - Content (bytea, zstd compressed file body)
+// PRIMARY KEY (file_hash, chain_name)
+type PackageFile struct {
+	FileHash  []byte `db:"file_hash" dbtype:"BYTEA" nullable:"false" primary:"true"`
+	ChainName string `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Size      int32  `db:"size" dbtype:"INTEGER" nullable:"false" primary:"false"`
+	Content   []byte `db:"content" dbtype:"BYTEA" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the PackageFile struct
+func (pf PackageFile) TableName() string {
+	return "package_files"
+}
+
+// GetTableInfo returns the table info for the PackageFile struct
+func (pf PackageFile) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(pf, pf.TableName())
+}
+
+// A method to get the columns of the struct
+func (pf PackageFile) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(pf)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// Package represents a package added to the chain, the row holds its first deployment
+// Stores:
+// - Package path (string)
+// - Chain Name (string)
+// - Package name (string)
+// - Creator (int32, pull from the gno_addresses table)
+// - First height (uint64)
+// - First timestamp (time.Time)
+// - Tx hash (bytea, the transaction of the first deployment)
+// - File count (int32)
+// PRIMARY KEY (pkg_path, chain_name)
+type Package struct {
+	PkgPath        string    `db:"pkg_path" dbtype:"TEXT" nullable:"false" primary:"true"`
+	ChainName      string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	PkgName        string    `db:"pkg_name" dbtype:"TEXT" nullable:"false" primary:"false"`
+	Creator        int32     `db:"creator" dbtype:"INTEGER" nullable:"false" primary:"false"`
+	FirstHeight    uint64    `db:"first_height" dbtype:"BIGINT" nullable:"false" primary:"false"`
+	FirstTimestamp time.Time `db:"first_timestamp" dbtype:"TIMESTAMPTZ" nullable:"false" primary:"false"`
+	TxHash         []byte    `db:"tx_hash" dbtype:"BYTEA" nullable:"false" primary:"false"`
+	FileCount      int32     `db:"file_count" dbtype:"INTEGER" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the Package struct
+func (p Package) TableName() string {
+	return "packages"
+}
+
+// GetTableInfo returns the table info for the Package struct
+func (p Package) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(p, p.TableName())
+}
+
+// A method to get the columns of the struct
+func (p Package) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(p)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// Grc20Balance represents the balance of a GRC20 token held by an address
+// the balance is the sum of the stored transfers, it is calculated again for every
+// address that gets a new transfer so the chunks can be indexed in any order
+// Stores:
+// - Token path (string, the realm of the token)
+// - Chain Name (string)
+// - Address (int32, pull from the gno_addresses table)
+// - Balance (numeric)
+// - Last height (uint64, the height of the last transfer of the address)
+// PRIMARY KEY (token_path, chain_name, address)
+type Grc20Balance struct {
+	TokenPath  string         `db:"token_path" dbtype:"TEXT" nullable:"false" primary:"true"`
+	ChainName  string         `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Address    int32          `db:"address" dbtype:"INTEGER" nullable:"false" primary:"true"`
+	Balance    pgtype.Numeric `db:"balance" dbtype:"NUMERIC" nullable:"false" primary:"false"`
+	LastHeight uint64         `db:"last_height" dbtype:"BIGINT" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the Grc20Balance struct
+func (gb Grc20Balance) TableName() string {
+	return "grc20_balances"
+}
+
+// GetTableInfo returns the table info for the Grc20Balance struct
+func (gb Grc20Balance) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(gb, gb.TableName())
+}
+
+// A method to get the columns of the struct
+func (gb Grc20Balance) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(gb)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// NftOwner represents the current owner of a GRC721 token
+// the row is taken from the latest stored transfer of the token, it is chosen again for every
+// token that gets a new transfer so the chunks can be indexed in any order
+// Stores:
+// - Collection path (string, the realm of the collection)
+// - Chain Name (string)
+// - Token id (string)
+// - Owner (int32, pull from the gno_addresses table, null if the token is burned)
+// - Last height (uint64, the height of the latest transfer)
+// - Last timestamp (time.Time)
+// - Tx hash (bytea, the transaction of the latest transfer)
+// PRIMARY KEY (collection_path, chain_name, token_id)
+type NftOwner struct {
+	CollectionPath string    `db:"collection_path" dbtype:"TEXT" nullable:"false" primary:"true"`
+	ChainName      string    `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	TokenId        string    `db:"token_id" dbtype:"TEXT" nullable:"false" primary:"true"`
+	Owner          *int32    `db:"owner" dbtype:"INTEGER" nullable:"true" primary:"false"`
+	LastHeight     uint64    `db:"last_height" dbtype:"BIGINT" nullable:"false" primary:"false"`
+	LastTimestamp  time.Time `db:"last_timestamp" dbtype:"TIMESTAMPTZ" nullable:"false" primary:"false"`
+	TxHash         []byte    `db:"tx_hash" dbtype:"BYTEA" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the NftOwner struct
+func (no NftOwner) TableName() string {
+	return "nft_owners"
+}
+
+// GetTableInfo returns the table info for the NftOwner struct
+func (no NftOwner) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(no, no.TableName())
+}
+
+// A method to get the columns of the struct
+func (no NftOwner) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(no)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
+// NativeBalance represents the native coin balance of an address
+// the balance is the sum of the stored balance changes, it is calculated again for every
+// address that gets a new change so the chunks can be indexed in any order
+// Stores:
+// - Address (int32, pull from the gno_addresses table)
+// - Chain Name (string)
+// - Denom (string)
+// - Balance (numeric)
+// - Last height (uint64, the height of the last change of the address)
+// PRIMARY KEY (address, chain_name, denom)
+type NativeBalance struct {
+	Address    int32          `db:"address" dbtype:"INTEGER" nullable:"false" primary:"true"`
+	ChainName  string         `db:"chain_name" dbtype:"chain_name" nullable:"false" primary:"true"`
+	Denom      string         `db:"denom" dbtype:"TEXT" nullable:"false" primary:"true"`
+	Balance    pgtype.Numeric `db:"balance" dbtype:"NUMERIC" nullable:"false" primary:"false"`
+	LastHeight uint64         `db:"last_height" dbtype:"BIGINT" nullable:"false" primary:"false"`
+}
+
+// TableName returns the name of the table for the NativeBalance struct
+func (nb NativeBalance) TableName() string {
+	return "native_balances"
+}
+
+// GetTableInfo returns the table info for the NativeBalance struct
+func (nb NativeBalance) GetTableInfo() (*dbinit.TableInfo, error) {
+	return dbinit.GetTableInfo(nb, nb.TableName())
+}
+
+// A method to get the columns of the struct
+func (nb NativeBalance) TableColumns() []string {
+	columns := make([]string, 0)
+	fields := reflect.TypeOf(nb)
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		columns = append(columns, field.Tag.Get("db"))
+	}
+	return columns
+}
+
 // DBTable is an interface for structs that represent database tables
 type DBTable interface {
 	GetTableInfo() (*dbinit.TableInfo, error)
@@ -132,13 +365,29 @@ func AllTableNames() []string {
 		GnoValidatorAddress{},
 		Blocks{},
 		ValidatorBlockSigning{},
+		ValidatorSet{},
+		ValidatorMissedBlock{},
+		ValidatorUpdate{},
+		BlockEvent{},
 		AddressTx{},
 		TransactionGeneral{},
 		MsgSend{},
 		MsgCall{},
 		MsgAddPackage{},
 		MsgRun{},
+		MsgGeneric{},
+		TxEvent{},
+		TxSignature{},
 		ApiKey{},
+		IndexerProgress{},
+		PackageFile{},
+		Package{},
+		Grc20Transfer{},
+		Grc20Balance{},
+		NftTransfer{},
+		NftOwner{},
+		NativeBalanceChange{},
+		NativeBalance{},
 	}
 	names := make([]string, len(tables))
 	for i, t := range tables {